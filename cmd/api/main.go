@@ -8,6 +8,8 @@ import (
 	"os"
 	"os/signal"
 	"requester/internal/api"
+	"requester/internal/destination"
+	"requester/internal/encryption"
 	"requester/internal/logger"
 	"requester/internal/queue"
 	"requester/internal/repository"
@@ -36,12 +38,40 @@ func main() {
 	queueSvc := queue.New(&queueConfig)
 
 	cfg := api.MustConfig(api.LoadConfig())
-	taskQueueUrl, err := queueSvc.GetQueueURL(ctx, cfg.TaskQueue)
+	taskQueues, err := queue.NewTaskQueues(queueSvc, cfg.TaskQueue)
 	if err != nil {
-		logg.Fatal("Unable to get task queue url", zap.Error(err))
+		logg.Fatal("Unable to create task queues", zap.Error(err))
 	}
+	err = taskQueues.Prepare(ctx, append([]string{cfg.TaskQueue}, cfg.RoutingRules.Queues()...)...)
+	if err != nil {
+		logg.Fatal("Unable to get task queue urls", zap.Error(err))
+	}
+
+	policy, err := destination.NewPolicy(destination.MustConfig(destination.LoadConfig()))
+	if err != nil {
+		logg.Fatal("Unable to create destination policy", zap.Error(err))
+	}
+
+	encryptionCfg := encryption.MustConfig(encryption.LoadConfig())
+	cipher := encryption.MustCipher(encryptionCfg)
+	keyring := encryption.MustKeyring(encryptionCfg)
+
+	taskListener, err := repository.NewTaskListener(dbPool)
+	if err != nil {
+		logg.Fatal("Unable to create task listener", zap.Error(err))
+	}
+	go func() {
+		for {
+			err := taskListener.Listen(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			logg.Error("Task listener stopped, reconnecting", zap.Error(err))
+			time.Sleep(time.Second)
+		}
+	}()
 
-	h, err := api.NewHandler(&cfg, queueSvc, taskQueueUrl, dbPool, logg)
+	h, err := api.NewHandler(&cfg, queueSvc, taskQueues, taskListener, policy, cipher, keyring, dbPool, logg)
 	if err != nil {
 		logg.Fatal("Unable to create API handler", zap.Error(err))
 	}
@@ -49,7 +79,7 @@ func main() {
 		Addr:         cfg.ListenAddress,
 		Handler:      h,
 		ReadTimeout:  3 * time.Second,
-		WriteTimeout: 6 * time.Second,
+		WriteTimeout: cfg.MaxResponseDuration() + 6*time.Second,
 		IdleTimeout:  3 * time.Second,
 	}
 
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"requester/internal/destination"
+	"requester/internal/encryption"
 	"requester/internal/logger"
 	"requester/internal/queue"
 	"requester/internal/repository"
@@ -37,30 +39,80 @@ func main() {
 	queueSvc := queue.New(&queueConfig)
 
 	cfg := requester.MustConfig(requester.LoadConfig())
-	taskQueueUrl, err := queueSvc.GetQueueURL(ctx, cfg.TaskQueue)
+	taskQueues, err := queue.NewTaskQueues(queueSvc, cfg.TaskQueue)
 	if err != nil {
-		logg.Fatal("Unable to get task queue url", zap.Error(err))
+		logg.Fatal("Unable to create task queues", zap.Error(err))
+	}
+	queues, err := requester.PriorityQueues(ctx, taskQueues, cfg.WorkerQueues(), cfg.PriorityWeights)
+	if err != nil {
+		logg.Fatal("Unable to get task queue urls", zap.Error(err))
 	}
 
+	policy, err := destination.NewPolicy(destination.MustConfig(destination.LoadConfig()))
+	if err != nil {
+		logg.Fatal("Unable to create destination policy", zap.Error(err))
+	}
+
+	encryptionCfg := encryption.MustConfig(encryption.LoadConfig())
+	cipher := encryption.MustCipher(encryptionCfg)
+	keyring := encryption.MustKeyring(encryptionCfg)
+
 	client := &http.Client{
 		Timeout: 10 * time.Second,
 		Transport: &http.Transport{
-			DialContext: (&net.Dialer{
-				Timeout: 5 * time.Second,
-			}).DialContext,
+			DialContext: destination.DialContext(
+				&net.Dialer{Timeout: 5 * time.Second},
+				policy,
+			),
 			TLSHandshakeTimeout: 5 * time.Second,
 		},
 	}
-	processor, err := requester.New(repository.NewTaskDB(dbPool), client, logg)
+	metrics, err := requester.NewMetrics()
+	if err != nil {
+		logg.Fatal("Unable to create metrics", zap.Error(err))
+	}
+	breakers, err := requester.NewBreakers(cfg.BreakerConfig, metrics.MeterProvider())
+	if err != nil {
+		logg.Fatal("Unable to create circuit breakers", zap.Error(err))
+	}
+	processor, err := requester.New(
+		repository.NewTaskDB(dbPool, keyring),
+		repository.NewHostDB(dbPool),
+		repository.NewDestinationDB(dbPool),
+		repository.NewSecretDB(dbPool, cipher),
+		repository.NewOAuth2ProfileDB(dbPool),
+		repository.NewTLSProfileDB(dbPool, cipher),
+		queueSvc,
+		taskQueues,
+		breakers,
+		policy,
+		client,
+		&cfg,
+		logg,
+	)
 	if err != nil {
 		logg.Fatal("Unable to create processor", zap.Error(err))
 	}
-	instance, err := requester.NewWorker(taskQueueUrl, cfg.Workers, queueSvc, processor, logg)
+	instance, err := requester.NewWorker(queues, cfg.Workers, queueSvc, processor, logg)
 	if err != nil {
 		logg.Fatal("Unable to create worker", zap.Error(err))
 	}
 
-	logg.Info("Waiting for messages")
+	adminServer := http.Server{
+		Addr:         cfg.AdminListenAddress,
+		Handler:      requester.NewAdminHandler(breakers, metrics, logg),
+		ReadTimeout:  3 * time.Second,
+		WriteTimeout: 6 * time.Second,
+		IdleTimeout:  3 * time.Second,
+	}
+	go func() {
+		logg.Info("Admin server starting...", zap.String("address", cfg.AdminListenAddress))
+		if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logg.Fatal("Unable to start admin server", zap.Error(err))
+		}
+	}()
+
+	logg.Info("Waiting for messages", zap.Strings("queues", cfg.WorkerQueues()))
 	go instance.WatchMessages(ctx)
 
 	signalChan := make(chan os.Signal, 1)
@@ -68,4 +120,10 @@ func main() {
 	<-signalChan
 
 	logg.Info("Stopping worker...")
+	if err := adminServer.Shutdown(ctx); err != nil {
+		logg.Error("Unable to shut down admin server", zap.Error(err))
+	}
+	if err := metrics.Shutdown(ctx); err != nil {
+		logg.Error("Unable to shut down metrics", zap.Error(err))
+	}
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"requester/internal/encryption"
+	"requester/internal/repository"
+
+	_ "github.com/joho/godotenv/autoload"
+)
+
+var batchSize int
+
+func init() {
+	flag.IntVar(&batchSize, "batch", 100, "number of tasks re-encrypted in one transaction")
+	flag.Parse()
+}
+
+func main() {
+	ctx := context.Background()
+
+	dbCfg, err := repository.LoadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	dbPool := repository.MustPool(repository.SetupPool(ctx, dbCfg))
+	defer dbPool.Close()
+
+	keyring := encryption.MustKeyring(encryption.MustConfig(encryption.LoadConfig()))
+
+	total := 0
+	for {
+		count, err := repository.RotateTaskKeys(ctx, dbPool, keyring, batchSize)
+		if err != nil {
+			log.Fatalf("rotate task keys: %v", err)
+		}
+		if count == 0 {
+			break
+		}
+		total += count
+		log.Printf("re-encrypted %d tasks", total)
+	}
+	log.Printf("all tasks are encrypted with key %s", keyring.PrimaryID())
+}
@@ -2,7 +2,9 @@ package api
 
 import (
 	"github.com/kelseyhightower/envconfig"
+	"requester/internal/routing"
 	"strings"
+	"time"
 )
 
 // Config for API.
@@ -10,6 +12,37 @@ type Config struct {
 	MountPrefix   string `envconfig:"MOUNT_PREFIX" default:"/api/v1"`
 	ListenAddress string `envconfig:"LISTEN_ADDR" default:":3000"`
 	TaskQueue     string `envconfig:"TASK_QUEUE" default:"task-queue"`
+	// MaxTaskWait caps the time task creation waits for the task to finish.
+	MaxTaskWait time.Duration `envconfig:"MAX_TASK_WAIT" default:"10s"`
+	// MaxStatusWait caps the time getting the task status waits for it to change.
+	MaxStatusWait time.Duration `envconfig:"MAX_STATUS_WAIT" default:"30s"`
+	// EventStreamDuration is the time after which event streams are closed, so clients reconnect.
+	EventStreamDuration time.Duration `envconfig:"EVENT_STREAM_DURATION" default:"60s"`
+	// RoutingRules route tasks to queues by the URL host, the client and the label, the first match wins.
+	// Tasks matching no rule go to TASK_QUEUE.
+	RoutingRules routing.Rules `envconfig:"ROUTING_RULES"`
+	LimitsConfig
+}
+
+// LimitsConfig is the default per-client limits on task creation.
+// Zero value disables the limit.
+type LimitsConfig struct {
+	RequestsPerSecond   int           `envconfig:"LIMIT_REQUESTS_PER_SECOND" default:"0"`
+	MaxOutstandingTasks int           `envconfig:"LIMIT_MAX_OUTSTANDING_TASKS" default:"0"`
+	DailyTaskQuota      int           `envconfig:"LIMIT_DAILY_TASK_QUOTA" default:"0"`
+	OutstandingRetry    time.Duration `envconfig:"LIMIT_OUTSTANDING_RETRY_AFTER" default:"10s"`
+}
+
+// MaxResponseDuration returns the longest time a response is held open waiting for task updates.
+func (c Config) MaxResponseDuration() time.Duration {
+	max := c.MaxTaskWait
+	if c.MaxStatusWait > max {
+		max = c.MaxStatusWait
+	}
+	if c.EventStreamDuration > max {
+		max = c.EventStreamDuration
+	}
+	return max
 }
 
 // LoadConfig loads envs.
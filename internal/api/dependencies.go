@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"requester/internal/api/oas"
+	"requester/internal/dependency"
+	"requester/internal/models"
+)
+
+// taskDependsOn validates the parents of the task input and returns their deduplicated IDs.
+// Parents must be tasks of the same client, referenced values must be extracted by the parents.
+// Returns an error response if the parents are invalid.
+func (h *handler) taskDependsOn(
+	ctx context.Context,
+	clientID string,
+	req *oas.CreateTaskInput,
+) ([]uuid.UUID, *oas.ErrorOutput, error) {
+	var dependsOn []uuid.UUID
+	parents := make(map[uuid.UUID]*models.TaskWithResponseData, len(req.DependsOn))
+	for _, id := range req.DependsOn {
+		if _, ok := parents[id]; ok {
+			continue
+		}
+		parent, exists, err := h.taskRepository.GetTask(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exists || parent.ClientID != clientID {
+			return nil, invalidDependsOn(fmt.Sprintf("task %s not found", id)), nil
+		}
+		parents[id] = parent
+		dependsOn = append(dependsOn, id)
+	}
+
+	refs := dependency.References(&models.Task{URL: req.URL.Value, Headers: req.Headers.Value, Body: req.Body.Value})
+	for _, ref := range refs {
+		parent, ok := parents[ref.ParentID]
+		if !ok {
+			return nil, invalidDependsOn(fmt.Sprintf("referenced task %s is not a parent", ref.ParentID)), nil
+		}
+		if _, ok = parent.Extract[ref.Name]; !ok {
+			return nil, invalidDependsOn(fmt.Sprintf("value %s isn't extracted by task %s", ref.Name, ref.ParentID)), nil
+		}
+	}
+	return dependsOn, nil, nil
+}
+
+// invalidDependsOn returns the error response of invalid parents.
+func invalidDependsOn(reason string) *oas.ErrorOutput {
+	return &oas.ErrorOutput{ErrorMessage: "Invalid depends_on: " + reason}
+}
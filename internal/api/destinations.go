@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"requester/internal/api/oas"
+	"requester/internal/destination"
+)
+
+// getClientPolicy returns the destination policy extended with the client rules.
+func (h *handler) getClientPolicy(ctx context.Context, clientID string) (*destination.Policy, error) {
+	rules, exists, err := h.destinationRepository.GetDestinationRules(ctx, clientID)
+	if err != nil || !exists {
+		return h.policy, err
+	}
+	return h.policy.WithRules(rules)
+}
+
+// validateTaskURL validates the task URL and checks it against the client destination policy.
+// Returns nil if the URL is valid.
+func (h *handler) validateTaskURL(ctx context.Context, clientID, rawURL string) (*oas.ErrorOutput, error) {
+	u, err := destination.ParseURL(rawURL)
+	if err != nil {
+		return &oas.ErrorOutput{ErrorMessage: "Invalid URL: " + err.Error()}, nil
+	}
+
+	policy, err := h.getClientPolicy(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if err = policy.CheckURL(u); err != nil {
+		var forbiddenErr *destination.ForbiddenError
+		if errors.As(err, &forbiddenErr) {
+			return &oas.ErrorOutput{ErrorMessage: "Invalid URL: " + err.Error()}, nil
+		}
+		return nil, err
+	}
+	return nil, nil
+}
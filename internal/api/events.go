@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	"net/http"
+	"requester/internal/api/oas"
+	"requester/internal/repository"
+	"time"
+)
+
+// maxEventTaskIDs is the maximum number of task IDs an event stream is filtered by.
+const maxEventTaskIDs = 100
+
+// eventsKeepAlive is the interval of comments keeping idle event streams open.
+const eventsKeepAlive = 15 * time.Second
+
+// eventsHandler streams task status updates as Server-Sent Events.
+// It's served next to the generated server, which doesn't flush streamed responses.
+type eventsHandler struct {
+	h *handler
+}
+
+// ServeHTTP streams updates of the tasks of the client of the X-Client-Id header, only of the tasks
+// of the task_id query parameters if any, starting with the current status of the requested tasks.
+// The stream is closed after the configured duration or if updates may have been missed, so the client reconnects.
+// Responds with 400 if the client is missing or the task IDs are invalid.
+func (e eventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed.")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "Streaming is not supported.")
+		return
+	}
+
+	// Streams are scoped to the client, so tasks of other clients can't be watched by their IDs.
+	filter := repository.TaskFilter{ClientID: r.Header.Get("X-Client-Id")}
+	if filter.ClientID == "" {
+		writeError(w, http.StatusBadRequest, "X-Client-Id header is required.")
+		return
+	}
+	taskIDs := r.URL.Query()["task_id"]
+	if len(taskIDs) > maxEventTaskIDs {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("At most %d task IDs are allowed.", maxEventTaskIDs))
+		return
+	}
+	for _, value := range taskIDs {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid task ID "+value+".")
+			return
+		}
+		filter.TaskIDs = append(filter.TaskIDs, id)
+	}
+	ctx := r.Context()
+	updates, unsubscribe := e.h.taskWatcher.Subscribe(filter)
+	defer unsubscribe()
+
+	// The current status is read after subscribing, so no update after the read is missed.
+	initial := make([]*repository.TaskUpdate, 0, len(filter.TaskIDs))
+	for _, id := range filter.TaskIDs {
+		task, exists, err := e.h.taskRepository.GetTask(ctx, id)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Unable to get task status.")
+			return
+		}
+		if exists && task.ClientID == filter.ClientID {
+			initial = append(initial, &repository.TaskUpdate{ID: task.ID, ClientID: task.ClientID, Status: task.Status})
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprint(w, "retry: 1000\n\n"); err != nil {
+		return
+	}
+	for _, update := range initial {
+		if writeEvent(w, update) != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+	end := time.NewTimer(e.h.cfg.EventStreamDuration)
+	defer end.Stop()
+
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			if writeEvent(w, update) != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case <-end.C:
+			return
+		case <-ctx.Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes the task update as a status event.
+func writeEvent(w http.ResponseWriter, update *repository.TaskUpdate) error {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: status\ndata: %s\n\n", data)
+	return err
+}
+
+// writeError writes the error response.
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	e := jx.GetEncoder()
+	(&oas.ErrorOutput{ErrorMessage: message}).Encode(e)
+	_, _ = w.Write(e.Bytes())
+}
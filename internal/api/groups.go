@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"requester/internal/api/oas"
+	"requester/internal/models"
+	"requester/internal/repository"
+	"time"
+)
+
+// CreateTaskFanout creates a task group with a task for each target of the request template.
+// Responds with 400 if the template or any target is invalid as a task
+// and with 429 if the client has exceeded its limits.
+// Tasks which can't be sent are errored and the rest are sent, responds with 500 then.
+func (h *handler) CreateTaskFanout(
+	ctx context.Context,
+	req *oas.FanoutInput,
+	params oas.CreateTaskFanoutParams,
+) (oas.CreateTaskFanoutRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	clientID := params.XClientID.Value
+	invalid, err := h.applyTemplate(ctx, clientID, &req.Request)
+	if err != nil {
+		return nil, err
+	}
+	if invalid != nil {
+		return invalid, nil
+	}
+
+	input := &repository.CreateTaskGroupInput{ClientID: clientID}
+	for i, target := range req.Targets {
+		request := req.Request
+		if url, ok := target.URL.Get(); ok {
+			request.URL = oas.NewOptString(url)
+		}
+		if targetVariables, ok := target.Variables.Get(); ok {
+			request.Variables = oas.NewOptCreateTaskInputVariables(
+				mergeVariables(req.Request.Variables.Value, targetVariables),
+			)
+		}
+
+		taskInput, invalid, err := h.taskInput(ctx, clientID, &request)
+		if err != nil {
+			return nil, err
+		}
+		if invalid == nil {
+			taskInput.DependsOn, invalid, err = h.taskDependsOn(ctx, clientID, &request)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if invalid != nil {
+			invalid.ErrorMessage = fmt.Sprintf("Target %d: %s", i, invalid.ErrorMessage)
+			return invalid, nil
+		}
+		input.Tasks = append(input.Tasks, taskInput)
+	}
+
+	limits, exceeded, err := h.checkClientLimits(ctx, clientID, len(input.Tasks))
+	if err != nil {
+		return nil, err
+	}
+	if exceeded != nil {
+		return exceeded.response(), nil
+	}
+
+	for _, taskInput := range input.Tasks {
+		taskInput.MaxOutstandingTasks = limits.MaxOutstandingTasks
+	}
+	input.CounterLimits = limits.counterLimits(len(input.Tasks))
+	group, err := h.taskGroupRepository.CreateTaskGroup(ctx, input)
+	if exceeded = creationExceeded(err, limits); exceeded != nil {
+		return exceeded.response(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// All group tasks share the parents, so they are either all new or all waiting or skipped.
+	// The rest of the tasks are sent even if one can't be, it's errored then, so the group still completes.
+	if group.Counts[models.TaskStatusNew] > 0 {
+		var sendErr error
+		for i, taskID := range group.TaskIDs {
+			if err = h.sendTask(ctx, input.Tasks[i].QueuedTask(taskID)); err != nil && sendErr == nil {
+				sendErr = err
+			}
+		}
+		if sendErr != nil {
+			return nil, sendErr
+		}
+	}
+
+	return &oas.FanoutOutput{GroupID: group.ID, TaskIds: group.TaskIDs}, nil
+}
+
+// mergeVariables returns the variables overridden by the overrides.
+func mergeVariables(variables, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(variables)+len(overrides))
+	for k, v := range variables {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+// GetTaskGroup returns task group progress.
+func (h *handler) GetTaskGroup(ctx context.Context, params oas.GetTaskGroupParams) (oas.GetTaskGroupRes, error) {
+	group, exists, err := h.taskGroupRepository.GetTaskGroup(ctx, params.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &oas.GetTaskGroupNotFound{}, nil
+	}
+
+	counts := make(oas.TaskGroupOutputCounts, len(group.Counts))
+	for status, count := range group.Counts {
+		counts[string(status)] = count
+	}
+	return &oas.TaskGroupOutput{
+		ID:        group.ID,
+		Total:     group.Total(),
+		Counts:    counts,
+		Complete:  group.Complete(),
+		CreatedAt: group.CreatedAt,
+	}, nil
+}
@@ -10,29 +10,53 @@ import (
 	"go.uber.org/zap"
 	"net/http"
 	"requester/internal/api/oas"
+	"requester/internal/destination"
+	"requester/internal/encryption"
+	"requester/internal/queue"
 	"requester/internal/repository"
 	"runtime/debug"
 	"time"
 )
 
 // taskSender is an interface for sending messages to the task queue.
+// Messages with the same ordering key are delivered in order by FIFO queues.
 type taskSender interface {
-	SendMessage(ctx context.Context, url *string, data interface{}) error
+	SendMessage(ctx context.Context, url *string, data interface{}, orderingKey string) error
+}
+
+// taskWatcher is an interface for watching task status updates.
+// The updates channel is closed if updates may have been missed.
+type taskWatcher interface {
+	Subscribe(filter repository.TaskFilter) (updates <-chan *repository.TaskUpdate, unsubscribe func())
 }
 
 // handler is an implementation of oas.Handler.
 type handler struct {
-	taskSender     taskSender
-	taskQueueUrl   *string
-	cfg            *Config
-	taskRepository repository.TaskRepository
+	taskSender              taskSender
+	taskQueues              *queue.TaskQueues
+	taskWatcher             taskWatcher
+	cfg                     *Config
+	policy                  *destination.Policy
+	taskRepository          repository.TaskRepository
+	limitRepository         repository.LimitRepository
+	destinationRepository   repository.DestinationRepository
+	secretRepository        repository.SecretRepository
+	oauth2ProfileRepository repository.OAuth2ProfileRepository
+	tlsProfileRepository    repository.TLSProfileRepository
+	workflowRepository      repository.WorkflowRepository
+	taskGroupRepository     repository.TaskGroupRepository
+	templateRepository      repository.TemplateRepository
 }
 
 // newServer creates a new server and handler.
 func newServer(
 	cfg *Config,
 	taskSender taskSender,
-	taskQueueUrl *string,
+	taskQueues *queue.TaskQueues,
+	taskWatcher taskWatcher,
+	policy *destination.Policy,
+	cipher *encryption.Cipher,
+	keyring *encryption.Keyring,
 	dbPool *pgxpool.Pool,
 	logger *zap.Logger,
 ) (*oas.Server, *handler, error) {
@@ -42,14 +66,39 @@ func newServer(
 	if taskSender == nil {
 		return nil, nil, errors.New("must specify taskSender")
 	}
+	if taskQueues == nil {
+		return nil, nil, errors.New("must specify *queue.TaskQueues")
+	}
+	if taskWatcher == nil {
+		return nil, nil, errors.New("must specify taskWatcher")
+	}
+	if policy == nil {
+		return nil, nil, errors.New("must specify *destination.Policy")
+	}
+	if cipher == nil {
+		return nil, nil, errors.New("must specify *encryption.Cipher")
+	}
+	if keyring == nil {
+		return nil, nil, errors.New("must specify *encryption.Keyring")
+	}
 	if dbPool == nil {
 		return nil, nil, errors.New("must specify *pgxpool.Pool")
 	}
 	h := &handler{
-		cfg:            cfg,
-		taskSender:     taskSender,
-		taskQueueUrl:   taskQueueUrl,
-		taskRepository: repository.NewTaskDB(dbPool),
+		cfg:                     cfg,
+		policy:                  policy,
+		taskSender:              taskSender,
+		taskQueues:              taskQueues,
+		taskWatcher:             taskWatcher,
+		taskRepository:          repository.NewTaskDB(dbPool, keyring),
+		limitRepository:         repository.NewLimitDB(dbPool),
+		destinationRepository:   repository.NewDestinationDB(dbPool),
+		secretRepository:        repository.NewSecretDB(dbPool, cipher),
+		oauth2ProfileRepository: repository.NewOAuth2ProfileDB(dbPool),
+		tlsProfileRepository:    repository.NewTLSProfileDB(dbPool, cipher),
+		workflowRepository:      repository.NewWorkflowDB(dbPool, keyring),
+		taskGroupRepository:     repository.NewTaskGroupDB(dbPool, keyring),
+		templateRepository:      repository.NewTemplateDB(dbPool),
 	}
 	srv, err := oas.NewServer(h, oas.WithErrorHandler(getErrorHandler(logger)))
 	if err != nil {
@@ -62,7 +111,11 @@ func newServer(
 func NewHandler(
 	cfg *Config,
 	taskSender taskSender,
-	taskQueueUrl *string,
+	taskQueues *queue.TaskQueues,
+	taskWatcher taskWatcher,
+	policy *destination.Policy,
+	cipher *encryption.Cipher,
+	keyring *encryption.Keyring,
 	dbPool *pgxpool.Pool,
 	logger *zap.Logger,
 ) (http.Handler, error) {
@@ -70,13 +123,14 @@ func NewHandler(
 		return nil, errors.New("must specify *zap.Logger")
 	}
 
-	srv, _, err := newServer(cfg, taskSender, taskQueueUrl, dbPool, logger)
+	srv, h, err := newServer(cfg, taskSender, taskQueues, taskWatcher, policy, cipher, keyring, dbPool, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	mux := http.NewServeMux()
 	mux.Handle(cfg.MountPrefix+"/", http.StripPrefix(cfg.MountPrefix, srv))
+	mux.Handle(cfg.MountPrefix+"/tasks/events", eventsHandler{h})
 	docsPath := cfg.MountPrefix + "/docs"
 	mux.Handle(docsPath+"/", http.StripPrefix(docsPath, http.FileServer(http.Dir("./api"))))
 
@@ -126,6 +180,13 @@ func (w *responseWriter) WriteHeader(code int) {
 	w.ResponseWriter.WriteHeader(code)
 }
 
+// Flush flushes the underlying writer if it supports flushing, so event streams aren't buffered.
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 // loggingMiddleware is a middleware for logging http requests.
 type loggingMiddleware struct {
 	Next   http.Handler
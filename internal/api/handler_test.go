@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"errors"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4/pgxpool"
 	_ "github.com/joho/godotenv/autoload"
 	"github.com/stretchr/testify/mock"
@@ -10,8 +12,14 @@ import (
 	"go.uber.org/zap/zaptest"
 	"net/http"
 	"net/http/httptest"
+	"requester/internal/destination"
+	"requester/internal/encryption"
+	"requester/internal/models"
+	"requester/internal/queue"
 	"requester/internal/repository"
+	"sync"
 	"testing"
+	"time"
 )
 
 var dbPool *pgxpool.Pool
@@ -20,11 +28,109 @@ type testTaskSender struct {
 	mock.Mock
 }
 
-func (s *testTaskSender) SendMessage(ctx context.Context, url *string, data interface{}) error {
-	args := s.Called(ctx, url, data)
+func (s *testTaskSender) SendMessage(ctx context.Context, url *string, data interface{}, orderingKey string) error {
+	args := s.Called(ctx, url, data, orderingKey)
 	return args.Error(0)
 }
 
+// testTaskWatcher is a taskWatcher notified by tests, since updates in rolled back transactions aren't.
+type testTaskWatcher struct {
+	mu          sync.Mutex
+	subscribers map[chan *repository.TaskUpdate]repository.TaskFilter
+}
+
+func newTestTaskWatcher() *testTaskWatcher {
+	return &testTaskWatcher{subscribers: make(map[chan *repository.TaskUpdate]repository.TaskFilter)}
+}
+
+func (w *testTaskWatcher) Subscribe(filter repository.TaskFilter) (<-chan *repository.TaskUpdate, func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ch := make(chan *repository.TaskUpdate, 16)
+	w.subscribers[ch] = filter
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subscribers, ch)
+	}
+}
+
+// notify sends the update to the matching subscribers.
+func (w *testTaskWatcher) notify(update *repository.TaskUpdate) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ch, filter := range w.subscribers {
+		if filter.Matches(update) {
+			ch <- update
+		}
+	}
+}
+
+// subscribed reports whether anyone is subscribed.
+func (w *testTaskWatcher) subscribed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.subscribers) > 0
+}
+
+// testQueueURLGetter returns URLs of queues by their names.
+type testQueueURLGetter struct{}
+
+func (testQueueURLGetter) GetQueueURL(_ context.Context, queue string) (*string, error) {
+	return testTaskQueueURL(queue, models.TaskPriorityNormal), nil
+}
+
+// newTestTaskQueues returns queues with the default test-queue.
+func newTestTaskQueues() *queue.TaskQueues {
+	queues, err := queue.NewTaskQueues(testQueueURLGetter{}, "test-queue")
+	if err != nil {
+		panic(err)
+	}
+	return queues
+}
+
+// testTaskQueueURL returns the URL of the test task queue of the name and priority.
+func testTaskQueueURL(name string, priority models.TaskPriority) *string {
+	url := "sqs://" + queue.TaskQueueName(name, priority)
+	return &url
+}
+
+// setTaskStatus moves the task through the statuses, each of them must be allowed after the previous one.
+func setTaskStatus(
+	ctx context.Context, taskRepository repository.TaskRepository, taskID uuid.UUID, statuses ...models.TaskStatus,
+) error {
+	for _, status := range statuses {
+		err := taskRepository.UpdateTask(ctx, &repository.UpdateTaskInput{ID: taskID, Status: status.Pointer()})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subscribeListener subscribes to the task listener once it listens.
+// Subscriptions are closed when listening starts, so the task is probed with status updates until one arrives.
+func subscribeListener(
+	ctx context.Context, listener *repository.TaskListener, filter repository.TaskFilter, probeID uuid.UUID,
+) (<-chan *repository.TaskUpdate, func(), error) {
+	for attempt := 0; attempt < 50; attempt++ {
+		updates, unsubscribe := listener.Subscribe(filter)
+		if _, err := dbPool.Exec(ctx, "UPDATE tasks SET status = status WHERE id = $1", probeID); err != nil {
+			unsubscribe()
+			return nil, nil, err
+		}
+		select {
+		case _, ok := <-updates:
+			if ok {
+				return updates, unsubscribe, nil
+			}
+		case <-time.After(100 * time.Millisecond):
+		}
+		unsubscribe()
+	}
+	return nil, nil, errors.New("task listener doesn't listen")
+}
+
 func TestMain(m *testing.M) {
 	ctx := context.Background()
 
@@ -37,10 +143,17 @@ func TestMain(m *testing.M) {
 
 func Test_HandleHealthStatus(t *testing.T) {
 	config := MustConfig(LoadConfig())
-	url := "sqs://test-queue"
+	queues := newTestTaskQueues()
 	logger := zaptest.NewLogger(t, zaptest.Level(zap.PanicLevel))
 
-	h, err := NewHandler(&config, &testTaskSender{}, &url, dbPool, logger)
+	policy, err := destination.NewPolicy(destination.Config{})
+	require.NoError(t, err)
+
+	encryptionCfg := encryption.MustConfig(encryption.LoadConfig())
+	cipher := encryption.MustCipher(encryptionCfg)
+	keyring := encryption.MustKeyring(encryptionCfg)
+
+	h, err := NewHandler(&config, &testTaskSender{}, queues, newTestTaskWatcher(), policy, cipher, keyring, dbPool, logger)
 	require.NoError(t, err)
 
 	req := httptest.NewRequest(http.MethodGet, config.MountPrefix+"/health", nil)
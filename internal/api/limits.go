@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"requester/internal/api/oas"
+	"requester/internal/models"
+	"requester/internal/repository"
+	"time"
+)
+
+// limitExceeded describes the client limit that has been exceeded.
+type limitExceeded struct {
+	message    string
+	retryAfter time.Duration
+}
+
+// response returns the 429 response for the exceeded limit.
+// Retry-After is rounded up to whole seconds and is at least one second.
+func (e *limitExceeded) response() *oas.ErrorOutputHeaders {
+	retryAfter := int(math.Ceil(e.retryAfter.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	return &oas.ErrorOutputHeaders{
+		RetryAfter: retryAfter,
+		Response:   oas.ErrorOutput{ErrorMessage: e.message},
+	}
+}
+
+// getClientLimits returns the default limits merged with the client overrides.
+func (h *handler) getClientLimits(ctx context.Context, clientID string) (LimitsConfig, error) {
+	limits := h.cfg.LimitsConfig
+	overrides, exists, err := h.limitRepository.GetClientLimits(ctx, clientID)
+	if err != nil || !exists {
+		return limits, err
+	}
+	if overrides.RequestsPerSecond != nil {
+		limits.RequestsPerSecond = *overrides.RequestsPerSecond
+	}
+	if overrides.MaxOutstandingTasks != nil {
+		limits.MaxOutstandingTasks = *overrides.MaxOutstandingTasks
+	}
+	if overrides.DailyTaskQuota != nil {
+		limits.DailyTaskQuota = *overrides.DailyTaskQuota
+	}
+	return limits, nil
+}
+
+// checkClientLimits checks the client outstanding tasks limit on creation of the number of tasks
+// by a single request. Returns the client limits and nil if the client is allowed to create the tasks.
+// Rate counters are incremented and the outstanding tasks limit is checked again on creation
+// of the tasks, see creationExceeded.
+func (h *handler) checkClientLimits(
+	ctx context.Context,
+	clientID string,
+	tasks int,
+) (LimitsConfig, *limitExceeded, error) {
+	limits, err := h.getClientLimits(ctx, clientID)
+	if err != nil {
+		return limits, nil, err
+	}
+
+	if limits.MaxOutstandingTasks > 0 {
+		count, err := h.limitRepository.CountOutstandingTasks(ctx, clientID)
+		if err != nil {
+			return limits, nil, err
+		}
+		if count+tasks > limits.MaxOutstandingTasks {
+			return limits, outstandingLimitExceeded(limits), nil
+		}
+	}
+
+	return limits, nil, nil
+}
+
+// counterLimits returns the limits of the client rate counters incremented on creation
+// of the number of tasks by a single request.
+func (c LimitsConfig) counterLimits(tasks int) []repository.CounterLimit {
+	var limits []repository.CounterLimit
+	if c.RequestsPerSecond > 0 {
+		limits = append(limits, repository.CounterLimit{
+			Bucket: models.RateBucketSecond,
+			Limit:  c.RequestsPerSecond,
+			N:      1,
+		})
+	}
+	if c.DailyTaskQuota > 0 {
+		limits = append(limits, repository.CounterLimit{
+			Bucket: models.RateBucketDay,
+			Limit:  c.DailyTaskQuota,
+			N:      tasks,
+		})
+	}
+	return limits
+}
+
+// creationExceeded returns the exceeded limit if the tasks creation has failed on a rate counter
+// or on the outstanding tasks limit, since concurrent requests may pass checkClientLimits together.
+func creationExceeded(err error, limits LimitsConfig) *limitExceeded {
+	var counterErr *repository.CounterLimitError
+	if errors.As(err, &counterErr) {
+		message := "Requests per second limit exceeded."
+		if counterErr.Bucket == models.RateBucketDay {
+			message = "Daily task quota exceeded."
+		}
+		return &limitExceeded{message: message, retryAfter: counterErr.ResetsIn}
+	}
+	var limitErr *repository.OutstandingLimitError
+	if errors.As(err, &limitErr) {
+		return outstandingLimitExceeded(limits)
+	}
+	return nil
+}
+
+// outstandingLimitExceeded returns the exceeded outstanding tasks limit.
+func outstandingLimitExceeded(limits LimitsConfig) *limitExceeded {
+	return &limitExceeded{
+		message:    "Outstanding tasks limit exceeded.",
+		retryAfter: limits.OutstandingRetry,
+	}
+}
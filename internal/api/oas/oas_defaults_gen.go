@@ -0,0 +1,35 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package oas
+
+// setDefaults set default value of fields.
+func (s *CreateTaskInput) setDefaults() {
+	{
+		val := TaskPriority("normal")
+		s.Priority.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *Success) setDefaults() {
+	{
+		val := bool(false)
+		s.Retry.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *TaskStatusOutput) setDefaults() {
+	{
+		val := TaskPriority("normal")
+		s.Priority.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *TemplateParameter) setDefaults() {
+	{
+		val := bool(false)
+		s.Required.SetTo(val)
+	}
+}
@@ -1,6 +1,58 @@
 // Code generated by ogen, DO NOT EDIT.
 package oas
 
+type CreateTaskFanoutRes interface {
+	createTaskFanoutRes()
+}
+
+type CreateTaskRes interface {
+	createTaskRes()
+}
+
+type CreateTemplateRes interface {
+	createTemplateRes()
+}
+
+type CreateWorkflowRes interface {
+	createWorkflowRes()
+}
+
+type DeleteOAuth2ProfileRes interface {
+	deleteOAuth2ProfileRes()
+}
+
+type DeleteSecretRes interface {
+	deleteSecretRes()
+}
+
+type DeleteTLSProfileRes interface {
+	deleteTLSProfileRes()
+}
+
+type DeleteTemplateRes interface {
+	deleteTemplateRes()
+}
+
+type GetTaskGroupRes interface {
+	getTaskGroupRes()
+}
+
 type GetTaskStatusRes interface {
 	getTaskStatusRes()
 }
+
+type GetTemplateRes interface {
+	getTemplateRes()
+}
+
+type GetWorkflowRes interface {
+	getWorkflowRes()
+}
+
+type PutOAuth2ProfileRes interface {
+	putOAuth2ProfileRes()
+}
+
+type PutTLSProfileRes interface {
+	putTLSProfileRes()
+}
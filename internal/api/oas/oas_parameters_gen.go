@@ -16,25 +16,1227 @@ import (
 	"github.com/ogen-go/ogen/validate"
 )
 
+// CreateTaskParams is parameters of createTask operation.
+type CreateTaskParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+	// Time to wait for the task to finish, e.g. `5s`.
+	Wait OptString
+	// Preferences, `wait=<seconds>` is used unless `wait` is set.
+	Prefer OptString
+}
+
+func unpackCreateTaskParams(packed middleware.Parameters) (params CreateTaskParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "wait",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Wait = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "Prefer",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.Prefer = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeCreateTaskParams(args [0]string, argsEscaped bool, r *http.Request) (params CreateTaskParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode query: wait.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "wait",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotWaitVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotWaitVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Wait.SetTo(paramsDotWaitVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "wait",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode header: Prefer.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "Prefer",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotPreferVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotPreferVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Prefer.SetTo(paramsDotPreferVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "Prefer",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// CreateTaskFanoutParams is parameters of createTaskFanout operation.
+type CreateTaskFanoutParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+}
+
+func unpackCreateTaskFanoutParams(packed middleware.Parameters) (params CreateTaskFanoutParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeCreateTaskFanoutParams(args [0]string, argsEscaped bool, r *http.Request) (params CreateTaskFanoutParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// CreateTemplateParams is parameters of createTemplate operation.
+type CreateTemplateParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+}
+
+func unpackCreateTemplateParams(packed middleware.Parameters) (params CreateTemplateParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeCreateTemplateParams(args [0]string, argsEscaped bool, r *http.Request) (params CreateTemplateParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// CreateWorkflowParams is parameters of createWorkflow operation.
+type CreateWorkflowParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+}
+
+func unpackCreateWorkflowParams(packed middleware.Parameters) (params CreateWorkflowParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeCreateWorkflowParams(args [0]string, argsEscaped bool, r *http.Request) (params CreateWorkflowParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// DeleteOAuth2ProfileParams is parameters of deleteOAuth2Profile operation.
+type DeleteOAuth2ProfileParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+	// Name of profile to delete.
+	Name string
+}
+
+func unpackDeleteOAuth2ProfileParams(packed middleware.Parameters) (params DeleteOAuth2ProfileParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "name",
+			In:   "path",
+		}
+		params.Name = packed[key].(string)
+	}
+	return params
+}
+
+func decodeDeleteOAuth2ProfileParams(args [1]string, argsEscaped bool, r *http.Request) (params DeleteOAuth2ProfileParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: name.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "name",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.Name = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "name",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// DeleteSecretParams is parameters of deleteSecret operation.
+type DeleteSecretParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+	// Name of secret to delete.
+	Name string
+}
+
+func unpackDeleteSecretParams(packed middleware.Parameters) (params DeleteSecretParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "name",
+			In:   "path",
+		}
+		params.Name = packed[key].(string)
+	}
+	return params
+}
+
+func decodeDeleteSecretParams(args [1]string, argsEscaped bool, r *http.Request) (params DeleteSecretParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: name.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "name",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.Name = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "name",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// DeleteTLSProfileParams is parameters of deleteTLSProfile operation.
+type DeleteTLSProfileParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+	// Name of profile to delete.
+	Name string
+}
+
+func unpackDeleteTLSProfileParams(packed middleware.Parameters) (params DeleteTLSProfileParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "name",
+			In:   "path",
+		}
+		params.Name = packed[key].(string)
+	}
+	return params
+}
+
+func decodeDeleteTLSProfileParams(args [1]string, argsEscaped bool, r *http.Request) (params DeleteTLSProfileParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: name.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "name",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.Name = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "name",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// DeleteTemplateParams is parameters of deleteTemplate operation.
+type DeleteTemplateParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+	// Name of template to delete.
+	Name string
+}
+
+func unpackDeleteTemplateParams(packed middleware.Parameters) (params DeleteTemplateParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "name",
+			In:   "path",
+		}
+		params.Name = packed[key].(string)
+	}
+	return params
+}
+
+func decodeDeleteTemplateParams(args [1]string, argsEscaped bool, r *http.Request) (params DeleteTemplateParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: name.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "name",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.Name = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "name",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetTaskGroupParams is parameters of getTaskGroup operation.
+type GetTaskGroupParams struct {
+	// ID of task group to return.
+	GroupID uuid.UUID
+}
+
+func unpackGetTaskGroupParams(packed middleware.Parameters) (params GetTaskGroupParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "groupID",
+			In:   "path",
+		}
+		params.GroupID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeGetTaskGroupParams(args [1]string, argsEscaped bool, r *http.Request) (params GetTaskGroupParams, _ error) {
+	// Decode path: groupID.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "groupID",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.GroupID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "groupID",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
 // GetTaskStatusParams is parameters of getTaskStatus operation.
 type GetTaskStatusParams struct {
 	// ID of task to return.
 	TaskID uuid.UUID
+	// Time to wait for the task status to change, e.g. `30s`.
+	WaitForChange OptString
+}
+
+func unpackGetTaskStatusParams(packed middleware.Parameters) (params GetTaskStatusParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "taskID",
+			In:   "path",
+		}
+		params.TaskID = packed[key].(uuid.UUID)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "wait_for_change",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.WaitForChange = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeGetTaskStatusParams(args [1]string, argsEscaped bool, r *http.Request) (params GetTaskStatusParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	// Decode path: taskID.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "taskID",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.TaskID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "taskID",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode query: wait_for_change.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "wait_for_change",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotWaitForChangeVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotWaitForChangeVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.WaitForChange.SetTo(paramsDotWaitForChangeVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "wait_for_change",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetTemplateParams is parameters of getTemplate operation.
+type GetTemplateParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+	// Name of template to return.
+	Name string
+	// Version of template to return, the latest by default.
+	Version OptInt
+}
+
+func unpackGetTemplateParams(packed middleware.Parameters) (params GetTemplateParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "name",
+			In:   "path",
+		}
+		params.Name = packed[key].(string)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "version",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Version = v.(OptInt)
+		}
+	}
+	return params
+}
+
+func decodeGetTemplateParams(args [1]string, argsEscaped bool, r *http.Request) (params GetTemplateParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: name.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "name",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.Name = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "name",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode query: version.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "version",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotVersionVal int
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotVersionVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Version.SetTo(paramsDotVersionVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if params.Version.Set {
+					if err := func() error {
+						if err := (validate.Int{
+							MinSet:        true,
+							Min:           1,
+							MaxSet:        false,
+							Max:           0,
+							MinExclusive:  false,
+							MaxExclusive:  false,
+							MultipleOfSet: false,
+							MultipleOf:    0,
+						}).Validate(int64(params.Version.Value)); err != nil {
+							return errors.Wrap(err, "int")
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "version",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
 }
 
-func unpackGetTaskStatusParams(packed middleware.Parameters) (params GetTaskStatusParams) {
+// GetWorkflowParams is parameters of getWorkflow operation.
+type GetWorkflowParams struct {
+	// ID of workflow to return.
+	WorkflowID uuid.UUID
+}
+
+func unpackGetWorkflowParams(packed middleware.Parameters) (params GetWorkflowParams) {
 	{
 		key := middleware.ParameterKey{
-			Name: "taskID",
+			Name: "workflowID",
 			In:   "path",
 		}
-		params.TaskID = packed[key].(uuid.UUID)
+		params.WorkflowID = packed[key].(uuid.UUID)
 	}
 	return params
 }
 
-func decodeGetTaskStatusParams(args [1]string, argsEscaped bool, r *http.Request) (params GetTaskStatusParams, _ error) {
-	// Decode path: taskID.
+func decodeGetWorkflowParams(args [1]string, argsEscaped bool, r *http.Request) (params GetWorkflowParams, _ error) {
+	// Decode path: workflowID.
 	if err := func() error {
 		param := args[0]
 		if argsEscaped {
@@ -46,7 +1248,7 @@ func decodeGetTaskStatusParams(args [1]string, argsEscaped bool, r *http.Request
 		}
 		if len(param) > 0 {
 			d := uri.NewPathDecoder(uri.PathDecoderConfig{
-				Param:   "taskID",
+				Param:   "workflowID",
 				Value:   param,
 				Style:   uri.PathStyleSimple,
 				Explode: false,
@@ -63,7 +1265,7 @@ func decodeGetTaskStatusParams(args [1]string, argsEscaped bool, r *http.Request
 					return err
 				}
 
-				params.TaskID = c
+				params.WorkflowID = c
 				return nil
 			}(); err != nil {
 				return err
@@ -74,10 +1276,451 @@ func decodeGetTaskStatusParams(args [1]string, argsEscaped bool, r *http.Request
 		return nil
 	}(); err != nil {
 		return params, &ogenerrors.DecodeParamError{
-			Name: "taskID",
+			Name: "workflowID",
 			In:   "path",
 			Err:  err,
 		}
 	}
 	return params, nil
 }
+
+// ListOAuth2ProfilesParams is parameters of listOAuth2Profiles operation.
+type ListOAuth2ProfilesParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+}
+
+func unpackListOAuth2ProfilesParams(packed middleware.Parameters) (params ListOAuth2ProfilesParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeListOAuth2ProfilesParams(args [0]string, argsEscaped bool, r *http.Request) (params ListOAuth2ProfilesParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ListSecretsParams is parameters of listSecrets operation.
+type ListSecretsParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+}
+
+func unpackListSecretsParams(packed middleware.Parameters) (params ListSecretsParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeListSecretsParams(args [0]string, argsEscaped bool, r *http.Request) (params ListSecretsParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ListTLSProfilesParams is parameters of listTLSProfiles operation.
+type ListTLSProfilesParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+}
+
+func unpackListTLSProfilesParams(packed middleware.Parameters) (params ListTLSProfilesParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeListTLSProfilesParams(args [0]string, argsEscaped bool, r *http.Request) (params ListTLSProfilesParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ListTemplatesParams is parameters of listTemplates operation.
+type ListTemplatesParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+}
+
+func unpackListTemplatesParams(packed middleware.Parameters) (params ListTemplatesParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeListTemplatesParams(args [0]string, argsEscaped bool, r *http.Request) (params ListTemplatesParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// PutOAuth2ProfileParams is parameters of putOAuth2Profile operation.
+type PutOAuth2ProfileParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+}
+
+func unpackPutOAuth2ProfileParams(packed middleware.Parameters) (params PutOAuth2ProfileParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodePutOAuth2ProfileParams(args [0]string, argsEscaped bool, r *http.Request) (params PutOAuth2ProfileParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// PutSecretParams is parameters of putSecret operation.
+type PutSecretParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+}
+
+func unpackPutSecretParams(packed middleware.Parameters) (params PutSecretParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodePutSecretParams(args [0]string, argsEscaped bool, r *http.Request) (params PutSecretParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// PutTLSProfileParams is parameters of putTLSProfile operation.
+type PutTLSProfileParams struct {
+	// ID of the client making the request.
+	XClientID OptString
+}
+
+func unpackPutTLSProfileParams(packed middleware.Parameters) (params PutTLSProfileParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Id",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientID = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodePutTLSProfileParams(args [0]string, argsEscaped bool, r *http.Request) (params PutTLSProfileParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientID.SetTo(paramsDotXClientIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
@@ -73,8 +73,312 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 					return
 				}
-			case 't': // Prefix: "tasks"
-				if l := len("tasks"); len(elem) >= l && elem[0:l] == "tasks" {
+			case 'o': // Prefix: "oauth2-profiles"
+				if l := len("oauth2-profiles"); len(elem) >= l && elem[0:l] == "oauth2-profiles" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch r.Method {
+					case "GET":
+						s.handleListOAuth2ProfilesRequest([0]string{}, elemIsEscaped, w, r)
+					case "POST":
+						s.handlePutOAuth2ProfileRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "GET,POST")
+					}
+
+					return
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "name"
+					// Leaf parameter
+					args[0] = elem
+					elem = ""
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "DELETE":
+							s.handleDeleteOAuth2ProfileRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "DELETE")
+						}
+
+						return
+					}
+				}
+			case 's': // Prefix: "secrets"
+				if l := len("secrets"); len(elem) >= l && elem[0:l] == "secrets" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch r.Method {
+					case "GET":
+						s.handleListSecretsRequest([0]string{}, elemIsEscaped, w, r)
+					case "POST":
+						s.handlePutSecretRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "GET,POST")
+					}
+
+					return
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "name"
+					// Leaf parameter
+					args[0] = elem
+					elem = ""
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "DELETE":
+							s.handleDeleteSecretRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "DELETE")
+						}
+
+						return
+					}
+				}
+			case 't': // Prefix: "t"
+				if l := len("t"); len(elem) >= l && elem[0:l] == "t" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case 'a': // Prefix: "ask"
+					if l := len("ask"); len(elem) >= l && elem[0:l] == "ask" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case '-': // Prefix: "-groups/"
+						if l := len("-groups/"); len(elem) >= l && elem[0:l] == "-groups/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "groupID"
+						// Leaf parameter
+						args[0] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetTaskGroupRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+					case 's': // Prefix: "s"
+						if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch r.Method {
+							case "POST":
+								s.handleCreateTaskRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/"
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case 'f': // Prefix: "fanout"
+								if l := len("fanout"); len(elem) >= l && elem[0:l] == "fanout" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "POST":
+										s.handleCreateTaskFanoutRequest([0]string{}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "POST")
+									}
+
+									return
+								}
+							}
+							// Param: "taskID"
+							// Leaf parameter
+							args[0] = elem
+							elem = ""
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetTaskStatusRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+						}
+					}
+				case 'e': // Prefix: "emplates"
+					if l := len("emplates"); len(elem) >= l && elem[0:l] == "emplates" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						switch r.Method {
+						case "GET":
+							s.handleListTemplatesRequest([0]string{}, elemIsEscaped, w, r)
+						case "POST":
+							s.handleCreateTemplateRequest([0]string{}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "GET,POST")
+						}
+
+						return
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/"
+						if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "name"
+						// Leaf parameter
+						args[0] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "DELETE":
+								s.handleDeleteTemplateRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							case "GET":
+								s.handleGetTemplateRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "DELETE,GET")
+							}
+
+							return
+						}
+					}
+				case 'l': // Prefix: "ls-profiles"
+					if l := len("ls-profiles"); len(elem) >= l && elem[0:l] == "ls-profiles" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						switch r.Method {
+						case "GET":
+							s.handleListTLSProfilesRequest([0]string{}, elemIsEscaped, w, r)
+						case "POST":
+							s.handlePutTLSProfileRequest([0]string{}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "GET,POST")
+						}
+
+						return
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/"
+						if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "name"
+						// Leaf parameter
+						args[0] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "DELETE":
+								s.handleDeleteTLSProfileRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "DELETE")
+							}
+
+							return
+						}
+					}
+				}
+			case 'w': // Prefix: "workflows"
+				if l := len("workflows"); len(elem) >= l && elem[0:l] == "workflows" {
 					elem = elem[l:]
 				} else {
 					break
@@ -83,7 +387,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				if len(elem) == 0 {
 					switch r.Method {
 					case "POST":
-						s.handleCreateTaskRequest([0]string{}, elemIsEscaped, w, r)
+						s.handleCreateWorkflowRequest([0]string{}, elemIsEscaped, w, r)
 					default:
 						s.notAllowed(w, r, "POST")
 					}
@@ -98,7 +402,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 						break
 					}
 
-					// Param: "taskID"
+					// Param: "workflowID"
 					// Leaf parameter
 					args[0] = elem
 					elem = ""
@@ -107,7 +411,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 						// Leaf node.
 						switch r.Method {
 						case "GET":
-							s.handleGetTaskStatusRequest([1]string{
+							s.handleGetWorkflowRequest([1]string{
 								args[0],
 							}, elemIsEscaped, w, r)
 						default:
@@ -219,8 +523,360 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						return
 					}
 				}
-			case 't': // Prefix: "tasks"
-				if l := len("tasks"); len(elem) >= l && elem[0:l] == "tasks" {
+			case 'o': // Prefix: "oauth2-profiles"
+				if l := len("oauth2-profiles"); len(elem) >= l && elem[0:l] == "oauth2-profiles" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch method {
+					case "GET":
+						r.name = "ListOAuth2Profiles"
+						r.operationID = "listOAuth2Profiles"
+						r.pathPattern = "/oauth2-profiles"
+						r.args = args
+						r.count = 0
+						return r, true
+					case "POST":
+						r.name = "PutOAuth2Profile"
+						r.operationID = "putOAuth2Profile"
+						r.pathPattern = "/oauth2-profiles"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "name"
+					// Leaf parameter
+					args[0] = elem
+					elem = ""
+
+					if len(elem) == 0 {
+						switch method {
+						case "DELETE":
+							// Leaf: DeleteOAuth2Profile
+							r.name = "DeleteOAuth2Profile"
+							r.operationID = "deleteOAuth2Profile"
+							r.pathPattern = "/oauth2-profiles/{name}"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+				}
+			case 's': // Prefix: "secrets"
+				if l := len("secrets"); len(elem) >= l && elem[0:l] == "secrets" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch method {
+					case "GET":
+						r.name = "ListSecrets"
+						r.operationID = "listSecrets"
+						r.pathPattern = "/secrets"
+						r.args = args
+						r.count = 0
+						return r, true
+					case "POST":
+						r.name = "PutSecret"
+						r.operationID = "putSecret"
+						r.pathPattern = "/secrets"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "name"
+					// Leaf parameter
+					args[0] = elem
+					elem = ""
+
+					if len(elem) == 0 {
+						switch method {
+						case "DELETE":
+							// Leaf: DeleteSecret
+							r.name = "DeleteSecret"
+							r.operationID = "deleteSecret"
+							r.pathPattern = "/secrets/{name}"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+				}
+			case 't': // Prefix: "t"
+				if l := len("t"); len(elem) >= l && elem[0:l] == "t" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case 'a': // Prefix: "ask"
+					if l := len("ask"); len(elem) >= l && elem[0:l] == "ask" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case '-': // Prefix: "-groups/"
+						if l := len("-groups/"); len(elem) >= l && elem[0:l] == "-groups/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "groupID"
+						// Leaf parameter
+						args[0] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								// Leaf: GetTaskGroup
+								r.name = "GetTaskGroup"
+								r.operationID = "getTaskGroup"
+								r.pathPattern = "/task-groups/{groupID}"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+					case 's': // Prefix: "s"
+						if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "POST":
+								r.name = "CreateTask"
+								r.operationID = "createTask"
+								r.pathPattern = "/tasks"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/"
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case 'f': // Prefix: "fanout"
+								if l := len("fanout"); len(elem) >= l && elem[0:l] == "fanout" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									switch method {
+									case "POST":
+										// Leaf: CreateTaskFanout
+										r.name = "CreateTaskFanout"
+										r.operationID = "createTaskFanout"
+										r.pathPattern = "/tasks/fanout"
+										r.args = args
+										r.count = 0
+										return r, true
+									default:
+										return
+									}
+								}
+							}
+							// Param: "taskID"
+							// Leaf parameter
+							args[0] = elem
+							elem = ""
+
+							if len(elem) == 0 {
+								switch method {
+								case "GET":
+									// Leaf: GetTaskStatus
+									r.name = "GetTaskStatus"
+									r.operationID = "getTaskStatus"
+									r.pathPattern = "/tasks/{taskID}"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+						}
+					}
+				case 'e': // Prefix: "emplates"
+					if l := len("emplates"); len(elem) >= l && elem[0:l] == "emplates" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						switch method {
+						case "GET":
+							r.name = "ListTemplates"
+							r.operationID = "listTemplates"
+							r.pathPattern = "/templates"
+							r.args = args
+							r.count = 0
+							return r, true
+						case "POST":
+							r.name = "CreateTemplate"
+							r.operationID = "createTemplate"
+							r.pathPattern = "/templates"
+							r.args = args
+							r.count = 0
+							return r, true
+						default:
+							return
+						}
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/"
+						if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "name"
+						// Leaf parameter
+						args[0] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							switch method {
+							case "DELETE":
+								// Leaf: DeleteTemplate
+								r.name = "DeleteTemplate"
+								r.operationID = "deleteTemplate"
+								r.pathPattern = "/templates/{name}"
+								r.args = args
+								r.count = 1
+								return r, true
+							case "GET":
+								// Leaf: GetTemplate
+								r.name = "GetTemplate"
+								r.operationID = "getTemplate"
+								r.pathPattern = "/templates/{name}"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+					}
+				case 'l': // Prefix: "ls-profiles"
+					if l := len("ls-profiles"); len(elem) >= l && elem[0:l] == "ls-profiles" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						switch method {
+						case "GET":
+							r.name = "ListTLSProfiles"
+							r.operationID = "listTLSProfiles"
+							r.pathPattern = "/tls-profiles"
+							r.args = args
+							r.count = 0
+							return r, true
+						case "POST":
+							r.name = "PutTLSProfile"
+							r.operationID = "putTLSProfile"
+							r.pathPattern = "/tls-profiles"
+							r.args = args
+							r.count = 0
+							return r, true
+						default:
+							return
+						}
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/"
+						if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "name"
+						// Leaf parameter
+						args[0] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							switch method {
+							case "DELETE":
+								// Leaf: DeleteTLSProfile
+								r.name = "DeleteTLSProfile"
+								r.operationID = "deleteTLSProfile"
+								r.pathPattern = "/tls-profiles/{name}"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+					}
+				}
+			case 'w': // Prefix: "workflows"
+				if l := len("workflows"); len(elem) >= l && elem[0:l] == "workflows" {
 					elem = elem[l:]
 				} else {
 					break
@@ -229,9 +885,9 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 				if len(elem) == 0 {
 					switch method {
 					case "POST":
-						r.name = "CreateTask"
-						r.operationID = "createTask"
-						r.pathPattern = "/tasks"
+						r.name = "CreateWorkflow"
+						r.operationID = "createWorkflow"
+						r.pathPattern = "/workflows"
 						r.args = args
 						r.count = 0
 						return r, true
@@ -247,7 +903,7 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						break
 					}
 
-					// Param: "taskID"
+					// Param: "workflowID"
 					// Leaf parameter
 					args[0] = elem
 					elem = ""
@@ -255,10 +911,10 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					if len(elem) == 0 {
 						switch method {
 						case "GET":
-							// Leaf: GetTaskStatus
-							r.name = "GetTaskStatus"
-							r.operationID = "getTaskStatus"
-							r.pathPattern = "/tasks/{taskID}"
+							// Leaf: GetWorkflow
+							r.name = "GetWorkflow"
+							r.operationID = "getWorkflow"
+							r.pathPattern = "/workflows/{workflowID}"
 							r.args = args
 							r.count = 1
 							return r, true
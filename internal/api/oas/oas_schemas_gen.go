@@ -3,11 +3,86 @@
 package oas
 
 import (
+	"time"
+
 	"github.com/go-faster/errors"
 	"github.com/go-faster/jx"
 	"github.com/google/uuid"
 )
 
+// Ref: #/components/schemas/awsSigV4Signing
+type AwsSigV4Signing struct {
+	// AWS region.
+	Region string `json:"region"`
+	// AWS service name.
+	Service string `json:"service"`
+	// AWS access key ID.
+	AccessKeyID string `json:"access_key_id"`
+	// AWS secret access key.
+	SecretAccessKey string `json:"secret_access_key"`
+	// AWS session token.
+	SessionToken OptString `json:"session_token"`
+}
+
+// GetRegion returns the value of Region.
+func (s *AwsSigV4Signing) GetRegion() string {
+	return s.Region
+}
+
+// GetService returns the value of Service.
+func (s *AwsSigV4Signing) GetService() string {
+	return s.Service
+}
+
+// GetAccessKeyID returns the value of AccessKeyID.
+func (s *AwsSigV4Signing) GetAccessKeyID() string {
+	return s.AccessKeyID
+}
+
+// GetSecretAccessKey returns the value of SecretAccessKey.
+func (s *AwsSigV4Signing) GetSecretAccessKey() string {
+	return s.SecretAccessKey
+}
+
+// GetSessionToken returns the value of SessionToken.
+func (s *AwsSigV4Signing) GetSessionToken() OptString {
+	return s.SessionToken
+}
+
+// SetRegion sets the value of Region.
+func (s *AwsSigV4Signing) SetRegion(val string) {
+	s.Region = val
+}
+
+// SetService sets the value of Service.
+func (s *AwsSigV4Signing) SetService(val string) {
+	s.Service = val
+}
+
+// SetAccessKeyID sets the value of AccessKeyID.
+func (s *AwsSigV4Signing) SetAccessKeyID(val string) {
+	s.AccessKeyID = val
+}
+
+// SetSecretAccessKey sets the value of SecretAccessKey.
+func (s *AwsSigV4Signing) SetSecretAccessKey(val string) {
+	s.SecretAccessKey = val
+}
+
+// SetSessionToken sets the value of SessionToken.
+func (s *AwsSigV4Signing) SetSessionToken(val OptString) {
+	s.SessionToken = val
+}
+
+type CreateTaskAccepted TaskStatusOutput
+
+func (*CreateTaskAccepted) createTaskRes() {}
+
+type CreateTaskCreated TaskStatusOutput
+
+func (*CreateTaskCreated) createTaskRes() {}
+
+// Method and URL are required unless the task is created from a template.
 // Ref: #/components/schemas/createTaskInput
 type CreateTaskInput struct {
 	// Request body.
@@ -15,9 +90,42 @@ type CreateTaskInput struct {
 	// Request headers.
 	Headers OptCreateTaskInputHeaders `json:"headers"`
 	// Request method.
-	Method CreateTaskInputMethod `json:"method"`
+	Method OptCreateTaskInputMethod `json:"method"`
 	// Request URL.
-	URL string `json:"url"`
+	URL     OptString  `json:"url"`
+	Signing OptSigning `json:"signing"`
+	// Name of the client OAuth2 profile to get a bearer token from.
+	OAuth2Profile OptString `json:"oauth2_profile"`
+	// Name of the client TLS profile to send the request with.
+	TLSProfile OptString  `json:"tls_profile"`
+	Proxy      OptProxy   `json:"proxy"`
+	Success    OptSuccess `json:"success"`
+	// Values to extract from the response by name: JSONPaths of the JSON body like `$.data.id` or
+	// response header names. Values which aren't found are null.
+	Extract OptCreateTaskInputExtract `json:"extract"`
+	// Variables referenced in URL, headers and body as `{{var:name}}`, rendered by the worker when the
+	// request is sent along with built-ins `{{now}}`, `{{now_unix}}`, `{{task_id}}` and `{{uuid}}`.
+	Variables OptCreateTaskInputVariables `json:"variables"`
+	// Name of the client request template to take method, URL, headers and body from, which must not be
+	// set then.
+	Template OptString `json:"template"`
+	// Version of the template, the latest by default.
+	TemplateVersion OptInt `json:"template_version"`
+	// Template parameters by name, validated against the template parameter schema and passed to the
+	// task as variables.
+	Params OptCreateTaskInputParams `json:"params"`
+	// IDs of parent tasks. The task waits until all parents are done and is skipped if any of them fails.
+	//  Values extracted from parents are referenced in URL, headers and body like `{{parent:<id>.
+	// <name>}}`.
+	DependsOn []uuid.UUID     `json:"depends_on"`
+	Priority  OptTaskPriority `json:"priority"`
+	// Label of the task matched by the routing rules along with the URL host and the client to pick the
+	// queue of the task.
+	Label OptString `json:"label"`
+	// Key of the tasks delivered in order when the task queue is a FIFO one, like an account ID. Tasks
+	// with the same key are sent one after another, ignored by standard queues. Requires the normal
+	// priority, since each priority has its own queue.
+	OrderingKey OptString `json:"ordering_key"`
 }
 
 // GetBody returns the value of Body.
@@ -31,15 +139,85 @@ func (s *CreateTaskInput) GetHeaders() OptCreateTaskInputHeaders {
 }
 
 // GetMethod returns the value of Method.
-func (s *CreateTaskInput) GetMethod() CreateTaskInputMethod {
+func (s *CreateTaskInput) GetMethod() OptCreateTaskInputMethod {
 	return s.Method
 }
 
 // GetURL returns the value of URL.
-func (s *CreateTaskInput) GetURL() string {
+func (s *CreateTaskInput) GetURL() OptString {
 	return s.URL
 }
 
+// GetSigning returns the value of Signing.
+func (s *CreateTaskInput) GetSigning() OptSigning {
+	return s.Signing
+}
+
+// GetOAuth2Profile returns the value of OAuth2Profile.
+func (s *CreateTaskInput) GetOAuth2Profile() OptString {
+	return s.OAuth2Profile
+}
+
+// GetTLSProfile returns the value of TLSProfile.
+func (s *CreateTaskInput) GetTLSProfile() OptString {
+	return s.TLSProfile
+}
+
+// GetProxy returns the value of Proxy.
+func (s *CreateTaskInput) GetProxy() OptProxy {
+	return s.Proxy
+}
+
+// GetSuccess returns the value of Success.
+func (s *CreateTaskInput) GetSuccess() OptSuccess {
+	return s.Success
+}
+
+// GetExtract returns the value of Extract.
+func (s *CreateTaskInput) GetExtract() OptCreateTaskInputExtract {
+	return s.Extract
+}
+
+// GetVariables returns the value of Variables.
+func (s *CreateTaskInput) GetVariables() OptCreateTaskInputVariables {
+	return s.Variables
+}
+
+// GetTemplate returns the value of Template.
+func (s *CreateTaskInput) GetTemplate() OptString {
+	return s.Template
+}
+
+// GetTemplateVersion returns the value of TemplateVersion.
+func (s *CreateTaskInput) GetTemplateVersion() OptInt {
+	return s.TemplateVersion
+}
+
+// GetParams returns the value of Params.
+func (s *CreateTaskInput) GetParams() OptCreateTaskInputParams {
+	return s.Params
+}
+
+// GetDependsOn returns the value of DependsOn.
+func (s *CreateTaskInput) GetDependsOn() []uuid.UUID {
+	return s.DependsOn
+}
+
+// GetPriority returns the value of Priority.
+func (s *CreateTaskInput) GetPriority() OptTaskPriority {
+	return s.Priority
+}
+
+// GetLabel returns the value of Label.
+func (s *CreateTaskInput) GetLabel() OptString {
+	return s.Label
+}
+
+// GetOrderingKey returns the value of OrderingKey.
+func (s *CreateTaskInput) GetOrderingKey() OptString {
+	return s.OrderingKey
+}
+
 // SetBody sets the value of Body.
 func (s *CreateTaskInput) SetBody(val OptCreateTaskInputBody) {
 	s.Body = val
@@ -51,15 +229,85 @@ func (s *CreateTaskInput) SetHeaders(val OptCreateTaskInputHeaders) {
 }
 
 // SetMethod sets the value of Method.
-func (s *CreateTaskInput) SetMethod(val CreateTaskInputMethod) {
+func (s *CreateTaskInput) SetMethod(val OptCreateTaskInputMethod) {
 	s.Method = val
 }
 
 // SetURL sets the value of URL.
-func (s *CreateTaskInput) SetURL(val string) {
+func (s *CreateTaskInput) SetURL(val OptString) {
 	s.URL = val
 }
 
+// SetSigning sets the value of Signing.
+func (s *CreateTaskInput) SetSigning(val OptSigning) {
+	s.Signing = val
+}
+
+// SetOAuth2Profile sets the value of OAuth2Profile.
+func (s *CreateTaskInput) SetOAuth2Profile(val OptString) {
+	s.OAuth2Profile = val
+}
+
+// SetTLSProfile sets the value of TLSProfile.
+func (s *CreateTaskInput) SetTLSProfile(val OptString) {
+	s.TLSProfile = val
+}
+
+// SetProxy sets the value of Proxy.
+func (s *CreateTaskInput) SetProxy(val OptProxy) {
+	s.Proxy = val
+}
+
+// SetSuccess sets the value of Success.
+func (s *CreateTaskInput) SetSuccess(val OptSuccess) {
+	s.Success = val
+}
+
+// SetExtract sets the value of Extract.
+func (s *CreateTaskInput) SetExtract(val OptCreateTaskInputExtract) {
+	s.Extract = val
+}
+
+// SetVariables sets the value of Variables.
+func (s *CreateTaskInput) SetVariables(val OptCreateTaskInputVariables) {
+	s.Variables = val
+}
+
+// SetTemplate sets the value of Template.
+func (s *CreateTaskInput) SetTemplate(val OptString) {
+	s.Template = val
+}
+
+// SetTemplateVersion sets the value of TemplateVersion.
+func (s *CreateTaskInput) SetTemplateVersion(val OptInt) {
+	s.TemplateVersion = val
+}
+
+// SetParams sets the value of Params.
+func (s *CreateTaskInput) SetParams(val OptCreateTaskInputParams) {
+	s.Params = val
+}
+
+// SetDependsOn sets the value of DependsOn.
+func (s *CreateTaskInput) SetDependsOn(val []uuid.UUID) {
+	s.DependsOn = val
+}
+
+// SetPriority sets the value of Priority.
+func (s *CreateTaskInput) SetPriority(val OptTaskPriority) {
+	s.Priority = val
+}
+
+// SetLabel sets the value of Label.
+func (s *CreateTaskInput) SetLabel(val OptString) {
+	s.Label = val
+}
+
+// SetOrderingKey sets the value of OrderingKey.
+func (s *CreateTaskInput) SetOrderingKey(val OptString) {
+	s.OrderingKey = val
+}
+
 // Request body.
 type CreateTaskInputBody map[string]jx.Raw
 
@@ -72,6 +320,19 @@ func (s *CreateTaskInputBody) init() CreateTaskInputBody {
 	return m
 }
 
+// Values to extract from the response by name: JSONPaths of the JSON body like `$.data.id` or
+// response header names. Values which aren't found are null.
+type CreateTaskInputExtract map[string]string
+
+func (s *CreateTaskInputExtract) init() CreateTaskInputExtract {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
+	}
+	return m
+}
+
 // Request headers.
 type CreateTaskInputHeaders map[string]string
 
@@ -142,6 +403,32 @@ func (s *CreateTaskInputMethod) UnmarshalText(data []byte) error {
 	}
 }
 
+// Template parameters by name, validated against the template parameter schema and passed to the
+// task as variables.
+type CreateTaskInputParams map[string]jx.Raw
+
+func (s *CreateTaskInputParams) init() CreateTaskInputParams {
+	m := *s
+	if m == nil {
+		m = map[string]jx.Raw{}
+		*s = m
+	}
+	return m
+}
+
+// Variables referenced in URL, headers and body as `{{var:name}}`, rendered by the worker when the
+// request is sent along with built-ins `{{now}}`, `{{now_unix}}`, `{{task_id}}` and `{{uuid}}`.
+type CreateTaskInputVariables map[string]string
+
+func (s *CreateTaskInputVariables) init() CreateTaskInputVariables {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
+	}
+	return m
+}
+
 // Ref: #/components/schemas/createTaskOutput
 type CreateTaskOutput struct {
 	// Task ID.
@@ -158,264 +445,3299 @@ func (s *CreateTaskOutput) SetID(val uuid.UUID) {
 	s.ID = val
 }
 
-// GetHealthStatusOK is response for GetHealthStatus operation.
-type GetHealthStatusOK struct{}
-
-// GetTaskStatusNotFound is response for GetTaskStatus operation.
-type GetTaskStatusNotFound struct{}
-
-func (*GetTaskStatusNotFound) getTaskStatusRes() {}
+func (*CreateTaskOutput) createTaskRes() {}
 
-// NewOptCreateTaskInputBody returns new OptCreateTaskInputBody with value set to v.
-func NewOptCreateTaskInputBody(v CreateTaskInputBody) OptCreateTaskInputBody {
-	return OptCreateTaskInputBody{
-		Value: v,
-		Set:   true,
-	}
+// Ref: #/components/schemas/createWorkflowInput
+type CreateWorkflowInput struct {
+	// Variables referenced in step URL, headers and body as `{{var:name}}`, step request variables
+	// override them.
+	Variables OptCreateWorkflowInputVariables `json:"variables"`
+	Steps     []WorkflowStepInput             `json:"steps"`
 }
 
-// OptCreateTaskInputBody is optional CreateTaskInputBody.
-type OptCreateTaskInputBody struct {
-	Value CreateTaskInputBody
-	Set   bool
+// GetVariables returns the value of Variables.
+func (s *CreateWorkflowInput) GetVariables() OptCreateWorkflowInputVariables {
+	return s.Variables
 }
 
-// IsSet returns true if OptCreateTaskInputBody was set.
-func (o OptCreateTaskInputBody) IsSet() bool { return o.Set }
+// GetSteps returns the value of Steps.
+func (s *CreateWorkflowInput) GetSteps() []WorkflowStepInput {
+	return s.Steps
+}
 
-// Reset unsets value.
-func (o *OptCreateTaskInputBody) Reset() {
-	var v CreateTaskInputBody
-	o.Value = v
-	o.Set = false
+// SetVariables sets the value of Variables.
+func (s *CreateWorkflowInput) SetVariables(val OptCreateWorkflowInputVariables) {
+	s.Variables = val
 }
 
-// SetTo sets value to v.
-func (o *OptCreateTaskInputBody) SetTo(v CreateTaskInputBody) {
-	o.Set = true
-	o.Value = v
+// SetSteps sets the value of Steps.
+func (s *CreateWorkflowInput) SetSteps(val []WorkflowStepInput) {
+	s.Steps = val
 }
 
-// Get returns value and boolean that denotes whether value was set.
-func (o OptCreateTaskInputBody) Get() (v CreateTaskInputBody, ok bool) {
-	if !o.Set {
-		return v, false
+// Variables referenced in step URL, headers and body as `{{var:name}}`, step request variables
+// override them.
+type CreateWorkflowInputVariables map[string]string
+
+func (s *CreateWorkflowInputVariables) init() CreateWorkflowInputVariables {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
 	}
-	return o.Value, true
+	return m
 }
 
-// Or returns value if set, or given parameter if does not.
-func (o OptCreateTaskInputBody) Or(d CreateTaskInputBody) CreateTaskInputBody {
-	if v, ok := o.Get(); ok {
-		return v
-	}
-	return d
+// DeleteOAuth2ProfileNoContent is response for DeleteOAuth2Profile operation.
+type DeleteOAuth2ProfileNoContent struct{}
+
+func (*DeleteOAuth2ProfileNoContent) deleteOAuth2ProfileRes() {}
+
+// DeleteOAuth2ProfileNotFound is response for DeleteOAuth2Profile operation.
+type DeleteOAuth2ProfileNotFound struct{}
+
+func (*DeleteOAuth2ProfileNotFound) deleteOAuth2ProfileRes() {}
+
+// DeleteSecretNoContent is response for DeleteSecret operation.
+type DeleteSecretNoContent struct{}
+
+func (*DeleteSecretNoContent) deleteSecretRes() {}
+
+// DeleteSecretNotFound is response for DeleteSecret operation.
+type DeleteSecretNotFound struct{}
+
+func (*DeleteSecretNotFound) deleteSecretRes() {}
+
+// DeleteTLSProfileNoContent is response for DeleteTLSProfile operation.
+type DeleteTLSProfileNoContent struct{}
+
+func (*DeleteTLSProfileNoContent) deleteTLSProfileRes() {}
+
+// DeleteTLSProfileNotFound is response for DeleteTLSProfile operation.
+type DeleteTLSProfileNotFound struct{}
+
+func (*DeleteTLSProfileNotFound) deleteTLSProfileRes() {}
+
+// DeleteTemplateNoContent is response for DeleteTemplate operation.
+type DeleteTemplateNoContent struct{}
+
+func (*DeleteTemplateNoContent) deleteTemplateRes() {}
+
+// DeleteTemplateNotFound is response for DeleteTemplate operation.
+type DeleteTemplateNotFound struct{}
+
+func (*DeleteTemplateNotFound) deleteTemplateRes() {}
+
+// Ref: #/components/schemas/errorOutput
+type ErrorOutput struct {
+	// Error message.
+	ErrorMessage string `json:"error_message"`
 }
 
-// NewOptCreateTaskInputHeaders returns new OptCreateTaskInputHeaders with value set to v.
-func NewOptCreateTaskInputHeaders(v CreateTaskInputHeaders) OptCreateTaskInputHeaders {
-	return OptCreateTaskInputHeaders{
-		Value: v,
-		Set:   true,
-	}
+// GetErrorMessage returns the value of ErrorMessage.
+func (s *ErrorOutput) GetErrorMessage() string {
+	return s.ErrorMessage
 }
 
-// OptCreateTaskInputHeaders is optional CreateTaskInputHeaders.
-type OptCreateTaskInputHeaders struct {
-	Value CreateTaskInputHeaders
-	Set   bool
+// SetErrorMessage sets the value of ErrorMessage.
+func (s *ErrorOutput) SetErrorMessage(val string) {
+	s.ErrorMessage = val
 }
 
-// IsSet returns true if OptCreateTaskInputHeaders was set.
-func (o OptCreateTaskInputHeaders) IsSet() bool { return o.Set }
+func (*ErrorOutput) createTaskFanoutRes() {}
+func (*ErrorOutput) createTaskRes()       {}
+func (*ErrorOutput) createTemplateRes()   {}
+func (*ErrorOutput) createWorkflowRes()   {}
+func (*ErrorOutput) getTaskStatusRes()    {}
+func (*ErrorOutput) putOAuth2ProfileRes() {}
+func (*ErrorOutput) putTLSProfileRes()    {}
+
+// ErrorOutputHeaders wraps ErrorOutput with response headers.
+type ErrorOutputHeaders struct {
+	RetryAfter int
+	Response   ErrorOutput
+}
 
-// Reset unsets value.
-func (o *OptCreateTaskInputHeaders) Reset() {
-	var v CreateTaskInputHeaders
-	o.Value = v
-	o.Set = false
+// GetRetryAfter returns the value of RetryAfter.
+func (s *ErrorOutputHeaders) GetRetryAfter() int {
+	return s.RetryAfter
 }
 
-// SetTo sets value to v.
-func (o *OptCreateTaskInputHeaders) SetTo(v CreateTaskInputHeaders) {
-	o.Set = true
-	o.Value = v
+// GetResponse returns the value of Response.
+func (s *ErrorOutputHeaders) GetResponse() ErrorOutput {
+	return s.Response
 }
 
-// Get returns value and boolean that denotes whether value was set.
-func (o OptCreateTaskInputHeaders) Get() (v CreateTaskInputHeaders, ok bool) {
-	if !o.Set {
-		return v, false
-	}
-	return o.Value, true
+// SetRetryAfter sets the value of RetryAfter.
+func (s *ErrorOutputHeaders) SetRetryAfter(val int) {
+	s.RetryAfter = val
 }
 
-// Or returns value if set, or given parameter if does not.
-func (o OptCreateTaskInputHeaders) Or(d CreateTaskInputHeaders) CreateTaskInputHeaders {
-	if v, ok := o.Get(); ok {
-		return v
-	}
-	return d
+// SetResponse sets the value of Response.
+func (s *ErrorOutputHeaders) SetResponse(val ErrorOutput) {
+	s.Response = val
 }
 
-// NewOptInt returns new OptInt with value set to v.
-func NewOptInt(v int) OptInt {
-	return OptInt{
-		Value: v,
-		Set:   true,
-	}
+func (*ErrorOutputHeaders) createTaskFanoutRes() {}
+func (*ErrorOutputHeaders) createTaskRes()       {}
+func (*ErrorOutputHeaders) createWorkflowRes()   {}
+
+// Ref: #/components/schemas/fanoutInput
+type FanoutInput struct {
+	Request CreateTaskInput `json:"request"`
+	Targets []FanoutTarget  `json:"targets"`
 }
 
-// OptInt is optional int.
-type OptInt struct {
-	Value int
-	Set   bool
+// GetRequest returns the value of Request.
+func (s *FanoutInput) GetRequest() CreateTaskInput {
+	return s.Request
 }
 
-// IsSet returns true if OptInt was set.
-func (o OptInt) IsSet() bool { return o.Set }
+// GetTargets returns the value of Targets.
+func (s *FanoutInput) GetTargets() []FanoutTarget {
+	return s.Targets
+}
 
-// Reset unsets value.
-func (o *OptInt) Reset() {
-	var v int
-	o.Value = v
-	o.Set = false
+// SetRequest sets the value of Request.
+func (s *FanoutInput) SetRequest(val CreateTaskInput) {
+	s.Request = val
 }
 
-// SetTo sets value to v.
-func (o *OptInt) SetTo(v int) {
-	o.Set = true
-	o.Value = v
+// SetTargets sets the value of Targets.
+func (s *FanoutInput) SetTargets(val []FanoutTarget) {
+	s.Targets = val
 }
 
-// Get returns value and boolean that denotes whether value was set.
-func (o OptInt) Get() (v int, ok bool) {
-	if !o.Set {
-		return v, false
-	}
-	return o.Value, true
+// Ref: #/components/schemas/fanoutOutput
+type FanoutOutput struct {
+	// Task group ID.
+	GroupID uuid.UUID `json:"group_id"`
+	// IDs of the created tasks in the order of the targets.
+	TaskIds []uuid.UUID `json:"task_ids"`
 }
 
-// Or returns value if set, or given parameter if does not.
-func (o OptInt) Or(d int) int {
-	if v, ok := o.Get(); ok {
-		return v
-	}
-	return d
+// GetGroupID returns the value of GroupID.
+func (s *FanoutOutput) GetGroupID() uuid.UUID {
+	return s.GroupID
 }
 
-// NewOptInt64 returns new OptInt64 with value set to v.
-func NewOptInt64(v int64) OptInt64 {
-	return OptInt64{
-		Value: v,
-		Set:   true,
-	}
+// GetTaskIds returns the value of TaskIds.
+func (s *FanoutOutput) GetTaskIds() []uuid.UUID {
+	return s.TaskIds
 }
 
-// OptInt64 is optional int64.
-type OptInt64 struct {
-	Value int64
-	Set   bool
+// SetGroupID sets the value of GroupID.
+func (s *FanoutOutput) SetGroupID(val uuid.UUID) {
+	s.GroupID = val
 }
 
-// IsSet returns true if OptInt64 was set.
-func (o OptInt64) IsSet() bool { return o.Set }
+// SetTaskIds sets the value of TaskIds.
+func (s *FanoutOutput) SetTaskIds(val []uuid.UUID) {
+	s.TaskIds = val
+}
 
-// Reset unsets value.
-func (o *OptInt64) Reset() {
-	var v int64
+func (*FanoutOutput) createTaskFanoutRes() {}
+
+// Ref: #/components/schemas/fanoutTarget
+type FanoutTarget struct {
+	// URL replacing the template one.
+	URL OptString `json:"url"`
+	// Variables referenced in the template as `{{var:name}}`, overriding the template ones.
+	Variables OptFanoutTargetVariables `json:"variables"`
+}
+
+// GetURL returns the value of URL.
+func (s *FanoutTarget) GetURL() OptString {
+	return s.URL
+}
+
+// GetVariables returns the value of Variables.
+func (s *FanoutTarget) GetVariables() OptFanoutTargetVariables {
+	return s.Variables
+}
+
+// SetURL sets the value of URL.
+func (s *FanoutTarget) SetURL(val OptString) {
+	s.URL = val
+}
+
+// SetVariables sets the value of Variables.
+func (s *FanoutTarget) SetVariables(val OptFanoutTargetVariables) {
+	s.Variables = val
+}
+
+// Variables referenced in the template as `{{var:name}}`, overriding the template ones.
+type FanoutTargetVariables map[string]string
+
+func (s *FanoutTargetVariables) init() FanoutTargetVariables {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
+	}
+	return m
+}
+
+// GetHealthStatusOK is response for GetHealthStatus operation.
+type GetHealthStatusOK struct{}
+
+// GetTaskGroupNotFound is response for GetTaskGroup operation.
+type GetTaskGroupNotFound struct{}
+
+func (*GetTaskGroupNotFound) getTaskGroupRes() {}
+
+// GetTaskStatusNotFound is response for GetTaskStatus operation.
+type GetTaskStatusNotFound struct{}
+
+func (*GetTaskStatusNotFound) getTaskStatusRes() {}
+
+// GetTemplateNotFound is response for GetTemplate operation.
+type GetTemplateNotFound struct{}
+
+func (*GetTemplateNotFound) getTemplateRes() {}
+
+// GetWorkflowNotFound is response for GetWorkflow operation.
+type GetWorkflowNotFound struct{}
+
+func (*GetWorkflowNotFound) getWorkflowRes() {}
+
+// Ref: #/components/schemas/hmacSigning
+type HmacSigning struct {
+	// HMAC key.
+	Key string `json:"key"`
+	// Canonical parts of the request joined by a newline, in the given order. Defaults to method, path,
+	// timestamp and body.
+	Parts []HmacSigningPartsItem `json:"parts"`
+	// Header with the hex-encoded signature, `X-Signature` by default.
+	Header OptString `json:"header"`
+	// Header with the Unix timestamp, `X-Timestamp` by default.
+	TimestampHeader OptString `json:"timestamp_header"`
+}
+
+// GetKey returns the value of Key.
+func (s *HmacSigning) GetKey() string {
+	return s.Key
+}
+
+// GetParts returns the value of Parts.
+func (s *HmacSigning) GetParts() []HmacSigningPartsItem {
+	return s.Parts
+}
+
+// GetHeader returns the value of Header.
+func (s *HmacSigning) GetHeader() OptString {
+	return s.Header
+}
+
+// GetTimestampHeader returns the value of TimestampHeader.
+func (s *HmacSigning) GetTimestampHeader() OptString {
+	return s.TimestampHeader
+}
+
+// SetKey sets the value of Key.
+func (s *HmacSigning) SetKey(val string) {
+	s.Key = val
+}
+
+// SetParts sets the value of Parts.
+func (s *HmacSigning) SetParts(val []HmacSigningPartsItem) {
+	s.Parts = val
+}
+
+// SetHeader sets the value of Header.
+func (s *HmacSigning) SetHeader(val OptString) {
+	s.Header = val
+}
+
+// SetTimestampHeader sets the value of TimestampHeader.
+func (s *HmacSigning) SetTimestampHeader(val OptString) {
+	s.TimestampHeader = val
+}
+
+type HmacSigningPartsItem string
+
+const (
+	HmacSigningPartsItemMethod    HmacSigningPartsItem = "method"
+	HmacSigningPartsItemPath      HmacSigningPartsItem = "path"
+	HmacSigningPartsItemQuery     HmacSigningPartsItem = "query"
+	HmacSigningPartsItemTimestamp HmacSigningPartsItem = "timestamp"
+	HmacSigningPartsItemBody      HmacSigningPartsItem = "body"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (s HmacSigningPartsItem) MarshalText() ([]byte, error) {
+	switch s {
+	case HmacSigningPartsItemMethod:
+		return []byte(s), nil
+	case HmacSigningPartsItemPath:
+		return []byte(s), nil
+	case HmacSigningPartsItemQuery:
+		return []byte(s), nil
+	case HmacSigningPartsItemTimestamp:
+		return []byte(s), nil
+	case HmacSigningPartsItemBody:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *HmacSigningPartsItem) UnmarshalText(data []byte) error {
+	switch HmacSigningPartsItem(data) {
+	case HmacSigningPartsItemMethod:
+		*s = HmacSigningPartsItemMethod
+		return nil
+	case HmacSigningPartsItemPath:
+		*s = HmacSigningPartsItemPath
+		return nil
+	case HmacSigningPartsItemQuery:
+		*s = HmacSigningPartsItemQuery
+		return nil
+	case HmacSigningPartsItemTimestamp:
+		*s = HmacSigningPartsItemTimestamp
+		return nil
+	case HmacSigningPartsItemBody:
+		*s = HmacSigningPartsItemBody
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/oauth2ProfileInput
+type Oauth2ProfileInput struct {
+	// Profile name.
+	Name string `json:"name"`
+	// Token endpoint URL.
+	TokenURL string `json:"token_url"`
+	// OAuth2 client ID.
+	ClientID string `json:"client_id"`
+	// Name of the client secret holding the OAuth2 client secret.
+	ClientSecretName string `json:"client_secret_name"`
+	// Requested scopes.
+	Scopes []string `json:"scopes"`
+}
+
+// GetName returns the value of Name.
+func (s *Oauth2ProfileInput) GetName() string {
+	return s.Name
+}
+
+// GetTokenURL returns the value of TokenURL.
+func (s *Oauth2ProfileInput) GetTokenURL() string {
+	return s.TokenURL
+}
+
+// GetClientID returns the value of ClientID.
+func (s *Oauth2ProfileInput) GetClientID() string {
+	return s.ClientID
+}
+
+// GetClientSecretName returns the value of ClientSecretName.
+func (s *Oauth2ProfileInput) GetClientSecretName() string {
+	return s.ClientSecretName
+}
+
+// GetScopes returns the value of Scopes.
+func (s *Oauth2ProfileInput) GetScopes() []string {
+	return s.Scopes
+}
+
+// SetName sets the value of Name.
+func (s *Oauth2ProfileInput) SetName(val string) {
+	s.Name = val
+}
+
+// SetTokenURL sets the value of TokenURL.
+func (s *Oauth2ProfileInput) SetTokenURL(val string) {
+	s.TokenURL = val
+}
+
+// SetClientID sets the value of ClientID.
+func (s *Oauth2ProfileInput) SetClientID(val string) {
+	s.ClientID = val
+}
+
+// SetClientSecretName sets the value of ClientSecretName.
+func (s *Oauth2ProfileInput) SetClientSecretName(val string) {
+	s.ClientSecretName = val
+}
+
+// SetScopes sets the value of Scopes.
+func (s *Oauth2ProfileInput) SetScopes(val []string) {
+	s.Scopes = val
+}
+
+// Ref: #/components/schemas/oauth2ProfileListOutput
+type Oauth2ProfileListOutput struct {
+	// Client OAuth2 profiles.
+	Profiles []Oauth2ProfileOutput `json:"profiles"`
+}
+
+// GetProfiles returns the value of Profiles.
+func (s *Oauth2ProfileListOutput) GetProfiles() []Oauth2ProfileOutput {
+	return s.Profiles
+}
+
+// SetProfiles sets the value of Profiles.
+func (s *Oauth2ProfileListOutput) SetProfiles(val []Oauth2ProfileOutput) {
+	s.Profiles = val
+}
+
+// Ref: #/components/schemas/oauth2ProfileOutput
+type Oauth2ProfileOutput struct {
+	// Profile name.
+	Name string `json:"name"`
+	// Token endpoint URL.
+	TokenURL string `json:"token_url"`
+	// OAuth2 client ID.
+	ClientID string `json:"client_id"`
+	// Name of the client secret holding the OAuth2 client secret.
+	ClientSecretName string `json:"client_secret_name"`
+	// Requested scopes.
+	Scopes []string `json:"scopes"`
+	// Creation time.
+	CreatedAt time.Time `json:"created_at"`
+	// Last update time.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetName returns the value of Name.
+func (s *Oauth2ProfileOutput) GetName() string {
+	return s.Name
+}
+
+// GetTokenURL returns the value of TokenURL.
+func (s *Oauth2ProfileOutput) GetTokenURL() string {
+	return s.TokenURL
+}
+
+// GetClientID returns the value of ClientID.
+func (s *Oauth2ProfileOutput) GetClientID() string {
+	return s.ClientID
+}
+
+// GetClientSecretName returns the value of ClientSecretName.
+func (s *Oauth2ProfileOutput) GetClientSecretName() string {
+	return s.ClientSecretName
+}
+
+// GetScopes returns the value of Scopes.
+func (s *Oauth2ProfileOutput) GetScopes() []string {
+	return s.Scopes
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Oauth2ProfileOutput) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetUpdatedAt returns the value of UpdatedAt.
+func (s *Oauth2ProfileOutput) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
+
+// SetName sets the value of Name.
+func (s *Oauth2ProfileOutput) SetName(val string) {
+	s.Name = val
+}
+
+// SetTokenURL sets the value of TokenURL.
+func (s *Oauth2ProfileOutput) SetTokenURL(val string) {
+	s.TokenURL = val
+}
+
+// SetClientID sets the value of ClientID.
+func (s *Oauth2ProfileOutput) SetClientID(val string) {
+	s.ClientID = val
+}
+
+// SetClientSecretName sets the value of ClientSecretName.
+func (s *Oauth2ProfileOutput) SetClientSecretName(val string) {
+	s.ClientSecretName = val
+}
+
+// SetScopes sets the value of Scopes.
+func (s *Oauth2ProfileOutput) SetScopes(val []string) {
+	s.Scopes = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Oauth2ProfileOutput) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetUpdatedAt sets the value of UpdatedAt.
+func (s *Oauth2ProfileOutput) SetUpdatedAt(val time.Time) {
+	s.UpdatedAt = val
+}
+
+func (*Oauth2ProfileOutput) putOAuth2ProfileRes() {}
+
+// NewOptAwsSigV4Signing returns new OptAwsSigV4Signing with value set to v.
+func NewOptAwsSigV4Signing(v AwsSigV4Signing) OptAwsSigV4Signing {
+	return OptAwsSigV4Signing{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptAwsSigV4Signing is optional AwsSigV4Signing.
+type OptAwsSigV4Signing struct {
+	Value AwsSigV4Signing
+	Set   bool
+}
+
+// IsSet returns true if OptAwsSigV4Signing was set.
+func (o OptAwsSigV4Signing) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptAwsSigV4Signing) Reset() {
+	var v AwsSigV4Signing
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptAwsSigV4Signing) SetTo(v AwsSigV4Signing) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptAwsSigV4Signing) Get() (v AwsSigV4Signing, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptAwsSigV4Signing) Or(d AwsSigV4Signing) AwsSigV4Signing {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
+	Set   bool
+}
+
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptBool) SetTo(v bool) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBool) Get() (v bool, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptCreateTaskInputBody returns new OptCreateTaskInputBody with value set to v.
+func NewOptCreateTaskInputBody(v CreateTaskInputBody) OptCreateTaskInputBody {
+	return OptCreateTaskInputBody{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptCreateTaskInputBody is optional CreateTaskInputBody.
+type OptCreateTaskInputBody struct {
+	Value CreateTaskInputBody
+	Set   bool
+}
+
+// IsSet returns true if OptCreateTaskInputBody was set.
+func (o OptCreateTaskInputBody) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptCreateTaskInputBody) Reset() {
+	var v CreateTaskInputBody
 	o.Value = v
 	o.Set = false
 }
 
-// SetTo sets value to v.
-func (o *OptInt64) SetTo(v int64) {
-	o.Set = true
-	o.Value = v
+// SetTo sets value to v.
+func (o *OptCreateTaskInputBody) SetTo(v CreateTaskInputBody) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptCreateTaskInputBody) Get() (v CreateTaskInputBody, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptCreateTaskInputBody) Or(d CreateTaskInputBody) CreateTaskInputBody {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptCreateTaskInputExtract returns new OptCreateTaskInputExtract with value set to v.
+func NewOptCreateTaskInputExtract(v CreateTaskInputExtract) OptCreateTaskInputExtract {
+	return OptCreateTaskInputExtract{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptCreateTaskInputExtract is optional CreateTaskInputExtract.
+type OptCreateTaskInputExtract struct {
+	Value CreateTaskInputExtract
+	Set   bool
+}
+
+// IsSet returns true if OptCreateTaskInputExtract was set.
+func (o OptCreateTaskInputExtract) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptCreateTaskInputExtract) Reset() {
+	var v CreateTaskInputExtract
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptCreateTaskInputExtract) SetTo(v CreateTaskInputExtract) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptCreateTaskInputExtract) Get() (v CreateTaskInputExtract, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptCreateTaskInputExtract) Or(d CreateTaskInputExtract) CreateTaskInputExtract {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptCreateTaskInputHeaders returns new OptCreateTaskInputHeaders with value set to v.
+func NewOptCreateTaskInputHeaders(v CreateTaskInputHeaders) OptCreateTaskInputHeaders {
+	return OptCreateTaskInputHeaders{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptCreateTaskInputHeaders is optional CreateTaskInputHeaders.
+type OptCreateTaskInputHeaders struct {
+	Value CreateTaskInputHeaders
+	Set   bool
+}
+
+// IsSet returns true if OptCreateTaskInputHeaders was set.
+func (o OptCreateTaskInputHeaders) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptCreateTaskInputHeaders) Reset() {
+	var v CreateTaskInputHeaders
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptCreateTaskInputHeaders) SetTo(v CreateTaskInputHeaders) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptCreateTaskInputHeaders) Get() (v CreateTaskInputHeaders, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptCreateTaskInputHeaders) Or(d CreateTaskInputHeaders) CreateTaskInputHeaders {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptCreateTaskInputMethod returns new OptCreateTaskInputMethod with value set to v.
+func NewOptCreateTaskInputMethod(v CreateTaskInputMethod) OptCreateTaskInputMethod {
+	return OptCreateTaskInputMethod{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptCreateTaskInputMethod is optional CreateTaskInputMethod.
+type OptCreateTaskInputMethod struct {
+	Value CreateTaskInputMethod
+	Set   bool
+}
+
+// IsSet returns true if OptCreateTaskInputMethod was set.
+func (o OptCreateTaskInputMethod) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptCreateTaskInputMethod) Reset() {
+	var v CreateTaskInputMethod
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptCreateTaskInputMethod) SetTo(v CreateTaskInputMethod) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptCreateTaskInputMethod) Get() (v CreateTaskInputMethod, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptCreateTaskInputMethod) Or(d CreateTaskInputMethod) CreateTaskInputMethod {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptCreateTaskInputParams returns new OptCreateTaskInputParams with value set to v.
+func NewOptCreateTaskInputParams(v CreateTaskInputParams) OptCreateTaskInputParams {
+	return OptCreateTaskInputParams{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptCreateTaskInputParams is optional CreateTaskInputParams.
+type OptCreateTaskInputParams struct {
+	Value CreateTaskInputParams
+	Set   bool
+}
+
+// IsSet returns true if OptCreateTaskInputParams was set.
+func (o OptCreateTaskInputParams) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptCreateTaskInputParams) Reset() {
+	var v CreateTaskInputParams
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptCreateTaskInputParams) SetTo(v CreateTaskInputParams) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptCreateTaskInputParams) Get() (v CreateTaskInputParams, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptCreateTaskInputParams) Or(d CreateTaskInputParams) CreateTaskInputParams {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptCreateTaskInputVariables returns new OptCreateTaskInputVariables with value set to v.
+func NewOptCreateTaskInputVariables(v CreateTaskInputVariables) OptCreateTaskInputVariables {
+	return OptCreateTaskInputVariables{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptCreateTaskInputVariables is optional CreateTaskInputVariables.
+type OptCreateTaskInputVariables struct {
+	Value CreateTaskInputVariables
+	Set   bool
+}
+
+// IsSet returns true if OptCreateTaskInputVariables was set.
+func (o OptCreateTaskInputVariables) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptCreateTaskInputVariables) Reset() {
+	var v CreateTaskInputVariables
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptCreateTaskInputVariables) SetTo(v CreateTaskInputVariables) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptCreateTaskInputVariables) Get() (v CreateTaskInputVariables, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptCreateTaskInputVariables) Or(d CreateTaskInputVariables) CreateTaskInputVariables {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptCreateWorkflowInputVariables returns new OptCreateWorkflowInputVariables with value set to v.
+func NewOptCreateWorkflowInputVariables(v CreateWorkflowInputVariables) OptCreateWorkflowInputVariables {
+	return OptCreateWorkflowInputVariables{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptCreateWorkflowInputVariables is optional CreateWorkflowInputVariables.
+type OptCreateWorkflowInputVariables struct {
+	Value CreateWorkflowInputVariables
+	Set   bool
+}
+
+// IsSet returns true if OptCreateWorkflowInputVariables was set.
+func (o OptCreateWorkflowInputVariables) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptCreateWorkflowInputVariables) Reset() {
+	var v CreateWorkflowInputVariables
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptCreateWorkflowInputVariables) SetTo(v CreateWorkflowInputVariables) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptCreateWorkflowInputVariables) Get() (v CreateWorkflowInputVariables, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptCreateWorkflowInputVariables) Or(d CreateWorkflowInputVariables) CreateWorkflowInputVariables {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptFanoutTargetVariables returns new OptFanoutTargetVariables with value set to v.
+func NewOptFanoutTargetVariables(v FanoutTargetVariables) OptFanoutTargetVariables {
+	return OptFanoutTargetVariables{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptFanoutTargetVariables is optional FanoutTargetVariables.
+type OptFanoutTargetVariables struct {
+	Value FanoutTargetVariables
+	Set   bool
+}
+
+// IsSet returns true if OptFanoutTargetVariables was set.
+func (o OptFanoutTargetVariables) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptFanoutTargetVariables) Reset() {
+	var v FanoutTargetVariables
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptFanoutTargetVariables) SetTo(v FanoutTargetVariables) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFanoutTargetVariables) Get() (v FanoutTargetVariables, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptFanoutTargetVariables) Or(d FanoutTargetVariables) FanoutTargetVariables {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptHmacSigning returns new OptHmacSigning with value set to v.
+func NewOptHmacSigning(v HmacSigning) OptHmacSigning {
+	return OptHmacSigning{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptHmacSigning is optional HmacSigning.
+type OptHmacSigning struct {
+	Value HmacSigning
+	Set   bool
+}
+
+// IsSet returns true if OptHmacSigning was set.
+func (o OptHmacSigning) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptHmacSigning) Reset() {
+	var v HmacSigning
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptHmacSigning) SetTo(v HmacSigning) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptHmacSigning) Get() (v HmacSigning, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptHmacSigning) Or(d HmacSigning) HmacSigning {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
+}
+
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
+	Set   bool
+}
+
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt64) Reset() {
+	var v int64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt64) SetTo(v int64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt64) Get() (v int64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt64) Or(d int64) int64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptProxy returns new OptProxy with value set to v.
+func NewOptProxy(v Proxy) OptProxy {
+	return OptProxy{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptProxy is optional Proxy.
+type OptProxy struct {
+	Value Proxy
+	Set   bool
+}
+
+// IsSet returns true if OptProxy was set.
+func (o OptProxy) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptProxy) Reset() {
+	var v Proxy
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptProxy) SetTo(v Proxy) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptProxy) Get() (v Proxy, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptProxy) Or(d Proxy) Proxy {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptSigning returns new OptSigning with value set to v.
+func NewOptSigning(v Signing) OptSigning {
+	return OptSigning{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptSigning is optional Signing.
+type OptSigning struct {
+	Value Signing
+	Set   bool
+}
+
+// IsSet returns true if OptSigning was set.
+func (o OptSigning) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptSigning) Reset() {
+	var v Signing
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptSigning) SetTo(v Signing) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptSigning) Get() (v Signing, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptSigning) Or(d Signing) Signing {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptSuccess returns new OptSuccess with value set to v.
+func NewOptSuccess(v Success) OptSuccess {
+	return OptSuccess{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptSuccess is optional Success.
+type OptSuccess struct {
+	Value Success
+	Set   bool
+}
+
+// IsSet returns true if OptSuccess was set.
+func (o OptSuccess) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptSuccess) Reset() {
+	var v Success
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptSuccess) SetTo(v Success) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptSuccess) Get() (v Success, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptSuccess) Or(d Success) Success {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTaskPriority returns new OptTaskPriority with value set to v.
+func NewOptTaskPriority(v TaskPriority) OptTaskPriority {
+	return OptTaskPriority{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptTaskPriority is optional TaskPriority.
+type OptTaskPriority struct {
+	Value TaskPriority
+	Set   bool
+}
+
+// IsSet returns true if OptTaskPriority was set.
+func (o OptTaskPriority) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTaskPriority) Reset() {
+	var v TaskPriority
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptTaskPriority) SetTo(v TaskPriority) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTaskPriority) Get() (v TaskPriority, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptTaskPriority) Or(d TaskPriority) TaskPriority {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTaskStatusOutputExtracted returns new OptTaskStatusOutputExtracted with value set to v.
+func NewOptTaskStatusOutputExtracted(v TaskStatusOutputExtracted) OptTaskStatusOutputExtracted {
+	return OptTaskStatusOutputExtracted{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptTaskStatusOutputExtracted is optional TaskStatusOutputExtracted.
+type OptTaskStatusOutputExtracted struct {
+	Value TaskStatusOutputExtracted
+	Set   bool
+}
+
+// IsSet returns true if OptTaskStatusOutputExtracted was set.
+func (o OptTaskStatusOutputExtracted) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTaskStatusOutputExtracted) Reset() {
+	var v TaskStatusOutputExtracted
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptTaskStatusOutputExtracted) SetTo(v TaskStatusOutputExtracted) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTaskStatusOutputExtracted) Get() (v TaskStatusOutputExtracted, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptTaskStatusOutputExtracted) Or(d TaskStatusOutputExtracted) TaskStatusOutputExtracted {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTaskStatusOutputHeaders returns new OptTaskStatusOutputHeaders with value set to v.
+func NewOptTaskStatusOutputHeaders(v TaskStatusOutputHeaders) OptTaskStatusOutputHeaders {
+	return OptTaskStatusOutputHeaders{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptTaskStatusOutputHeaders is optional TaskStatusOutputHeaders.
+type OptTaskStatusOutputHeaders struct {
+	Value TaskStatusOutputHeaders
+	Set   bool
+}
+
+// IsSet returns true if OptTaskStatusOutputHeaders was set.
+func (o OptTaskStatusOutputHeaders) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTaskStatusOutputHeaders) Reset() {
+	var v TaskStatusOutputHeaders
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptTaskStatusOutputHeaders) SetTo(v TaskStatusOutputHeaders) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTaskStatusOutputHeaders) Get() (v TaskStatusOutputHeaders, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptTaskStatusOutputHeaders) Or(d TaskStatusOutputHeaders) TaskStatusOutputHeaders {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTemplateInputBody returns new OptTemplateInputBody with value set to v.
+func NewOptTemplateInputBody(v TemplateInputBody) OptTemplateInputBody {
+	return OptTemplateInputBody{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptTemplateInputBody is optional TemplateInputBody.
+type OptTemplateInputBody struct {
+	Value TemplateInputBody
+	Set   bool
+}
+
+// IsSet returns true if OptTemplateInputBody was set.
+func (o OptTemplateInputBody) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTemplateInputBody) Reset() {
+	var v TemplateInputBody
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptTemplateInputBody) SetTo(v TemplateInputBody) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTemplateInputBody) Get() (v TemplateInputBody, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptTemplateInputBody) Or(d TemplateInputBody) TemplateInputBody {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTemplateInputHeaders returns new OptTemplateInputHeaders with value set to v.
+func NewOptTemplateInputHeaders(v TemplateInputHeaders) OptTemplateInputHeaders {
+	return OptTemplateInputHeaders{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptTemplateInputHeaders is optional TemplateInputHeaders.
+type OptTemplateInputHeaders struct {
+	Value TemplateInputHeaders
+	Set   bool
+}
+
+// IsSet returns true if OptTemplateInputHeaders was set.
+func (o OptTemplateInputHeaders) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTemplateInputHeaders) Reset() {
+	var v TemplateInputHeaders
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptTemplateInputHeaders) SetTo(v TemplateInputHeaders) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTemplateInputHeaders) Get() (v TemplateInputHeaders, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptTemplateInputHeaders) Or(d TemplateInputHeaders) TemplateInputHeaders {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTemplateInputParameters returns new OptTemplateInputParameters with value set to v.
+func NewOptTemplateInputParameters(v TemplateInputParameters) OptTemplateInputParameters {
+	return OptTemplateInputParameters{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptTemplateInputParameters is optional TemplateInputParameters.
+type OptTemplateInputParameters struct {
+	Value TemplateInputParameters
+	Set   bool
+}
+
+// IsSet returns true if OptTemplateInputParameters was set.
+func (o OptTemplateInputParameters) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTemplateInputParameters) Reset() {
+	var v TemplateInputParameters
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptTemplateInputParameters) SetTo(v TemplateInputParameters) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTemplateInputParameters) Get() (v TemplateInputParameters, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptTemplateInputParameters) Or(d TemplateInputParameters) TemplateInputParameters {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTemplateOutputBody returns new OptTemplateOutputBody with value set to v.
+func NewOptTemplateOutputBody(v TemplateOutputBody) OptTemplateOutputBody {
+	return OptTemplateOutputBody{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptTemplateOutputBody is optional TemplateOutputBody.
+type OptTemplateOutputBody struct {
+	Value TemplateOutputBody
+	Set   bool
+}
+
+// IsSet returns true if OptTemplateOutputBody was set.
+func (o OptTemplateOutputBody) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTemplateOutputBody) Reset() {
+	var v TemplateOutputBody
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptTemplateOutputBody) SetTo(v TemplateOutputBody) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTemplateOutputBody) Get() (v TemplateOutputBody, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptTemplateOutputBody) Or(d TemplateOutputBody) TemplateOutputBody {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTemplateOutputHeaders returns new OptTemplateOutputHeaders with value set to v.
+func NewOptTemplateOutputHeaders(v TemplateOutputHeaders) OptTemplateOutputHeaders {
+	return OptTemplateOutputHeaders{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptTemplateOutputHeaders is optional TemplateOutputHeaders.
+type OptTemplateOutputHeaders struct {
+	Value TemplateOutputHeaders
+	Set   bool
+}
+
+// IsSet returns true if OptTemplateOutputHeaders was set.
+func (o OptTemplateOutputHeaders) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTemplateOutputHeaders) Reset() {
+	var v TemplateOutputHeaders
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptTemplateOutputHeaders) SetTo(v TemplateOutputHeaders) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTemplateOutputHeaders) Get() (v TemplateOutputHeaders, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptTemplateOutputHeaders) Or(d TemplateOutputHeaders) TemplateOutputHeaders {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTimings returns new OptTimings with value set to v.
+func NewOptTimings(v Timings) OptTimings {
+	return OptTimings{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptTimings is optional Timings.
+type OptTimings struct {
+	Value Timings
+	Set   bool
+}
+
+// IsSet returns true if OptTimings was set.
+func (o OptTimings) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTimings) Reset() {
+	var v Timings
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptTimings) SetTo(v Timings) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTimings) Get() (v Timings, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptTimings) Or(d Timings) Timings {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTlsVersion returns new OptTlsVersion with value set to v.
+func NewOptTlsVersion(v TlsVersion) OptTlsVersion {
+	return OptTlsVersion{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptTlsVersion is optional TlsVersion.
+type OptTlsVersion struct {
+	Value TlsVersion
+	Set   bool
+}
+
+// IsSet returns true if OptTlsVersion was set.
+func (o OptTlsVersion) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTlsVersion) Reset() {
+	var v TlsVersion
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptTlsVersion) SetTo(v TlsVersion) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTlsVersion) Get() (v TlsVersion, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptTlsVersion) Or(d TlsVersion) TlsVersion {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptWorkflowCondition returns new OptWorkflowCondition with value set to v.
+func NewOptWorkflowCondition(v WorkflowCondition) OptWorkflowCondition {
+	return OptWorkflowCondition{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptWorkflowCondition is optional WorkflowCondition.
+type OptWorkflowCondition struct {
+	Value WorkflowCondition
+	Set   bool
+}
+
+// IsSet returns true if OptWorkflowCondition was set.
+func (o OptWorkflowCondition) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptWorkflowCondition) Reset() {
+	var v WorkflowCondition
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptWorkflowCondition) SetTo(v WorkflowCondition) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptWorkflowCondition) Get() (v WorkflowCondition, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptWorkflowCondition) Or(d WorkflowCondition) WorkflowCondition {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Proxy to send the request through, HTTP CONNECT or SOCKS5. The password can be a secret reference
+// like `{{secret:name}}`.
+// Ref: #/components/schemas/proxy
+type Proxy struct {
+	// Proxy URL without credentials, e.g. `http://proxy:3128` or `socks5://proxy:1080`.
+	URL string `json:"url"`
+	// Proxy username.
+	Username OptString `json:"username"`
+	// Proxy password, never returned.
+	Password OptString `json:"password"`
+}
+
+// GetURL returns the value of URL.
+func (s *Proxy) GetURL() string {
+	return s.URL
+}
+
+// GetUsername returns the value of Username.
+func (s *Proxy) GetUsername() OptString {
+	return s.Username
+}
+
+// GetPassword returns the value of Password.
+func (s *Proxy) GetPassword() OptString {
+	return s.Password
+}
+
+// SetURL sets the value of URL.
+func (s *Proxy) SetURL(val string) {
+	s.URL = val
+}
+
+// SetUsername sets the value of Username.
+func (s *Proxy) SetUsername(val OptString) {
+	s.Username = val
+}
+
+// SetPassword sets the value of Password.
+func (s *Proxy) SetPassword(val OptString) {
+	s.Password = val
+}
+
+// Ref: #/components/schemas/secretInput
+type SecretInput struct {
+	// Secret name.
+	Name string `json:"name"`
+	// Secret value.
+	Value string `json:"value"`
+}
+
+// GetName returns the value of Name.
+func (s *SecretInput) GetName() string {
+	return s.Name
+}
+
+// GetValue returns the value of Value.
+func (s *SecretInput) GetValue() string {
+	return s.Value
+}
+
+// SetName sets the value of Name.
+func (s *SecretInput) SetName(val string) {
+	s.Name = val
+}
+
+// SetValue sets the value of Value.
+func (s *SecretInput) SetValue(val string) {
+	s.Value = val
+}
+
+// Ref: #/components/schemas/secretListOutput
+type SecretListOutput struct {
+	// Client secrets without values.
+	Secrets []SecretOutput `json:"secrets"`
+}
+
+// GetSecrets returns the value of Secrets.
+func (s *SecretListOutput) GetSecrets() []SecretOutput {
+	return s.Secrets
+}
+
+// SetSecrets sets the value of Secrets.
+func (s *SecretListOutput) SetSecrets(val []SecretOutput) {
+	s.Secrets = val
+}
+
+// Ref: #/components/schemas/secretOutput
+type SecretOutput struct {
+	// Secret name.
+	Name string `json:"name"`
+	// Creation time.
+	CreatedAt time.Time `json:"created_at"`
+	// Last update time.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetName returns the value of Name.
+func (s *SecretOutput) GetName() string {
+	return s.Name
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *SecretOutput) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetUpdatedAt returns the value of UpdatedAt.
+func (s *SecretOutput) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
+
+// SetName sets the value of Name.
+func (s *SecretOutput) SetName(val string) {
+	s.Name = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *SecretOutput) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetUpdatedAt sets the value of UpdatedAt.
+func (s *SecretOutput) SetUpdatedAt(val time.Time) {
+	s.UpdatedAt = val
+}
+
+// Request signing, computed by the worker right before the request is sent. Keys can be secret
+// references like `{{secret:name}}`.
+// Ref: #/components/schemas/signing
+type Signing struct {
+	// Signing algorithm.
+	Type     SigningType        `json:"type"`
+	Hmac     OptHmacSigning     `json:"hmac"`
+	AWSSigv4 OptAwsSigV4Signing `json:"aws_sigv4"`
+}
+
+// GetType returns the value of Type.
+func (s *Signing) GetType() SigningType {
+	return s.Type
+}
+
+// GetHmac returns the value of Hmac.
+func (s *Signing) GetHmac() OptHmacSigning {
+	return s.Hmac
+}
+
+// GetAWSSigv4 returns the value of AWSSigv4.
+func (s *Signing) GetAWSSigv4() OptAwsSigV4Signing {
+	return s.AWSSigv4
+}
+
+// SetType sets the value of Type.
+func (s *Signing) SetType(val SigningType) {
+	s.Type = val
+}
+
+// SetHmac sets the value of Hmac.
+func (s *Signing) SetHmac(val OptHmacSigning) {
+	s.Hmac = val
+}
+
+// SetAWSSigv4 sets the value of AWSSigv4.
+func (s *Signing) SetAWSSigv4(val OptAwsSigV4Signing) {
+	s.AWSSigv4 = val
+}
+
+// Signing algorithm.
+type SigningType string
+
+const (
+	SigningTypeHmacSHA256 SigningType = "hmac_sha256"
+	SigningTypeAWSSigv4   SigningType = "aws_sigv4"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (s SigningType) MarshalText() ([]byte, error) {
+	switch s {
+	case SigningTypeHmacSHA256:
+		return []byte(s), nil
+	case SigningTypeAWSSigv4:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *SigningType) UnmarshalText(data []byte) error {
+	switch SigningType(data) {
+	case SigningTypeHmacSHA256:
+		*s = SigningTypeHmacSHA256
+		return nil
+	case SigningTypeAWSSigv4:
+		*s = SigningTypeAWSSigv4
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Success criteria of the response. All assertions must pass for the task to be done, otherwise the
+// task is failed with the failed assertion reported. Any response is accepted if not set.
+// Ref: #/components/schemas/success
+type Success struct {
+	// Accepted inclusive status code ranges.
+	StatusCodes []SuccessStatusCodesItem `json:"status_codes"`
+	// Required response headers.
+	Headers []SuccessHeadersItem `json:"headers"`
+	// Substring the response body must contain.
+	BodyContains OptString `json:"body_contains"`
+	// Regular expression (RE2) the response body must match.
+	BodyRegex OptString `json:"body_regex"`
+	// Values of the JSON response body.
+	JSON []SuccessJSONItem `json:"json"`
+	// Retry the request while assertions fail, up to the queue attempts limit.
+	Retry OptBool `json:"retry"`
+}
+
+// GetStatusCodes returns the value of StatusCodes.
+func (s *Success) GetStatusCodes() []SuccessStatusCodesItem {
+	return s.StatusCodes
+}
+
+// GetHeaders returns the value of Headers.
+func (s *Success) GetHeaders() []SuccessHeadersItem {
+	return s.Headers
+}
+
+// GetBodyContains returns the value of BodyContains.
+func (s *Success) GetBodyContains() OptString {
+	return s.BodyContains
+}
+
+// GetBodyRegex returns the value of BodyRegex.
+func (s *Success) GetBodyRegex() OptString {
+	return s.BodyRegex
+}
+
+// GetJSON returns the value of JSON.
+func (s *Success) GetJSON() []SuccessJSONItem {
+	return s.JSON
+}
+
+// GetRetry returns the value of Retry.
+func (s *Success) GetRetry() OptBool {
+	return s.Retry
+}
+
+// SetStatusCodes sets the value of StatusCodes.
+func (s *Success) SetStatusCodes(val []SuccessStatusCodesItem) {
+	s.StatusCodes = val
+}
+
+// SetHeaders sets the value of Headers.
+func (s *Success) SetHeaders(val []SuccessHeadersItem) {
+	s.Headers = val
+}
+
+// SetBodyContains sets the value of BodyContains.
+func (s *Success) SetBodyContains(val OptString) {
+	s.BodyContains = val
+}
+
+// SetBodyRegex sets the value of BodyRegex.
+func (s *Success) SetBodyRegex(val OptString) {
+	s.BodyRegex = val
+}
+
+// SetJSON sets the value of JSON.
+func (s *Success) SetJSON(val []SuccessJSONItem) {
+	s.JSON = val
+}
+
+// SetRetry sets the value of Retry.
+func (s *Success) SetRetry(val OptBool) {
+	s.Retry = val
+}
+
+type SuccessHeadersItem struct {
+	// Header name.
+	Name string `json:"name"`
+	// Exact header value, any value is accepted if not set.
+	Value OptString `json:"value"`
+}
+
+// GetName returns the value of Name.
+func (s *SuccessHeadersItem) GetName() string {
+	return s.Name
+}
+
+// GetValue returns the value of Value.
+func (s *SuccessHeadersItem) GetValue() OptString {
+	return s.Value
+}
+
+// SetName sets the value of Name.
+func (s *SuccessHeadersItem) SetName(val string) {
+	s.Name = val
+}
+
+// SetValue sets the value of Value.
+func (s *SuccessHeadersItem) SetValue(val OptString) {
+	s.Value = val
+}
+
+type SuccessJSONItem struct {
+	// JSONPath of the value, e.g. `$.data.items[0].id`.
+	Path string `json:"path"`
+	// Expected JSON value.
+	Equals jx.Raw `json:"equals"`
+}
+
+// GetPath returns the value of Path.
+func (s *SuccessJSONItem) GetPath() string {
+	return s.Path
+}
+
+// GetEquals returns the value of Equals.
+func (s *SuccessJSONItem) GetEquals() jx.Raw {
+	return s.Equals
+}
+
+// SetPath sets the value of Path.
+func (s *SuccessJSONItem) SetPath(val string) {
+	s.Path = val
+}
+
+// SetEquals sets the value of Equals.
+func (s *SuccessJSONItem) SetEquals(val jx.Raw) {
+	s.Equals = val
+}
+
+type SuccessStatusCodesItem struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// GetFrom returns the value of From.
+func (s *SuccessStatusCodesItem) GetFrom() int {
+	return s.From
+}
+
+// GetTo returns the value of To.
+func (s *SuccessStatusCodesItem) GetTo() int {
+	return s.To
+}
+
+// SetFrom sets the value of From.
+func (s *SuccessStatusCodesItem) SetFrom(val int) {
+	s.From = val
+}
+
+// SetTo sets the value of To.
+func (s *SuccessStatusCodesItem) SetTo(val int) {
+	s.To = val
+}
+
+// Ref: #/components/schemas/taskGroupOutput
+type TaskGroupOutput struct {
+	// Task group ID.
+	ID uuid.UUID `json:"id"`
+	// Number of the group tasks.
+	Total int `json:"total"`
+	// Number of the group tasks by status.
+	Counts TaskGroupOutputCounts `json:"counts"`
+	// Whether none of the group tasks is to be sent or in process. Errored tasks may still be retried.
+	Complete  bool      `json:"complete"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *TaskGroupOutput) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetTotal returns the value of Total.
+func (s *TaskGroupOutput) GetTotal() int {
+	return s.Total
+}
+
+// GetCounts returns the value of Counts.
+func (s *TaskGroupOutput) GetCounts() TaskGroupOutputCounts {
+	return s.Counts
+}
+
+// GetComplete returns the value of Complete.
+func (s *TaskGroupOutput) GetComplete() bool {
+	return s.Complete
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *TaskGroupOutput) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *TaskGroupOutput) SetID(val uuid.UUID) {
+	s.ID = val
+}
+
+// SetTotal sets the value of Total.
+func (s *TaskGroupOutput) SetTotal(val int) {
+	s.Total = val
+}
+
+// SetCounts sets the value of Counts.
+func (s *TaskGroupOutput) SetCounts(val TaskGroupOutputCounts) {
+	s.Counts = val
+}
+
+// SetComplete sets the value of Complete.
+func (s *TaskGroupOutput) SetComplete(val bool) {
+	s.Complete = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *TaskGroupOutput) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+func (*TaskGroupOutput) getTaskGroupRes() {}
+
+// Number of the group tasks by status.
+type TaskGroupOutputCounts map[string]int
+
+func (s *TaskGroupOutputCounts) init() TaskGroupOutputCounts {
+	m := *s
+	if m == nil {
+		m = map[string]int{}
+		*s = m
+	}
+	return m
+}
+
+// Priority of the task queue. Workers poll the queues of all priorities with weighted fairness, so
+// high priority tasks aren't stuck behind bulk low priority ones.
+// Ref: #/components/schemas/taskPriority
+type TaskPriority string
+
+const (
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityNormal TaskPriority = "normal"
+	TaskPriorityLow    TaskPriority = "low"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TaskPriority) MarshalText() ([]byte, error) {
+	switch s {
+	case TaskPriorityHigh:
+		return []byte(s), nil
+	case TaskPriorityNormal:
+		return []byte(s), nil
+	case TaskPriorityLow:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *TaskPriority) UnmarshalText(data []byte) error {
+	switch TaskPriority(data) {
+	case TaskPriorityHigh:
+		*s = TaskPriorityHigh
+		return nil
+	case TaskPriorityNormal:
+		*s = TaskPriorityNormal
+		return nil
+	case TaskPriorityLow:
+		*s = TaskPriorityLow
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/taskStatus
+type TaskStatus string
+
+const (
+	TaskStatusNew       TaskStatus = "new"
+	TaskStatusDone      TaskStatus = "done"
+	TaskStatusError     TaskStatus = "error"
+	TaskStatusInProcess TaskStatus = "in_process"
+	TaskStatusFailed    TaskStatus = "failed"
+	TaskStatusWaiting   TaskStatus = "waiting"
+	TaskStatusSkipped   TaskStatus = "skipped"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TaskStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case TaskStatusNew:
+		return []byte(s), nil
+	case TaskStatusDone:
+		return []byte(s), nil
+	case TaskStatusError:
+		return []byte(s), nil
+	case TaskStatusInProcess:
+		return []byte(s), nil
+	case TaskStatusFailed:
+		return []byte(s), nil
+	case TaskStatusWaiting:
+		return []byte(s), nil
+	case TaskStatusSkipped:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *TaskStatus) UnmarshalText(data []byte) error {
+	switch TaskStatus(data) {
+	case TaskStatusNew:
+		*s = TaskStatusNew
+		return nil
+	case TaskStatusDone:
+		*s = TaskStatusDone
+		return nil
+	case TaskStatusError:
+		*s = TaskStatusError
+		return nil
+	case TaskStatusInProcess:
+		*s = TaskStatusInProcess
+		return nil
+	case TaskStatusFailed:
+		*s = TaskStatusFailed
+		return nil
+	case TaskStatusWaiting:
+		*s = TaskStatusWaiting
+		return nil
+	case TaskStatusSkipped:
+		*s = TaskStatusSkipped
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/taskStatusOutput
+type TaskStatusOutput struct {
+	// Task ID.
+	ID uuid.UUID `json:"id"`
+	// Processing status.
+	Status   TaskStatus      `json:"status"`
+	Priority OptTaskPriority `json:"priority"`
+	// Name of the queue the task was routed to, the default queue if not set.
+	Queue OptString `json:"queue"`
+	// Key of the tasks delivered in order by FIFO queues.
+	OrderingKey OptString `json:"ordering_key"`
+	// Response headers.
+	Headers OptTaskStatusOutputHeaders `json:"headers"`
+	// Response status code.
+	HTTPStatusCode OptInt `json:"http_status_code"`
+	// Response content length.
+	Length OptInt64 `json:"length"`
+	// Proxy the request was sent through, without credentials.
+	Proxy   OptString  `json:"proxy"`
+	Timings OptTimings `json:"timings"`
+	// Assertion of the success criteria the response failed.
+	FailedAssertion OptString `json:"failed_assertion"`
+	// Values extracted from the response by name.
+	Extracted OptTaskStatusOutputExtracted `json:"extracted"`
+	// IDs of parent tasks.
+	DependsOn []uuid.UUID `json:"depends_on"`
+}
+
+// GetID returns the value of ID.
+func (s *TaskStatusOutput) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetStatus returns the value of Status.
+func (s *TaskStatusOutput) GetStatus() TaskStatus {
+	return s.Status
+}
+
+// GetPriority returns the value of Priority.
+func (s *TaskStatusOutput) GetPriority() OptTaskPriority {
+	return s.Priority
+}
+
+// GetQueue returns the value of Queue.
+func (s *TaskStatusOutput) GetQueue() OptString {
+	return s.Queue
+}
+
+// GetOrderingKey returns the value of OrderingKey.
+func (s *TaskStatusOutput) GetOrderingKey() OptString {
+	return s.OrderingKey
+}
+
+// GetHeaders returns the value of Headers.
+func (s *TaskStatusOutput) GetHeaders() OptTaskStatusOutputHeaders {
+	return s.Headers
+}
+
+// GetHTTPStatusCode returns the value of HTTPStatusCode.
+func (s *TaskStatusOutput) GetHTTPStatusCode() OptInt {
+	return s.HTTPStatusCode
+}
+
+// GetLength returns the value of Length.
+func (s *TaskStatusOutput) GetLength() OptInt64 {
+	return s.Length
+}
+
+// GetProxy returns the value of Proxy.
+func (s *TaskStatusOutput) GetProxy() OptString {
+	return s.Proxy
+}
+
+// GetTimings returns the value of Timings.
+func (s *TaskStatusOutput) GetTimings() OptTimings {
+	return s.Timings
+}
+
+// GetFailedAssertion returns the value of FailedAssertion.
+func (s *TaskStatusOutput) GetFailedAssertion() OptString {
+	return s.FailedAssertion
+}
+
+// GetExtracted returns the value of Extracted.
+func (s *TaskStatusOutput) GetExtracted() OptTaskStatusOutputExtracted {
+	return s.Extracted
+}
+
+// GetDependsOn returns the value of DependsOn.
+func (s *TaskStatusOutput) GetDependsOn() []uuid.UUID {
+	return s.DependsOn
+}
+
+// SetID sets the value of ID.
+func (s *TaskStatusOutput) SetID(val uuid.UUID) {
+	s.ID = val
+}
+
+// SetStatus sets the value of Status.
+func (s *TaskStatusOutput) SetStatus(val TaskStatus) {
+	s.Status = val
+}
+
+// SetPriority sets the value of Priority.
+func (s *TaskStatusOutput) SetPriority(val OptTaskPriority) {
+	s.Priority = val
+}
+
+// SetQueue sets the value of Queue.
+func (s *TaskStatusOutput) SetQueue(val OptString) {
+	s.Queue = val
+}
+
+// SetOrderingKey sets the value of OrderingKey.
+func (s *TaskStatusOutput) SetOrderingKey(val OptString) {
+	s.OrderingKey = val
+}
+
+// SetHeaders sets the value of Headers.
+func (s *TaskStatusOutput) SetHeaders(val OptTaskStatusOutputHeaders) {
+	s.Headers = val
+}
+
+// SetHTTPStatusCode sets the value of HTTPStatusCode.
+func (s *TaskStatusOutput) SetHTTPStatusCode(val OptInt) {
+	s.HTTPStatusCode = val
+}
+
+// SetLength sets the value of Length.
+func (s *TaskStatusOutput) SetLength(val OptInt64) {
+	s.Length = val
+}
+
+// SetProxy sets the value of Proxy.
+func (s *TaskStatusOutput) SetProxy(val OptString) {
+	s.Proxy = val
+}
+
+// SetTimings sets the value of Timings.
+func (s *TaskStatusOutput) SetTimings(val OptTimings) {
+	s.Timings = val
+}
+
+// SetFailedAssertion sets the value of FailedAssertion.
+func (s *TaskStatusOutput) SetFailedAssertion(val OptString) {
+	s.FailedAssertion = val
+}
+
+// SetExtracted sets the value of Extracted.
+func (s *TaskStatusOutput) SetExtracted(val OptTaskStatusOutputExtracted) {
+	s.Extracted = val
+}
+
+// SetDependsOn sets the value of DependsOn.
+func (s *TaskStatusOutput) SetDependsOn(val []uuid.UUID) {
+	s.DependsOn = val
+}
+
+func (*TaskStatusOutput) getTaskStatusRes() {}
+
+// Values extracted from the response by name.
+type TaskStatusOutputExtracted map[string]jx.Raw
+
+func (s *TaskStatusOutputExtracted) init() TaskStatusOutputExtracted {
+	m := *s
+	if m == nil {
+		m = map[string]jx.Raw{}
+		*s = m
+	}
+	return m
+}
+
+// Response headers.
+type TaskStatusOutputHeaders map[string][]string
+
+func (s *TaskStatusOutputHeaders) init() TaskStatusOutputHeaders {
+	m := *s
+	if m == nil {
+		m = map[string][]string{}
+		*s = m
+	}
+	return m
+}
+
+// Ref: #/components/schemas/templateInput
+type TemplateInput struct {
+	// Template name.
+	Name string `json:"name"`
+	// Request method.
+	Method TemplateInputMethod `json:"method"`
+	// Request URL.
+	URL string `json:"url"`
+	// Request headers.
+	Headers OptTemplateInputHeaders `json:"headers"`
+	// Request body.
+	Body OptTemplateInputBody `json:"body"`
+	// Parameter schemas by name.
+	Parameters OptTemplateInputParameters `json:"parameters"`
+}
+
+// GetName returns the value of Name.
+func (s *TemplateInput) GetName() string {
+	return s.Name
+}
+
+// GetMethod returns the value of Method.
+func (s *TemplateInput) GetMethod() TemplateInputMethod {
+	return s.Method
+}
+
+// GetURL returns the value of URL.
+func (s *TemplateInput) GetURL() string {
+	return s.URL
+}
+
+// GetHeaders returns the value of Headers.
+func (s *TemplateInput) GetHeaders() OptTemplateInputHeaders {
+	return s.Headers
+}
+
+// GetBody returns the value of Body.
+func (s *TemplateInput) GetBody() OptTemplateInputBody {
+	return s.Body
+}
+
+// GetParameters returns the value of Parameters.
+func (s *TemplateInput) GetParameters() OptTemplateInputParameters {
+	return s.Parameters
+}
+
+// SetName sets the value of Name.
+func (s *TemplateInput) SetName(val string) {
+	s.Name = val
+}
+
+// SetMethod sets the value of Method.
+func (s *TemplateInput) SetMethod(val TemplateInputMethod) {
+	s.Method = val
+}
+
+// SetURL sets the value of URL.
+func (s *TemplateInput) SetURL(val string) {
+	s.URL = val
+}
+
+// SetHeaders sets the value of Headers.
+func (s *TemplateInput) SetHeaders(val OptTemplateInputHeaders) {
+	s.Headers = val
+}
+
+// SetBody sets the value of Body.
+func (s *TemplateInput) SetBody(val OptTemplateInputBody) {
+	s.Body = val
+}
+
+// SetParameters sets the value of Parameters.
+func (s *TemplateInput) SetParameters(val OptTemplateInputParameters) {
+	s.Parameters = val
+}
+
+// Request body.
+type TemplateInputBody map[string]jx.Raw
+
+func (s *TemplateInputBody) init() TemplateInputBody {
+	m := *s
+	if m == nil {
+		m = map[string]jx.Raw{}
+		*s = m
+	}
+	return m
+}
+
+// Request headers.
+type TemplateInputHeaders map[string]string
+
+func (s *TemplateInputHeaders) init() TemplateInputHeaders {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
+	}
+	return m
+}
+
+// Request method.
+type TemplateInputMethod string
+
+const (
+	TemplateInputMethodHEAD   TemplateInputMethod = "HEAD"
+	TemplateInputMethodGET    TemplateInputMethod = "GET"
+	TemplateInputMethodPOST   TemplateInputMethod = "POST"
+	TemplateInputMethodPUT    TemplateInputMethod = "PUT"
+	TemplateInputMethodPATCH  TemplateInputMethod = "PATCH"
+	TemplateInputMethodDELETE TemplateInputMethod = "DELETE"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TemplateInputMethod) MarshalText() ([]byte, error) {
+	switch s {
+	case TemplateInputMethodHEAD:
+		return []byte(s), nil
+	case TemplateInputMethodGET:
+		return []byte(s), nil
+	case TemplateInputMethodPOST:
+		return []byte(s), nil
+	case TemplateInputMethodPUT:
+		return []byte(s), nil
+	case TemplateInputMethodPATCH:
+		return []byte(s), nil
+	case TemplateInputMethodDELETE:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *TemplateInputMethod) UnmarshalText(data []byte) error {
+	switch TemplateInputMethod(data) {
+	case TemplateInputMethodHEAD:
+		*s = TemplateInputMethodHEAD
+		return nil
+	case TemplateInputMethodGET:
+		*s = TemplateInputMethodGET
+		return nil
+	case TemplateInputMethodPOST:
+		*s = TemplateInputMethodPOST
+		return nil
+	case TemplateInputMethodPUT:
+		*s = TemplateInputMethodPUT
+		return nil
+	case TemplateInputMethodPATCH:
+		*s = TemplateInputMethodPATCH
+		return nil
+	case TemplateInputMethodDELETE:
+		*s = TemplateInputMethodDELETE
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Parameter schemas by name.
+type TemplateInputParameters map[string]TemplateParameter
+
+func (s *TemplateInputParameters) init() TemplateInputParameters {
+	m := *s
+	if m == nil {
+		m = map[string]TemplateParameter{}
+		*s = m
+	}
+	return m
+}
+
+// Ref: #/components/schemas/templateListOutput
+type TemplateListOutput struct {
+	// Latest versions of client templates.
+	Templates []TemplateOutput `json:"templates"`
+}
+
+// GetTemplates returns the value of Templates.
+func (s *TemplateListOutput) GetTemplates() []TemplateOutput {
+	return s.Templates
+}
+
+// SetTemplates sets the value of Templates.
+func (s *TemplateListOutput) SetTemplates(val []TemplateOutput) {
+	s.Templates = val
+}
+
+// Ref: #/components/schemas/templateOutput
+type TemplateOutput struct {
+	// Template name.
+	Name string `json:"name"`
+	// Template version.
+	Version int `json:"version"`
+	// Request method.
+	Method string `json:"method"`
+	// Request URL.
+	URL string `json:"url"`
+	// Request headers.
+	Headers OptTemplateOutputHeaders `json:"headers"`
+	// Request body.
+	Body OptTemplateOutputBody `json:"body"`
+	// Parameter schemas by name.
+	Parameters TemplateOutputParameters `json:"parameters"`
+	// Creation time of the version.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetName returns the value of Name.
+func (s *TemplateOutput) GetName() string {
+	return s.Name
+}
+
+// GetVersion returns the value of Version.
+func (s *TemplateOutput) GetVersion() int {
+	return s.Version
+}
+
+// GetMethod returns the value of Method.
+func (s *TemplateOutput) GetMethod() string {
+	return s.Method
+}
+
+// GetURL returns the value of URL.
+func (s *TemplateOutput) GetURL() string {
+	return s.URL
+}
+
+// GetHeaders returns the value of Headers.
+func (s *TemplateOutput) GetHeaders() OptTemplateOutputHeaders {
+	return s.Headers
+}
+
+// GetBody returns the value of Body.
+func (s *TemplateOutput) GetBody() OptTemplateOutputBody {
+	return s.Body
+}
+
+// GetParameters returns the value of Parameters.
+func (s *TemplateOutput) GetParameters() TemplateOutputParameters {
+	return s.Parameters
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *TemplateOutput) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetName sets the value of Name.
+func (s *TemplateOutput) SetName(val string) {
+	s.Name = val
+}
+
+// SetVersion sets the value of Version.
+func (s *TemplateOutput) SetVersion(val int) {
+	s.Version = val
+}
+
+// SetMethod sets the value of Method.
+func (s *TemplateOutput) SetMethod(val string) {
+	s.Method = val
+}
+
+// SetURL sets the value of URL.
+func (s *TemplateOutput) SetURL(val string) {
+	s.URL = val
+}
+
+// SetHeaders sets the value of Headers.
+func (s *TemplateOutput) SetHeaders(val OptTemplateOutputHeaders) {
+	s.Headers = val
+}
+
+// SetBody sets the value of Body.
+func (s *TemplateOutput) SetBody(val OptTemplateOutputBody) {
+	s.Body = val
+}
+
+// SetParameters sets the value of Parameters.
+func (s *TemplateOutput) SetParameters(val TemplateOutputParameters) {
+	s.Parameters = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *TemplateOutput) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+func (*TemplateOutput) createTemplateRes() {}
+func (*TemplateOutput) getTemplateRes()    {}
+
+// Request body.
+type TemplateOutputBody map[string]jx.Raw
+
+func (s *TemplateOutputBody) init() TemplateOutputBody {
+	m := *s
+	if m == nil {
+		m = map[string]jx.Raw{}
+		*s = m
+	}
+	return m
+}
+
+// Request headers.
+type TemplateOutputHeaders map[string]string
+
+func (s *TemplateOutputHeaders) init() TemplateOutputHeaders {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
+	}
+	return m
+}
+
+// Parameter schemas by name.
+type TemplateOutputParameters map[string]TemplateParameter
+
+func (s *TemplateOutputParameters) init() TemplateOutputParameters {
+	m := *s
+	if m == nil {
+		m = map[string]TemplateParameter{}
+		*s = m
+	}
+	return m
+}
+
+// Template parameter schema.
+// Ref: #/components/schemas/templateParameter
+type TemplateParameter struct {
+	// JSON type of the parameter value.
+	Type TemplateParameterType `json:"type"`
+	// Whether the parameter must be set, optional parameters are empty strings if not set.
+	Required OptBool `json:"required"`
+	// JSON value of the parameter if not set.
+	Default jx.Raw `json:"default"`
+	// Regular expression (RE2) string values must match.
+	Pattern OptString `json:"pattern"`
+	// Parameter description.
+	Description OptString `json:"description"`
+}
+
+// GetType returns the value of Type.
+func (s *TemplateParameter) GetType() TemplateParameterType {
+	return s.Type
+}
+
+// GetRequired returns the value of Required.
+func (s *TemplateParameter) GetRequired() OptBool {
+	return s.Required
+}
+
+// GetDefault returns the value of Default.
+func (s *TemplateParameter) GetDefault() jx.Raw {
+	return s.Default
+}
+
+// GetPattern returns the value of Pattern.
+func (s *TemplateParameter) GetPattern() OptString {
+	return s.Pattern
+}
+
+// GetDescription returns the value of Description.
+func (s *TemplateParameter) GetDescription() OptString {
+	return s.Description
+}
+
+// SetType sets the value of Type.
+func (s *TemplateParameter) SetType(val TemplateParameterType) {
+	s.Type = val
+}
+
+// SetRequired sets the value of Required.
+func (s *TemplateParameter) SetRequired(val OptBool) {
+	s.Required = val
+}
+
+// SetDefault sets the value of Default.
+func (s *TemplateParameter) SetDefault(val jx.Raw) {
+	s.Default = val
+}
+
+// SetPattern sets the value of Pattern.
+func (s *TemplateParameter) SetPattern(val OptString) {
+	s.Pattern = val
+}
+
+// SetDescription sets the value of Description.
+func (s *TemplateParameter) SetDescription(val OptString) {
+	s.Description = val
+}
+
+// JSON type of the parameter value.
+type TemplateParameterType string
+
+const (
+	TemplateParameterTypeString  TemplateParameterType = "string"
+	TemplateParameterTypeInteger TemplateParameterType = "integer"
+	TemplateParameterTypeNumber  TemplateParameterType = "number"
+	TemplateParameterTypeBoolean TemplateParameterType = "boolean"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TemplateParameterType) MarshalText() ([]byte, error) {
+	switch s {
+	case TemplateParameterTypeString:
+		return []byte(s), nil
+	case TemplateParameterTypeInteger:
+		return []byte(s), nil
+	case TemplateParameterTypeNumber:
+		return []byte(s), nil
+	case TemplateParameterTypeBoolean:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *TemplateParameterType) UnmarshalText(data []byte) error {
+	switch TemplateParameterType(data) {
+	case TemplateParameterTypeString:
+		*s = TemplateParameterTypeString
+		return nil
+	case TemplateParameterTypeInteger:
+		*s = TemplateParameterTypeInteger
+		return nil
+	case TemplateParameterTypeNumber:
+		*s = TemplateParameterTypeNumber
+		return nil
+	case TemplateParameterTypeBoolean:
+		*s = TemplateParameterTypeBoolean
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Timing breakdown of the last request attempt in milliseconds. Connection phases are zero if the
+// connection was reused.
+// Ref: #/components/schemas/timings
+type Timings struct {
+	// DNS lookup duration.
+	DNSMs float64 `json:"dns_ms"`
+	// TCP connect duration, to the proxy if the request was proxied.
+	ConnectMs float64 `json:"connect_ms"`
+	// TLS handshake duration.
+	TLSHandshakeMs float64 `json:"tls_handshake_ms"`
+	// Time from the request start to the first response byte.
+	TtfbMs float64 `json:"ttfb_ms"`
+	// Total duration including token requests and retries.
+	TotalMs float64 `json:"total_ms"`
+	// Remote IP address of the connection, the proxy one if the request was proxied.
+	RemoteIP OptString `json:"remote_ip"`
+	// Whether a previously opened connection was reused.
+	ConnectionReused bool `json:"connection_reused"`
+}
+
+// GetDNSMs returns the value of DNSMs.
+func (s *Timings) GetDNSMs() float64 {
+	return s.DNSMs
+}
+
+// GetConnectMs returns the value of ConnectMs.
+func (s *Timings) GetConnectMs() float64 {
+	return s.ConnectMs
+}
+
+// GetTLSHandshakeMs returns the value of TLSHandshakeMs.
+func (s *Timings) GetTLSHandshakeMs() float64 {
+	return s.TLSHandshakeMs
+}
+
+// GetTtfbMs returns the value of TtfbMs.
+func (s *Timings) GetTtfbMs() float64 {
+	return s.TtfbMs
+}
+
+// GetTotalMs returns the value of TotalMs.
+func (s *Timings) GetTotalMs() float64 {
+	return s.TotalMs
+}
+
+// GetRemoteIP returns the value of RemoteIP.
+func (s *Timings) GetRemoteIP() OptString {
+	return s.RemoteIP
+}
+
+// GetConnectionReused returns the value of ConnectionReused.
+func (s *Timings) GetConnectionReused() bool {
+	return s.ConnectionReused
 }
 
-// Get returns value and boolean that denotes whether value was set.
-func (o OptInt64) Get() (v int64, ok bool) {
-	if !o.Set {
-		return v, false
-	}
-	return o.Value, true
+// SetDNSMs sets the value of DNSMs.
+func (s *Timings) SetDNSMs(val float64) {
+	s.DNSMs = val
 }
 
-// Or returns value if set, or given parameter if does not.
-func (o OptInt64) Or(d int64) int64 {
-	if v, ok := o.Get(); ok {
-		return v
-	}
-	return d
+// SetConnectMs sets the value of ConnectMs.
+func (s *Timings) SetConnectMs(val float64) {
+	s.ConnectMs = val
 }
 
-// NewOptTaskStatusOutputHeaders returns new OptTaskStatusOutputHeaders with value set to v.
-func NewOptTaskStatusOutputHeaders(v TaskStatusOutputHeaders) OptTaskStatusOutputHeaders {
-	return OptTaskStatusOutputHeaders{
-		Value: v,
-		Set:   true,
-	}
+// SetTLSHandshakeMs sets the value of TLSHandshakeMs.
+func (s *Timings) SetTLSHandshakeMs(val float64) {
+	s.TLSHandshakeMs = val
 }
 
-// OptTaskStatusOutputHeaders is optional TaskStatusOutputHeaders.
-type OptTaskStatusOutputHeaders struct {
-	Value TaskStatusOutputHeaders
-	Set   bool
+// SetTtfbMs sets the value of TtfbMs.
+func (s *Timings) SetTtfbMs(val float64) {
+	s.TtfbMs = val
 }
 
-// IsSet returns true if OptTaskStatusOutputHeaders was set.
-func (o OptTaskStatusOutputHeaders) IsSet() bool { return o.Set }
+// SetTotalMs sets the value of TotalMs.
+func (s *Timings) SetTotalMs(val float64) {
+	s.TotalMs = val
+}
 
-// Reset unsets value.
-func (o *OptTaskStatusOutputHeaders) Reset() {
-	var v TaskStatusOutputHeaders
-	o.Value = v
-	o.Set = false
+// SetRemoteIP sets the value of RemoteIP.
+func (s *Timings) SetRemoteIP(val OptString) {
+	s.RemoteIP = val
 }
 
-// SetTo sets value to v.
-func (o *OptTaskStatusOutputHeaders) SetTo(v TaskStatusOutputHeaders) {
-	o.Set = true
-	o.Value = v
+// SetConnectionReused sets the value of ConnectionReused.
+func (s *Timings) SetConnectionReused(val bool) {
+	s.ConnectionReused = val
 }
 
-// Get returns value and boolean that denotes whether value was set.
-func (o OptTaskStatusOutputHeaders) Get() (v TaskStatusOutputHeaders, ok bool) {
-	if !o.Set {
-		return v, false
-	}
-	return o.Value, true
+// Ref: #/components/schemas/tlsProfileInput
+type TlsProfileInput struct {
+	// Profile name.
+	Name string `json:"name"`
+	// PEM-encoded client certificate chain.
+	ClientCert OptString `json:"client_cert"`
+	// PEM-encoded client private key, stored encrypted.
+	ClientKey OptString `json:"client_key"`
+	// PEM-encoded CA certificates to verify the server, system roots by default.
+	CaBundle OptString `json:"ca_bundle"`
+	// Minimal TLS version, 1.2 by default.
+	MinVersion OptTlsVersion `json:"min_version"`
+	// Server name for SNI and certificate verification, the URL host by default.
+	ServerName OptString `json:"server_name"`
+	Proxy      OptProxy  `json:"proxy"`
 }
 
-// Or returns value if set, or given parameter if does not.
-func (o OptTaskStatusOutputHeaders) Or(d TaskStatusOutputHeaders) TaskStatusOutputHeaders {
-	if v, ok := o.Get(); ok {
-		return v
-	}
-	return d
+// GetName returns the value of Name.
+func (s *TlsProfileInput) GetName() string {
+	return s.Name
 }
 
-// Ref: #/components/schemas/taskStatus
-type TaskStatus string
+// GetClientCert returns the value of ClientCert.
+func (s *TlsProfileInput) GetClientCert() OptString {
+	return s.ClientCert
+}
+
+// GetClientKey returns the value of ClientKey.
+func (s *TlsProfileInput) GetClientKey() OptString {
+	return s.ClientKey
+}
+
+// GetCaBundle returns the value of CaBundle.
+func (s *TlsProfileInput) GetCaBundle() OptString {
+	return s.CaBundle
+}
+
+// GetMinVersion returns the value of MinVersion.
+func (s *TlsProfileInput) GetMinVersion() OptTlsVersion {
+	return s.MinVersion
+}
+
+// GetServerName returns the value of ServerName.
+func (s *TlsProfileInput) GetServerName() OptString {
+	return s.ServerName
+}
+
+// GetProxy returns the value of Proxy.
+func (s *TlsProfileInput) GetProxy() OptProxy {
+	return s.Proxy
+}
+
+// SetName sets the value of Name.
+func (s *TlsProfileInput) SetName(val string) {
+	s.Name = val
+}
+
+// SetClientCert sets the value of ClientCert.
+func (s *TlsProfileInput) SetClientCert(val OptString) {
+	s.ClientCert = val
+}
+
+// SetClientKey sets the value of ClientKey.
+func (s *TlsProfileInput) SetClientKey(val OptString) {
+	s.ClientKey = val
+}
+
+// SetCaBundle sets the value of CaBundle.
+func (s *TlsProfileInput) SetCaBundle(val OptString) {
+	s.CaBundle = val
+}
+
+// SetMinVersion sets the value of MinVersion.
+func (s *TlsProfileInput) SetMinVersion(val OptTlsVersion) {
+	s.MinVersion = val
+}
+
+// SetServerName sets the value of ServerName.
+func (s *TlsProfileInput) SetServerName(val OptString) {
+	s.ServerName = val
+}
+
+// SetProxy sets the value of Proxy.
+func (s *TlsProfileInput) SetProxy(val OptProxy) {
+	s.Proxy = val
+}
+
+// Ref: #/components/schemas/tlsProfileListOutput
+type TlsProfileListOutput struct {
+	// Client TLS profiles without private keys.
+	Profiles []TlsProfileOutput `json:"profiles"`
+}
+
+// GetProfiles returns the value of Profiles.
+func (s *TlsProfileListOutput) GetProfiles() []TlsProfileOutput {
+	return s.Profiles
+}
+
+// SetProfiles sets the value of Profiles.
+func (s *TlsProfileListOutput) SetProfiles(val []TlsProfileOutput) {
+	s.Profiles = val
+}
+
+// Ref: #/components/schemas/tlsProfileOutput
+type TlsProfileOutput struct {
+	// Profile name.
+	Name string `json:"name"`
+	// PEM-encoded client certificate chain.
+	ClientCert OptString `json:"client_cert"`
+	// PEM-encoded CA certificates to verify the server.
+	CaBundle OptString `json:"ca_bundle"`
+	// Minimal TLS version.
+	MinVersion OptTlsVersion `json:"min_version"`
+	// Server name for SNI and certificate verification.
+	ServerName OptString `json:"server_name"`
+	Proxy      OptProxy  `json:"proxy"`
+	// Creation time.
+	CreatedAt time.Time `json:"created_at"`
+	// Last update time.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetName returns the value of Name.
+func (s *TlsProfileOutput) GetName() string {
+	return s.Name
+}
+
+// GetClientCert returns the value of ClientCert.
+func (s *TlsProfileOutput) GetClientCert() OptString {
+	return s.ClientCert
+}
+
+// GetCaBundle returns the value of CaBundle.
+func (s *TlsProfileOutput) GetCaBundle() OptString {
+	return s.CaBundle
+}
+
+// GetMinVersion returns the value of MinVersion.
+func (s *TlsProfileOutput) GetMinVersion() OptTlsVersion {
+	return s.MinVersion
+}
+
+// GetServerName returns the value of ServerName.
+func (s *TlsProfileOutput) GetServerName() OptString {
+	return s.ServerName
+}
+
+// GetProxy returns the value of Proxy.
+func (s *TlsProfileOutput) GetProxy() OptProxy {
+	return s.Proxy
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *TlsProfileOutput) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetUpdatedAt returns the value of UpdatedAt.
+func (s *TlsProfileOutput) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
+
+// SetName sets the value of Name.
+func (s *TlsProfileOutput) SetName(val string) {
+	s.Name = val
+}
+
+// SetClientCert sets the value of ClientCert.
+func (s *TlsProfileOutput) SetClientCert(val OptString) {
+	s.ClientCert = val
+}
+
+// SetCaBundle sets the value of CaBundle.
+func (s *TlsProfileOutput) SetCaBundle(val OptString) {
+	s.CaBundle = val
+}
+
+// SetMinVersion sets the value of MinVersion.
+func (s *TlsProfileOutput) SetMinVersion(val OptTlsVersion) {
+	s.MinVersion = val
+}
+
+// SetServerName sets the value of ServerName.
+func (s *TlsProfileOutput) SetServerName(val OptString) {
+	s.ServerName = val
+}
+
+// SetProxy sets the value of Proxy.
+func (s *TlsProfileOutput) SetProxy(val OptProxy) {
+	s.Proxy = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *TlsProfileOutput) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetUpdatedAt sets the value of UpdatedAt.
+func (s *TlsProfileOutput) SetUpdatedAt(val time.Time) {
+	s.UpdatedAt = val
+}
+
+func (*TlsProfileOutput) putTLSProfileRes() {}
+
+// TLS version.
+// Ref: #/components/schemas/tlsVersion
+type TlsVersion string
 
 const (
-	TaskStatusNew       TaskStatus = "new"
-	TaskStatusDone      TaskStatus = "done"
-	TaskStatusError     TaskStatus = "error"
-	TaskStatusInProcess TaskStatus = "in_process"
+	TlsVersion10 TlsVersion = "1.0"
+	TlsVersion11 TlsVersion = "1.1"
+	TlsVersion12 TlsVersion = "1.2"
+	TlsVersion13 TlsVersion = "1.3"
 )
 
 // MarshalText implements encoding.TextMarshaler.
-func (s TaskStatus) MarshalText() ([]byte, error) {
+func (s TlsVersion) MarshalText() ([]byte, error) {
 	switch s {
-	case TaskStatusNew:
+	case TlsVersion10:
 		return []byte(s), nil
-	case TaskStatusDone:
+	case TlsVersion11:
 		return []byte(s), nil
-	case TaskStatusError:
+	case TlsVersion12:
 		return []byte(s), nil
-	case TaskStatusInProcess:
+	case TlsVersion13:
 		return []byte(s), nil
 	default:
 		return nil, errors.Errorf("invalid value: %q", s)
@@ -423,99 +3745,258 @@ func (s TaskStatus) MarshalText() ([]byte, error) {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (s *TaskStatus) UnmarshalText(data []byte) error {
-	switch TaskStatus(data) {
-	case TaskStatusNew:
-		*s = TaskStatusNew
+func (s *TlsVersion) UnmarshalText(data []byte) error {
+	switch TlsVersion(data) {
+	case TlsVersion10:
+		*s = TlsVersion10
 		return nil
-	case TaskStatusDone:
-		*s = TaskStatusDone
+	case TlsVersion11:
+		*s = TlsVersion11
 		return nil
-	case TaskStatusError:
-		*s = TaskStatusError
+	case TlsVersion12:
+		*s = TlsVersion12
 		return nil
-	case TaskStatusInProcess:
-		*s = TaskStatusInProcess
+	case TlsVersion13:
+		*s = TlsVersion13
 		return nil
 	default:
 		return errors.Errorf("invalid value: %q", data)
 	}
 }
 
-// Ref: #/components/schemas/taskStatusOutput
-type TaskStatusOutput struct {
-	// Task ID.
+// Condition on a value extracted by a parent step. If it doesn't hold, the step is skipped along
+// with its dependents.
+// Ref: #/components/schemas/workflowCondition
+type WorkflowCondition struct {
+	// Name of the parent step.
+	Step string `json:"step"`
+	// Name of the value extracted by the parent step.
+	Value string `json:"value"`
+	// JSON value the extracted value must equal.
+	Equals jx.Raw `json:"equals"`
+	// JSON value the extracted value must not equal.
+	NotEquals jx.Raw `json:"not_equals"`
+}
+
+// GetStep returns the value of Step.
+func (s *WorkflowCondition) GetStep() string {
+	return s.Step
+}
+
+// GetValue returns the value of Value.
+func (s *WorkflowCondition) GetValue() string {
+	return s.Value
+}
+
+// GetEquals returns the value of Equals.
+func (s *WorkflowCondition) GetEquals() jx.Raw {
+	return s.Equals
+}
+
+// GetNotEquals returns the value of NotEquals.
+func (s *WorkflowCondition) GetNotEquals() jx.Raw {
+	return s.NotEquals
+}
+
+// SetStep sets the value of Step.
+func (s *WorkflowCondition) SetStep(val string) {
+	s.Step = val
+}
+
+// SetValue sets the value of Value.
+func (s *WorkflowCondition) SetValue(val string) {
+	s.Value = val
+}
+
+// SetEquals sets the value of Equals.
+func (s *WorkflowCondition) SetEquals(val jx.Raw) {
+	s.Equals = val
+}
+
+// SetNotEquals sets the value of NotEquals.
+func (s *WorkflowCondition) SetNotEquals(val jx.Raw) {
+	s.NotEquals = val
+}
+
+// Ref: #/components/schemas/workflowOutput
+type WorkflowOutput struct {
+	// Workflow ID.
 	ID uuid.UUID `json:"id"`
-	// Processing status.
-	Status TaskStatus `json:"status"`
-	// Response headers.
-	Headers OptTaskStatusOutputHeaders `json:"headers"`
-	// Response status code.
-	HTTPStatusCode OptInt `json:"http_status_code"`
-	// Response content length.
-	Length OptInt64 `json:"length"`
+	// Status derived from the steps: running while any step is to be sent or in process, failed if any
+	// step has failed or errored, done otherwise.
+	Status    WorkflowStatus       `json:"status"`
+	Steps     []WorkflowStepOutput `json:"steps"`
+	CreatedAt time.Time            `json:"created_at"`
 }
 
 // GetID returns the value of ID.
-func (s *TaskStatusOutput) GetID() uuid.UUID {
+func (s *WorkflowOutput) GetID() uuid.UUID {
 	return s.ID
 }
 
 // GetStatus returns the value of Status.
-func (s *TaskStatusOutput) GetStatus() TaskStatus {
+func (s *WorkflowOutput) GetStatus() WorkflowStatus {
 	return s.Status
 }
 
-// GetHeaders returns the value of Headers.
-func (s *TaskStatusOutput) GetHeaders() OptTaskStatusOutputHeaders {
-	return s.Headers
-}
-
-// GetHTTPStatusCode returns the value of HTTPStatusCode.
-func (s *TaskStatusOutput) GetHTTPStatusCode() OptInt {
-	return s.HTTPStatusCode
+// GetSteps returns the value of Steps.
+func (s *WorkflowOutput) GetSteps() []WorkflowStepOutput {
+	return s.Steps
 }
 
-// GetLength returns the value of Length.
-func (s *TaskStatusOutput) GetLength() OptInt64 {
-	return s.Length
+// GetCreatedAt returns the value of CreatedAt.
+func (s *WorkflowOutput) GetCreatedAt() time.Time {
+	return s.CreatedAt
 }
 
 // SetID sets the value of ID.
-func (s *TaskStatusOutput) SetID(val uuid.UUID) {
+func (s *WorkflowOutput) SetID(val uuid.UUID) {
 	s.ID = val
 }
 
 // SetStatus sets the value of Status.
-func (s *TaskStatusOutput) SetStatus(val TaskStatus) {
+func (s *WorkflowOutput) SetStatus(val WorkflowStatus) {
 	s.Status = val
 }
 
-// SetHeaders sets the value of Headers.
-func (s *TaskStatusOutput) SetHeaders(val OptTaskStatusOutputHeaders) {
-	s.Headers = val
+// SetSteps sets the value of Steps.
+func (s *WorkflowOutput) SetSteps(val []WorkflowStepOutput) {
+	s.Steps = val
 }
 
-// SetHTTPStatusCode sets the value of HTTPStatusCode.
-func (s *TaskStatusOutput) SetHTTPStatusCode(val OptInt) {
-	s.HTTPStatusCode = val
+// SetCreatedAt sets the value of CreatedAt.
+func (s *WorkflowOutput) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
 }
 
-// SetLength sets the value of Length.
-func (s *TaskStatusOutput) SetLength(val OptInt64) {
-	s.Length = val
-}
+func (*WorkflowOutput) createWorkflowRes() {}
+func (*WorkflowOutput) getWorkflowRes()    {}
 
-func (*TaskStatusOutput) getTaskStatusRes() {}
+// Ref: #/components/schemas/workflowStatus
+type WorkflowStatus string
 
-// Response headers.
-type TaskStatusOutputHeaders map[string][]string
+const (
+	WorkflowStatusRunning WorkflowStatus = "running"
+	WorkflowStatusDone    WorkflowStatus = "done"
+	WorkflowStatusFailed  WorkflowStatus = "failed"
+)
 
-func (s *TaskStatusOutputHeaders) init() TaskStatusOutputHeaders {
-	m := *s
-	if m == nil {
-		m = map[string][]string{}
-		*s = m
+// MarshalText implements encoding.TextMarshaler.
+func (s WorkflowStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case WorkflowStatusRunning:
+		return []byte(s), nil
+	case WorkflowStatusDone:
+		return []byte(s), nil
+	case WorkflowStatusFailed:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
 	}
-	return m
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *WorkflowStatus) UnmarshalText(data []byte) error {
+	switch WorkflowStatus(data) {
+	case WorkflowStatusRunning:
+		*s = WorkflowStatusRunning
+		return nil
+	case WorkflowStatusDone:
+		*s = WorkflowStatusDone
+		return nil
+	case WorkflowStatusFailed:
+		*s = WorkflowStatusFailed
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/workflowStepInput
+type WorkflowStepInput struct {
+	// Step name, unique within the workflow.
+	Name string `json:"name"`
+	// Names of the steps which must be done before the step is sent.
+	DependsOn []string             `json:"depends_on"`
+	Condition OptWorkflowCondition `json:"condition"`
+	Request   CreateTaskInput      `json:"request"`
+}
+
+// GetName returns the value of Name.
+func (s *WorkflowStepInput) GetName() string {
+	return s.Name
+}
+
+// GetDependsOn returns the value of DependsOn.
+func (s *WorkflowStepInput) GetDependsOn() []string {
+	return s.DependsOn
+}
+
+// GetCondition returns the value of Condition.
+func (s *WorkflowStepInput) GetCondition() OptWorkflowCondition {
+	return s.Condition
+}
+
+// GetRequest returns the value of Request.
+func (s *WorkflowStepInput) GetRequest() CreateTaskInput {
+	return s.Request
+}
+
+// SetName sets the value of Name.
+func (s *WorkflowStepInput) SetName(val string) {
+	s.Name = val
+}
+
+// SetDependsOn sets the value of DependsOn.
+func (s *WorkflowStepInput) SetDependsOn(val []string) {
+	s.DependsOn = val
+}
+
+// SetCondition sets the value of Condition.
+func (s *WorkflowStepInput) SetCondition(val OptWorkflowCondition) {
+	s.Condition = val
+}
+
+// SetRequest sets the value of Request.
+func (s *WorkflowStepInput) SetRequest(val CreateTaskInput) {
+	s.Request = val
+}
+
+// Ref: #/components/schemas/workflowStepOutput
+type WorkflowStepOutput struct {
+	// Step name.
+	Name string `json:"name"`
+	// ID of the task running the step.
+	TaskID uuid.UUID  `json:"task_id"`
+	Status TaskStatus `json:"status"`
+}
+
+// GetName returns the value of Name.
+func (s *WorkflowStepOutput) GetName() string {
+	return s.Name
+}
+
+// GetTaskID returns the value of TaskID.
+func (s *WorkflowStepOutput) GetTaskID() uuid.UUID {
+	return s.TaskID
+}
+
+// GetStatus returns the value of Status.
+func (s *WorkflowStepOutput) GetStatus() TaskStatus {
+	return s.Status
+}
+
+// SetName sets the value of Name.
+func (s *WorkflowStepOutput) SetName(val string) {
+	s.Name = val
+}
+
+// SetTaskID sets the value of TaskID.
+func (s *WorkflowStepOutput) SetTaskID(val uuid.UUID) {
+	s.TaskID = val
+}
+
+// SetStatus sets the value of Status.
+func (s *WorkflowStepOutput) SetStatus(val TaskStatus) {
+	s.Status = val
 }
@@ -10,22 +10,136 @@ import (
 type Handler interface {
 	// CreateTask implements createTask operation.
 	//
-	// Create request task.
+	// With `wait` or a `Prefer: wait=<seconds>` header the request blocks until the task reaches a
+	// terminal status (done, failed, error or skipped) or the wait, capped by the server, expires.
 	//
 	// POST /tasks
-	CreateTask(ctx context.Context, req *CreateTaskInput) (*CreateTaskOutput, error)
+	CreateTask(ctx context.Context, req *CreateTaskInput, params CreateTaskParams) (CreateTaskRes, error)
+	// CreateTaskFanout implements createTaskFanout operation.
+	//
+	// A task is created for each target with the target URL, if set, and variables merged with the
+	// template ones, which are rendered by the worker when the request is sent.
+	//
+	// POST /tasks/fanout
+	CreateTaskFanout(ctx context.Context, req *FanoutInput, params CreateTaskFanoutParams) (CreateTaskFanoutRes, error)
+	// CreateTemplate implements createTemplate operation.
+	//
+	// Each call with the same name stores the next version of the template, tasks reference the latest
+	// version by name unless a version is given. The template URL, headers and body reference parameters
+	// as `{{var:name}}`.
+	//
+	// POST /templates
+	CreateTemplate(ctx context.Context, req *TemplateInput, params CreateTemplateParams) (CreateTemplateRes, error)
+	// CreateWorkflow implements createWorkflow operation.
+	//
+	// Steps are run as tasks once the steps they depend on are done. Values extracted by parent steps
+	// are referenced as `{{step:name.value}}`, variables as `{{var:name}}`. Variables are rendered by
+	// the worker when the request is sent.
+	//
+	// POST /workflows
+	CreateWorkflow(ctx context.Context, req *CreateWorkflowInput, params CreateWorkflowParams) (CreateWorkflowRes, error)
+	// DeleteOAuth2Profile implements deleteOAuth2Profile operation.
+	//
+	// Delete client OAuth2 profile.
+	//
+	// DELETE /oauth2-profiles/{name}
+	DeleteOAuth2Profile(ctx context.Context, params DeleteOAuth2ProfileParams) (DeleteOAuth2ProfileRes, error)
+	// DeleteSecret implements deleteSecret operation.
+	//
+	// Delete client secret.
+	//
+	// DELETE /secrets/{name}
+	DeleteSecret(ctx context.Context, params DeleteSecretParams) (DeleteSecretRes, error)
+	// DeleteTLSProfile implements deleteTLSProfile operation.
+	//
+	// Delete client TLS profile.
+	//
+	// DELETE /tls-profiles/{name}
+	DeleteTLSProfile(ctx context.Context, params DeleteTLSProfileParams) (DeleteTLSProfileRes, error)
+	// DeleteTemplate implements deleteTemplate operation.
+	//
+	// Delete all versions of client request template.
+	//
+	// DELETE /templates/{name}
+	DeleteTemplate(ctx context.Context, params DeleteTemplateParams) (DeleteTemplateRes, error)
 	// GetHealthStatus implements getHealthStatus operation.
 	//
 	// Check service is health.
 	//
 	// GET /health
 	GetHealthStatus(ctx context.Context) error
+	// GetTaskGroup implements getTaskGroup operation.
+	//
+	// Get task group progress.
+	//
+	// GET /task-groups/{groupID}
+	GetTaskGroup(ctx context.Context, params GetTaskGroupParams) (GetTaskGroupRes, error)
 	// GetTaskStatus implements getTaskStatus operation.
 	//
-	// Get task status.
+	// With `wait_for_change` the request blocks until the task status differs from the status at the
+	// time of the request or the wait, capped by the server, expires. Status updates of the client tasks
+	// are also streamed as Server-Sent Events by `GET /tasks/events` with the `X-Client-Id` header,
+	// optionally narrowed to tasks by `task_id` (repeated) query parameters.
 	//
 	// GET /tasks/{taskID}
 	GetTaskStatus(ctx context.Context, params GetTaskStatusParams) (GetTaskStatusRes, error)
+	// GetTemplate implements getTemplate operation.
+	//
+	// Get client request template.
+	//
+	// GET /templates/{name}
+	GetTemplate(ctx context.Context, params GetTemplateParams) (GetTemplateRes, error)
+	// GetWorkflow implements getWorkflow operation.
+	//
+	// Get workflow progress.
+	//
+	// GET /workflows/{workflowID}
+	GetWorkflow(ctx context.Context, params GetWorkflowParams) (GetWorkflowRes, error)
+	// ListOAuth2Profiles implements listOAuth2Profiles operation.
+	//
+	// List client OAuth2 profiles.
+	//
+	// GET /oauth2-profiles
+	ListOAuth2Profiles(ctx context.Context, params ListOAuth2ProfilesParams) (*Oauth2ProfileListOutput, error)
+	// ListSecrets implements listSecrets operation.
+	//
+	// List client secrets.
+	//
+	// GET /secrets
+	ListSecrets(ctx context.Context, params ListSecretsParams) (*SecretListOutput, error)
+	// ListTLSProfiles implements listTLSProfiles operation.
+	//
+	// List client TLS profiles.
+	//
+	// GET /tls-profiles
+	ListTLSProfiles(ctx context.Context, params ListTLSProfilesParams) (*TlsProfileListOutput, error)
+	// ListTemplates implements listTemplates operation.
+	//
+	// List the latest versions of client request templates.
+	//
+	// GET /templates
+	ListTemplates(ctx context.Context, params ListTemplatesParams) (*TemplateListOutput, error)
+	// PutOAuth2Profile implements putOAuth2Profile operation.
+	//
+	// Tasks referencing the profile by name are sent with a bearer token obtained from the token
+	// endpoint with the client credentials grant.
+	//
+	// POST /oauth2-profiles
+	PutOAuth2Profile(ctx context.Context, req *Oauth2ProfileInput, params PutOAuth2ProfileParams) (PutOAuth2ProfileRes, error)
+	// PutSecret implements putSecret operation.
+	//
+	// Secrets can be referenced in task URL, headers and body as `{{secret:name}}`. References are
+	// resolved only when the request is made.
+	//
+	// POST /secrets
+	PutSecret(ctx context.Context, req *SecretInput, params PutSecretParams) (*SecretOutput, error)
+	// PutTLSProfile implements putTLSProfile operation.
+	//
+	// Tasks referencing the profile by name are sent over a dedicated transport with the client
+	// certificate and TLS settings of the profile.
+	//
+	// POST /tls-profiles
+	PutTLSProfile(ctx context.Context, req *TlsProfileInput, params PutTLSProfileParams) (PutTLSProfileRes, error)
 }
 
 // Server implements http server based on OpenAPI v3 specification and
@@ -15,10 +15,79 @@ var _ Handler = UnimplementedHandler{}
 
 // CreateTask implements createTask operation.
 //
-// Create request task.
+// With `wait` or a `Prefer: wait=<seconds>` header the request blocks until the task reaches a
+// terminal status (done, failed, error or skipped) or the wait, capped by the server, expires.
 //
 // POST /tasks
-func (UnimplementedHandler) CreateTask(ctx context.Context, req *CreateTaskInput) (r *CreateTaskOutput, _ error) {
+func (UnimplementedHandler) CreateTask(ctx context.Context, req *CreateTaskInput, params CreateTaskParams) (r CreateTaskRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// CreateTaskFanout implements createTaskFanout operation.
+//
+// A task is created for each target with the target URL, if set, and variables merged with the
+// template ones, which are rendered by the worker when the request is sent.
+//
+// POST /tasks/fanout
+func (UnimplementedHandler) CreateTaskFanout(ctx context.Context, req *FanoutInput, params CreateTaskFanoutParams) (r CreateTaskFanoutRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// CreateTemplate implements createTemplate operation.
+//
+// Each call with the same name stores the next version of the template, tasks reference the latest
+// version by name unless a version is given. The template URL, headers and body reference parameters
+// as `{{var:name}}`.
+//
+// POST /templates
+func (UnimplementedHandler) CreateTemplate(ctx context.Context, req *TemplateInput, params CreateTemplateParams) (r CreateTemplateRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// CreateWorkflow implements createWorkflow operation.
+//
+// Steps are run as tasks once the steps they depend on are done. Values extracted by parent steps
+// are referenced as `{{step:name.value}}`, variables as `{{var:name}}`. Variables are rendered by
+// the worker when the request is sent.
+//
+// POST /workflows
+func (UnimplementedHandler) CreateWorkflow(ctx context.Context, req *CreateWorkflowInput, params CreateWorkflowParams) (r CreateWorkflowRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DeleteOAuth2Profile implements deleteOAuth2Profile operation.
+//
+// Delete client OAuth2 profile.
+//
+// DELETE /oauth2-profiles/{name}
+func (UnimplementedHandler) DeleteOAuth2Profile(ctx context.Context, params DeleteOAuth2ProfileParams) (r DeleteOAuth2ProfileRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DeleteSecret implements deleteSecret operation.
+//
+// Delete client secret.
+//
+// DELETE /secrets/{name}
+func (UnimplementedHandler) DeleteSecret(ctx context.Context, params DeleteSecretParams) (r DeleteSecretRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DeleteTLSProfile implements deleteTLSProfile operation.
+//
+// Delete client TLS profile.
+//
+// DELETE /tls-profiles/{name}
+func (UnimplementedHandler) DeleteTLSProfile(ctx context.Context, params DeleteTLSProfileParams) (r DeleteTLSProfileRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DeleteTemplate implements deleteTemplate operation.
+//
+// Delete all versions of client request template.
+//
+// DELETE /templates/{name}
+func (UnimplementedHandler) DeleteTemplate(ctx context.Context, params DeleteTemplateParams) (r DeleteTemplateRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -31,11 +100,107 @@ func (UnimplementedHandler) GetHealthStatus(ctx context.Context) error {
 	return ht.ErrNotImplemented
 }
 
+// GetTaskGroup implements getTaskGroup operation.
+//
+// Get task group progress.
+//
+// GET /task-groups/{groupID}
+func (UnimplementedHandler) GetTaskGroup(ctx context.Context, params GetTaskGroupParams) (r GetTaskGroupRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetTaskStatus implements getTaskStatus operation.
 //
-// Get task status.
+// With `wait_for_change` the request blocks until the task status differs from the status at the
+// time of the request or the wait, capped by the server, expires. Status updates of the client tasks
+// are also streamed as Server-Sent Events by `GET /tasks/events` with the `X-Client-Id` header,
+// optionally narrowed to tasks by `task_id` (repeated) query parameters.
 //
 // GET /tasks/{taskID}
 func (UnimplementedHandler) GetTaskStatus(ctx context.Context, params GetTaskStatusParams) (r GetTaskStatusRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
+
+// GetTemplate implements getTemplate operation.
+//
+// Get client request template.
+//
+// GET /templates/{name}
+func (UnimplementedHandler) GetTemplate(ctx context.Context, params GetTemplateParams) (r GetTemplateRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetWorkflow implements getWorkflow operation.
+//
+// Get workflow progress.
+//
+// GET /workflows/{workflowID}
+func (UnimplementedHandler) GetWorkflow(ctx context.Context, params GetWorkflowParams) (r GetWorkflowRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListOAuth2Profiles implements listOAuth2Profiles operation.
+//
+// List client OAuth2 profiles.
+//
+// GET /oauth2-profiles
+func (UnimplementedHandler) ListOAuth2Profiles(ctx context.Context, params ListOAuth2ProfilesParams) (r *Oauth2ProfileListOutput, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListSecrets implements listSecrets operation.
+//
+// List client secrets.
+//
+// GET /secrets
+func (UnimplementedHandler) ListSecrets(ctx context.Context, params ListSecretsParams) (r *SecretListOutput, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListTLSProfiles implements listTLSProfiles operation.
+//
+// List client TLS profiles.
+//
+// GET /tls-profiles
+func (UnimplementedHandler) ListTLSProfiles(ctx context.Context, params ListTLSProfilesParams) (r *TlsProfileListOutput, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListTemplates implements listTemplates operation.
+//
+// List the latest versions of client request templates.
+//
+// GET /templates
+func (UnimplementedHandler) ListTemplates(ctx context.Context, params ListTemplatesParams) (r *TemplateListOutput, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PutOAuth2Profile implements putOAuth2Profile operation.
+//
+// Tasks referencing the profile by name are sent with a bearer token obtained from the token
+// endpoint with the client credentials grant.
+//
+// POST /oauth2-profiles
+func (UnimplementedHandler) PutOAuth2Profile(ctx context.Context, req *Oauth2ProfileInput, params PutOAuth2ProfileParams) (r PutOAuth2ProfileRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PutSecret implements putSecret operation.
+//
+// Secrets can be referenced in task URL, headers and body as `{{secret:name}}`. References are
+// resolved only when the request is made.
+//
+// POST /secrets
+func (UnimplementedHandler) PutSecret(ctx context.Context, req *SecretInput, params PutSecretParams) (r *SecretOutput, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PutTLSProfile implements putTLSProfile operation.
+//
+// Tasks referencing the profile by name are sent over a dedicated transport with the client
+// certificate and TLS settings of the profile.
+//
+// POST /tls-profiles
+func (UnimplementedHandler) PutTLSProfile(ctx context.Context, req *TlsProfileInput, params PutTLSProfileParams) (r PutTLSProfileRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"requester/internal/api/oas"
+	"requester/internal/models"
+	"requester/internal/repository"
+)
+
+// newOAuth2ProfileOutput converts a profile to the API output.
+func newOAuth2ProfileOutput(profile *models.OAuth2Profile) oas.Oauth2ProfileOutput {
+	return oas.Oauth2ProfileOutput{
+		Name:             profile.Name,
+		TokenURL:         profile.TokenURL,
+		ClientID:         profile.OAuth2ClientID,
+		ClientSecretName: profile.ClientSecretName,
+		Scopes:           profile.Scopes,
+		CreatedAt:        profile.CreatedAt,
+		UpdatedAt:        profile.UpdatedAt,
+	}
+}
+
+// ListOAuth2Profiles lists client OAuth2 profiles.
+func (h *handler) ListOAuth2Profiles(
+	ctx context.Context,
+	params oas.ListOAuth2ProfilesParams,
+) (*oas.Oauth2ProfileListOutput, error) {
+	profiles, err := h.oauth2ProfileRepository.ListProfiles(ctx, params.XClientID.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &oas.Oauth2ProfileListOutput{Profiles: make([]oas.Oauth2ProfileOutput, 0, len(profiles))}
+	for _, profile := range profiles {
+		output.Profiles = append(output.Profiles, newOAuth2ProfileOutput(profile))
+	}
+	return output, nil
+}
+
+// PutOAuth2Profile creates or updates client OAuth2 profile.
+// Responds with 400 if the token URL is invalid or forbidden for the client.
+func (h *handler) PutOAuth2Profile(
+	ctx context.Context,
+	req *oas.Oauth2ProfileInput,
+	params oas.PutOAuth2ProfileParams,
+) (oas.PutOAuth2ProfileRes, error) {
+	clientID := params.XClientID.Value
+	invalid, err := h.validateTaskURL(ctx, clientID, req.TokenURL)
+	if err != nil {
+		return nil, err
+	}
+	if invalid != nil {
+		return invalid, nil
+	}
+
+	profile, err := h.oauth2ProfileRepository.PutProfile(ctx, &repository.PutOAuth2ProfileInput{
+		ClientID:         clientID,
+		Name:             req.Name,
+		TokenURL:         req.TokenURL,
+		OAuth2ClientID:   req.ClientID,
+		ClientSecretName: req.ClientSecretName,
+		Scopes:           req.Scopes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := newOAuth2ProfileOutput(profile)
+	return &output, nil
+}
+
+// DeleteOAuth2Profile deletes client OAuth2 profile.
+func (h *handler) DeleteOAuth2Profile(
+	ctx context.Context,
+	params oas.DeleteOAuth2ProfileParams,
+) (oas.DeleteOAuth2ProfileRes, error) {
+	deleted, err := h.oauth2ProfileRepository.DeleteProfile(ctx, params.XClientID.Value, params.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return &oas.DeleteOAuth2ProfileNotFound{}, nil
+	}
+	return &oas.DeleteOAuth2ProfileNoContent{}, nil
+}
+
+// validateOAuth2Profile checks that the client OAuth2 profile referenced by the task exists.
+// Returns nil if the profile isn't set or exists.
+func (h *handler) validateOAuth2Profile(ctx context.Context, clientID, name string) (*oas.ErrorOutput, error) {
+	if name == "" {
+		return nil, nil
+	}
+	_, exists, err := h.oauth2ProfileRepository.GetProfile(ctx, clientID, name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &oas.ErrorOutput{ErrorMessage: "OAuth2 profile " + name + " not found."}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"net/http"
+	"net/http/httptest"
+	"requester/internal/api/oas"
+	"requester/internal/destination"
+	"requester/internal/encryption"
+	"requester/internal/repository"
+	"testing"
+)
+
+func TestOAuth2TestSuite(t *testing.T) {
+	suite.Run(t, &OAuth2TestSuite{})
+}
+
+type OAuth2TestSuite struct {
+	suite.Suite
+	handler *handler
+	server  *oas.Server
+}
+
+func (suite *OAuth2TestSuite) serve(req *http.Request) *http.Response {
+	suite.T().Helper()
+	req.Header.Set("X-Client-Id", "oauth2-client")
+	w := httptest.NewRecorder()
+	suite.server.ServeHTTP(w, req)
+	return w.Result()
+}
+
+func (suite *OAuth2TestSuite) SetupSuite() {
+	config := MustConfig(LoadConfig())
+	queues := newTestTaskQueues()
+	logger := zaptest.NewLogger(suite.T(), zaptest.Level(zap.PanicLevel))
+
+	policy, err := destination.NewPolicy(destination.Config{})
+	suite.Require().NoError(err)
+	encryptionCfg := encryption.MustConfig(encryption.LoadConfig())
+
+	suite.server, suite.handler, err = newServer(
+		&config,
+		&testTaskSender{},
+		queues,
+		newTestTaskWatcher(),
+		policy,
+		encryption.MustCipher(encryptionCfg),
+		encryption.MustKeyring(encryptionCfg),
+		dbPool,
+		logger,
+	)
+	suite.Require().NoError(err)
+}
+
+func (suite *OAuth2TestSuite) SetupTest() {
+	ctx := context.Background()
+	tx, err := dbPool.Begin(ctx)
+	suite.Require().NoError(err)
+	suite.handler.destinationRepository = repository.NewDestinationDB(tx)
+	suite.handler.oauth2ProfileRepository = repository.NewOAuth2ProfileDB(tx)
+	suite.T().Cleanup(func() {
+		suite.Require().NoError(tx.Rollback(ctx))
+	})
+}
+
+func (suite *OAuth2TestSuite) Test_HandleOAuth2Profiles() {
+	ctx := context.Background()
+
+	dataBytes, _ := json.Marshal(oas.Oauth2ProfileInput{
+		Name:             "partner",
+		TokenURL:         "https://auth.example.com/token",
+		ClientID:         "requester",
+		ClientSecretName: "partner_secret",
+		Scopes:           []string{"orders.read"},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/oauth2-profiles", bytes.NewReader(dataBytes))
+	req.Header.Set("Content-Type", "application/json")
+	resp := suite.serve(req)
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	profile, exists, err := suite.handler.oauth2ProfileRepository.GetProfile(ctx, "oauth2-client", "partner")
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal("https://auth.example.com/token", profile.TokenURL)
+	suite.Equal("requester", profile.OAuth2ClientID)
+	suite.Equal([]string{"orders.read"}, profile.Scopes)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/oauth2-profiles", nil))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	output := oas.Oauth2ProfileListOutput{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&output))
+	suite.Require().Len(output.Profiles, 1)
+	suite.Equal("partner_secret", output.Profiles[0].ClientSecretName)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodDelete, "/oauth2-profiles/partner", nil))
+	suite.Equal(http.StatusNoContent, resp.StatusCode)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodDelete, "/oauth2-profiles/partner", nil))
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *OAuth2TestSuite) Test_HandlePutOAuth2Profile_badRequest() {
+	dataBytes, _ := json.Marshal(oas.Oauth2ProfileInput{
+		Name:             "metadata",
+		TokenURL:         "http://169.254.169.254/token",
+		ClientID:         "requester",
+		ClientSecretName: "partner_secret",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/oauth2-profiles", bytes.NewReader(dataBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := suite.serve(req)
+	suite.Equal(http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"requester/internal/api/oas"
+	"requester/internal/destination"
+	"requester/internal/models"
+)
+
+// inputProxy converts the proxy of the input and checks it against the client policy.
+// Returns an error response if the proxy is invalid or forbidden for the client.
+func (h *handler) inputProxy(
+	ctx context.Context,
+	clientID string,
+	input oas.OptProxy,
+) (*models.Proxy, *oas.ErrorOutput, error) {
+	value, ok := input.Get()
+	if !ok {
+		return nil, nil, nil
+	}
+
+	u, err := destination.ParseProxyURL(value.URL)
+	if err != nil {
+		return nil, &oas.ErrorOutput{ErrorMessage: "Invalid proxy: " + err.Error()}, nil
+	}
+	policy, err := h.getClientPolicy(ctx, clientID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = policy.CheckURL(u); err != nil {
+		var forbiddenErr *destination.ForbiddenError
+		if errors.As(err, &forbiddenErr) {
+			return nil, &oas.ErrorOutput{ErrorMessage: "Invalid proxy: " + err.Error()}, nil
+		}
+		return nil, nil, err
+	}
+
+	return &models.Proxy{
+		URL:      value.URL,
+		Username: value.Username.Value,
+		Password: value.Password.Value,
+	}, nil, nil
+}
+
+// newProxyOutput converts a proxy to the API output without its password.
+func newProxyOutput(proxy *models.Proxy) oas.OptProxy {
+	if proxy == nil {
+		return oas.OptProxy{}
+	}
+	output := oas.Proxy{URL: proxy.URL}
+	if proxy.Username != "" {
+		output.Username = oas.NewOptString(proxy.Username)
+	}
+	return oas.NewOptProxy(output)
+}
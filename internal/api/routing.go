@@ -0,0 +1,16 @@
+package api
+
+import (
+	"requester/internal/api/oas"
+	"requester/internal/destination"
+)
+
+// taskQueue returns the queue the task is routed to by the URL host, the client and the label,
+// empty for the default queue.
+func (h *handler) taskQueue(clientID string, req *oas.CreateTaskInput) string {
+	u, err := destination.ParseURL(req.URL.Value)
+	if err != nil {
+		return ""
+	}
+	return h.cfg.RoutingRules.Queue(u.Hostname(), clientID, req.Label.Value)
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"requester/internal/api/oas"
+	"requester/internal/models"
+	"requester/internal/repository"
+)
+
+// newSecretOutput converts a secret to the API output without its value.
+func newSecretOutput(secret *models.Secret) oas.SecretOutput {
+	return oas.SecretOutput{
+		Name:      secret.Name,
+		CreatedAt: secret.CreatedAt,
+		UpdatedAt: secret.UpdatedAt,
+	}
+}
+
+// ListSecrets lists client secrets without values.
+func (h *handler) ListSecrets(ctx context.Context, params oas.ListSecretsParams) (*oas.SecretListOutput, error) {
+	secrets, err := h.secretRepository.ListSecrets(ctx, params.XClientID.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &oas.SecretListOutput{Secrets: make([]oas.SecretOutput, 0, len(secrets))}
+	for _, secret := range secrets {
+		output.Secrets = append(output.Secrets, newSecretOutput(secret))
+	}
+	return output, nil
+}
+
+// PutSecret creates or updates client secret.
+func (h *handler) PutSecret(
+	ctx context.Context,
+	req *oas.SecretInput,
+	params oas.PutSecretParams,
+) (*oas.SecretOutput, error) {
+	secret, err := h.secretRepository.PutSecret(ctx, &repository.PutSecretInput{
+		ClientID: params.XClientID.Value,
+		Name:     req.Name,
+		Value:    req.Value,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := newSecretOutput(secret)
+	return &output, nil
+}
+
+// DeleteSecret deletes client secret.
+func (h *handler) DeleteSecret(ctx context.Context, params oas.DeleteSecretParams) (oas.DeleteSecretRes, error) {
+	deleted, err := h.secretRepository.DeleteSecret(ctx, params.XClientID.Value, params.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return &oas.DeleteSecretNotFound{}, nil
+	}
+	return &oas.DeleteSecretNoContent{}, nil
+}
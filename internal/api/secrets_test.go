@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"net/http"
+	"net/http/httptest"
+	"requester/internal/api/oas"
+	"requester/internal/destination"
+	"requester/internal/encryption"
+	"requester/internal/repository"
+	"testing"
+)
+
+func TestSecretsTestSuite(t *testing.T) {
+	suite.Run(t, &SecretsTestSuite{})
+}
+
+type SecretsTestSuite struct {
+	suite.Suite
+	handler *handler
+	server  *oas.Server
+	cipher  *encryption.Cipher
+	keyring *encryption.Keyring
+}
+
+func (suite *SecretsTestSuite) serve(req *http.Request) *http.Response {
+	suite.T().Helper()
+	req.Header.Set("X-Client-Id", "secrets-client")
+	w := httptest.NewRecorder()
+	suite.server.ServeHTTP(w, req)
+	return w.Result()
+}
+
+func (suite *SecretsTestSuite) SetupSuite() {
+	config := MustConfig(LoadConfig())
+	queues := newTestTaskQueues()
+	logger := zaptest.NewLogger(suite.T(), zaptest.Level(zap.PanicLevel))
+
+	policy, err := destination.NewPolicy(destination.Config{})
+	suite.Require().NoError(err)
+	encryptionCfg := encryption.MustConfig(encryption.LoadConfig())
+	suite.cipher = encryption.MustCipher(encryptionCfg)
+	suite.keyring = encryption.MustKeyring(encryptionCfg)
+
+	suite.server, suite.handler, err = newServer(
+		&config, &testTaskSender{}, queues, newTestTaskWatcher(), policy, suite.cipher, suite.keyring, dbPool, logger,
+	)
+	suite.Require().NoError(err)
+}
+
+func (suite *SecretsTestSuite) SetupTest() {
+	ctx := context.Background()
+	tx, err := dbPool.Begin(ctx)
+	suite.Require().NoError(err)
+	suite.handler.secretRepository = repository.NewSecretDB(tx, suite.cipher)
+	suite.T().Cleanup(func() {
+		suite.Require().NoError(tx.Rollback(ctx))
+	})
+}
+
+func (suite *SecretsTestSuite) Test_HandleSecrets() {
+	ctx := context.Background()
+
+	dataBytes, _ := json.Marshal(oas.SecretInput{Name: "partner_token", Value: "s3cr3t"})
+	req := httptest.NewRequest(http.MethodPost, "/secrets", bytes.NewReader(dataBytes))
+	req.Header.Set("Content-Type", "application/json")
+	resp := suite.serve(req)
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	secret, exists, err := suite.handler.secretRepository.GetSecret(ctx, "secrets-client", "partner_token")
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal("s3cr3t", secret.Value)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/secrets", nil))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	var raw map[string][]map[string]interface{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&raw))
+	suite.Require().Len(raw["secrets"], 1)
+	suite.Equal("partner_token", raw["secrets"][0]["name"])
+	suite.NotContains(raw["secrets"][0], "value")
+
+	resp = suite.serve(httptest.NewRequest(http.MethodDelete, "/secrets/partner_token", nil))
+	suite.Equal(http.StatusNoContent, resp.StatusCode)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodDelete, "/secrets/partner_token", nil))
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *SecretsTestSuite) Test_HandlePutSecret_badRequest() {
+	dataBytes, _ := json.Marshal(oas.SecretInput{Name: "{{bad}}", Value: "value"})
+	req := httptest.NewRequest(http.MethodPost, "/secrets", bytes.NewReader(dataBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := suite.serve(req)
+	suite.Equal(http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"requester/internal/api/oas"
+	"requester/internal/models"
+	"requester/internal/signing"
+)
+
+// taskSigning converts the signing of the task input.
+// Returns an error response if the signing is invalid.
+func taskSigning(input oas.OptSigning) (*models.Signing, *oas.ErrorOutput) {
+	value, ok := input.Get()
+	if !ok {
+		return nil, nil
+	}
+
+	s := &models.Signing{Type: models.SigningType(value.Type)}
+	if opts, ok := value.Hmac.Get(); ok {
+		s.HMAC = &models.HMACSigning{
+			Key:             opts.Key,
+			Header:          opts.Header.Value,
+			TimestampHeader: opts.TimestampHeader.Value,
+		}
+		for _, part := range opts.Parts {
+			s.HMAC.Parts = append(s.HMAC.Parts, models.HMACPart(part))
+		}
+	}
+	if opts, ok := value.AWSSigv4.Get(); ok {
+		s.AWSSigV4 = &models.AWSSigV4Signing{
+			Region:          opts.Region,
+			Service:         opts.Service,
+			AccessKeyID:     opts.AccessKeyID,
+			SecretAccessKey: opts.SecretAccessKey,
+			SessionToken:    opts.SessionToken.Value,
+		}
+	}
+
+	if err := signing.Validate(s); err != nil {
+		return nil, &oas.ErrorOutput{ErrorMessage: "Invalid signing: " + err.Error()}
+	}
+	return s, nil
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"requester/internal/api/oas"
+	"requester/internal/models"
+	"requester/internal/success"
+)
+
+// taskSuccess converts the success criteria of the task input.
+// Returns an error response if the criteria are invalid.
+func taskSuccess(input oas.OptSuccess) (*models.Success, *oas.ErrorOutput) {
+	value, ok := input.Get()
+	if !ok {
+		return nil, nil
+	}
+
+	s := &models.Success{
+		BodyContains: value.BodyContains.Value,
+		BodyRegex:    value.BodyRegex.Value,
+		Retry:        value.Retry.Value,
+	}
+	for _, r := range value.StatusCodes {
+		s.StatusCodes = append(s.StatusCodes, models.StatusCodeRange{From: r.From, To: r.To})
+	}
+	for _, h := range value.Headers {
+		s.Headers = append(s.Headers, models.HeaderAssertion{Name: h.Name, Value: h.Value.Value})
+	}
+	for _, a := range value.JSON {
+		s.JSON = append(s.JSON, models.JSONAssertion{Path: a.Path, Equals: a.Equals})
+	}
+
+	if err := success.Validate(s); err != nil {
+		return nil, &oas.ErrorOutput{ErrorMessage: "Invalid success criteria: " + err.Error()}
+	}
+	return s, nil
+}
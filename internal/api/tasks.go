@@ -4,45 +4,204 @@ import (
 	"context"
 	"fmt"
 	"requester/internal/api/oas"
+	"requester/internal/extract"
 	"requester/internal/models"
 	"requester/internal/repository"
+	"requester/internal/variables"
 	"time"
 )
 
 // CreateTask creates new task.
-func (h *handler) CreateTask(ctx context.Context, req *oas.CreateTaskInput) (*oas.CreateTaskOutput, error) {
+// Responds with 400 if the URL or the proxy is invalid or forbidden for the client, the signing,
+// the success criteria, the extraction, the variables, the template params or the parents are invalid
+// or the template, the OAuth2 or TLS profile doesn't exist and with 429 if the client has exceeded its limits.
+// If requested, waits for the task to finish and responds with 201 and the task status
+// or with 202 and the task status if the wait expires first.
+func (h *handler) CreateTask(
+	ctx context.Context,
+	req *oas.CreateTaskInput,
+	params oas.CreateTaskParams,
+) (oas.CreateTaskRes, error) {
+	wait, invalid := h.taskWait(params)
+	if invalid != nil {
+		return invalid, nil
+	}
+	reqCtx := ctx
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	task, err := h.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
-		Method:  string(req.Method),
-		URL:     req.URL,
-		Headers: req.Headers.Value,
-		Body:    req.Body.Value,
-	})
+	clientID := params.XClientID.Value
+	input, invalid, err := h.taskInput(ctx, clientID, req)
 	if err != nil {
 		return nil, err
 	}
+	if invalid != nil {
+		return invalid, nil
+	}
+	if input.DependsOn, invalid, err = h.taskDependsOn(ctx, clientID, req); err != nil {
+		return nil, err
+	}
+	if invalid != nil {
+		return invalid, nil
+	}
 
-	if err = h.taskSender.SendMessage(ctx, h.taskQueueUrl, task.ID); err != nil {
-		updErr := h.taskRepository.UpdateTask(
-			ctx,
-			&repository.UpdateTaskInput{ID: task.ID, Status: models.TaskStatusError.Pointer()},
-		)
-		if updErr != nil {
-			return nil, fmt.Errorf(
-				"failed to update task status while processing sendMessage err: %w: %s",
-				err, updErr,
-			)
+	limits, exceeded, err := h.checkClientLimits(ctx, clientID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if exceeded != nil {
+		return exceeded.response(), nil
+	}
+
+	input.MaxOutstandingTasks = limits.MaxOutstandingTasks
+	input.CounterLimits = limits.counterLimits(1)
+	task, err := h.taskRepository.CreateTask(ctx, input)
+	if exceeded = creationExceeded(err, limits); exceeded != nil {
+		return exceeded.response(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Waiting tasks are sent once their parents are done, skipped ones are never sent.
+	if task.Status == models.TaskStatusNew {
+		if err = h.sendTask(ctx, input.QueuedTask(task.ID)); err != nil {
+			return nil, err
 		}
+	}
+
+	if wait == 0 {
+		return &oas.CreateTaskOutput{ID: task.ID}, nil
+	}
+	waited, finished, err := h.waitTask(reqCtx, task.ID, wait, isTerminal)
+	if err != nil {
 		return nil, err
 	}
+	if finished {
+		return (*oas.CreateTaskCreated)(newTaskStatusOutput(waited)), nil
+	}
+	return (*oas.CreateTaskAccepted)(newTaskStatusOutput(waited)), nil
+}
 
-	return &oas.CreateTaskOutput{ID: task.ID}, nil
+// taskInput validates the task request and converts it to the repository input without parents.
+// The referenced template is applied to the request first.
+// Returns an error response if the request is invalid.
+func (h *handler) taskInput(
+	ctx context.Context,
+	clientID string,
+	req *oas.CreateTaskInput,
+) (*repository.CreateTaskInput, *oas.ErrorOutput, error) {
+	invalid, err := h.applyTemplate(ctx, clientID, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if invalid != nil {
+		return nil, invalid, nil
+	}
+	if !req.Method.Set || !req.URL.Set {
+		return nil, &oas.ErrorOutput{ErrorMessage: "Method and URL are required without a template."}, nil
+	}
+	// Priorities have separate queues and ordering holds within a queue.
+	if req.OrderingKey.Set && req.Priority.Or(oas.TaskPriorityNormal) != oas.TaskPriorityNormal {
+		return nil, &oas.ErrorOutput{ErrorMessage: "Ordering key requires the normal priority."}, nil
+	}
+	invalid, err = h.validateTaskURL(ctx, clientID, req.URL.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+	if invalid != nil {
+		return nil, invalid, nil
+	}
+	taskSigning, invalid := taskSigning(req.Signing)
+	if invalid != nil {
+		return nil, invalid, nil
+	}
+	taskSuccess, invalid := taskSuccess(req.Success)
+	if invalid != nil {
+		return nil, invalid, nil
+	}
+	if err := extract.Validate(req.Extract.Value); err != nil {
+		return nil, &oas.ErrorOutput{ErrorMessage: "Invalid extract: " + err.Error()}, nil
+	}
+	template := &models.Task{URL: req.URL.Value, Headers: req.Headers.Value, Body: req.Body.Value}
+	if err := variables.Validate(template, req.Variables.Value); err != nil {
+		return nil, &oas.ErrorOutput{ErrorMessage: "Invalid variables: " + err.Error()}, nil
+	}
+	taskProxy, invalid, err := h.inputProxy(ctx, clientID, req.Proxy)
+	if err != nil {
+		return nil, nil, err
+	}
+	if invalid != nil {
+		return nil, invalid, nil
+	}
+	invalid, err = h.validateOAuth2Profile(ctx, clientID, req.OAuth2Profile.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+	if invalid != nil {
+		return nil, invalid, nil
+	}
+	invalid, err = h.validateTLSProfile(ctx, clientID, req.TLSProfile.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+	if invalid != nil {
+		return nil, invalid, nil
+	}
+
+	return &repository.CreateTaskInput{
+		ClientID:      clientID,
+		Method:        string(req.Method.Value),
+		URL:           req.URL.Value,
+		Headers:       req.Headers.Value,
+		Body:          req.Body.Value,
+		Signing:       taskSigning,
+		OAuth2Profile: req.OAuth2Profile.Value,
+		TLSProfile:    req.TLSProfile.Value,
+		Proxy:         taskProxy,
+		Success:       taskSuccess,
+		Extract:       req.Extract.Value,
+		Variables:     req.Variables.Value,
+		Priority:      models.TaskPriority(req.Priority.Or(oas.TaskPriorityNormal)),
+		Queue:         h.taskQueue(clientID, req),
+		OrderingKey:   req.OrderingKey.Value,
+	}, nil, nil
+}
+
+// sendTask sends the new task to its queue of its priority.
+// The task is errored if it can't be sent.
+func (h *handler) sendTask(ctx context.Context, task repository.QueuedTask) error {
+	queueURL, err := h.taskQueues.URL(ctx, task.Queue, task.Priority)
+	if err == nil {
+		err = h.taskSender.SendMessage(ctx, queueURL, task.ID, task.OrderingKey)
+	}
+	if err == nil {
+		return nil
+	}
+	updErr := h.taskRepository.UpdateTask(
+		ctx,
+		&repository.UpdateTaskInput{ID: task.ID, Status: models.TaskStatusError.Pointer()},
+	)
+	if updErr != nil {
+		return fmt.Errorf(
+			"failed to update task status while processing sendMessage err: %w: %s",
+			err, updErr,
+		)
+	}
+	return err
 }
 
 // GetTaskStatus returns task status.
+// If requested, waits for the status to change first. Responds with 400 if the wait is invalid.
 func (h *handler) GetTaskStatus(ctx context.Context, params oas.GetTaskStatusParams) (oas.GetTaskStatusRes, error) {
+	var wait time.Duration
+	if value, ok := params.WaitForChange.Get(); ok {
+		var invalid *oas.ErrorOutput
+		if wait, invalid = parseWait(value, h.cfg.MaxStatusWait); invalid != nil {
+			return invalid, nil
+		}
+	}
+
 	task, exists, err := h.taskRepository.GetTask(ctx, params.TaskID)
 	if err != nil {
 		return nil, err
@@ -51,6 +210,20 @@ func (h *handler) GetTaskStatus(ctx context.Context, params oas.GetTaskStatusPar
 		return &oas.GetTaskStatusNotFound{}, nil
 	}
 
+	if wait > 0 {
+		status := task.Status
+		task, _, err = h.waitTask(ctx, task.ID, wait, func(task *models.TaskWithResponseData) bool {
+			return task.Status != status
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newTaskStatusOutput(task), nil
+}
+
+// newTaskStatusOutput converts the task to the status API output.
+func newTaskStatusOutput(task *models.TaskWithResponseData) *oas.TaskStatusOutput {
 	var headers oas.OptTaskStatusOutputHeaders
 	if task.ResponseHeaders != nil {
 		headers = oas.NewOptTaskStatusOutputHeaders(task.ResponseHeaders)
@@ -63,12 +236,62 @@ func (h *handler) GetTaskStatus(ctx context.Context, params oas.GetTaskStatusPar
 	if task.ResponseContentLength != nil {
 		contentLength = oas.NewOptInt64(*task.ResponseContentLength)
 	}
+	var proxy oas.OptString
+	if task.UsedProxy != nil {
+		proxy = oas.NewOptString(*task.UsedProxy)
+	}
+	var failedAssertion oas.OptString
+	if task.FailedAssertion != nil {
+		failedAssertion = oas.NewOptString(*task.FailedAssertion)
+	}
+	var queueName oas.OptString
+	if task.Queue != "" {
+		queueName = oas.NewOptString(task.Queue)
+	}
+	var orderingKey oas.OptString
+	if task.OrderingKey != "" {
+		orderingKey = oas.NewOptString(task.OrderingKey)
+	}
+	var extracted oas.OptTaskStatusOutputExtracted
+	if task.Extracted != nil {
+		extracted = oas.NewOptTaskStatusOutputExtracted(task.Extracted)
+	}
 
 	return &oas.TaskStatusOutput{
-		ID:             task.ID,
-		Status:         oas.TaskStatus(task.Status),
-		Headers:        headers,
-		HTTPStatusCode: statusCode,
-		Length:         contentLength,
-	}, nil
+		ID:              task.ID,
+		Status:          oas.TaskStatus(task.Status),
+		Priority:        oas.NewOptTaskPriority(oas.TaskPriority(task.Priority)),
+		Queue:           queueName,
+		OrderingKey:     orderingKey,
+		Headers:         headers,
+		HTTPStatusCode:  statusCode,
+		Length:          contentLength,
+		Proxy:           proxy,
+		Timings:         newTimingsOutput(task.Timings),
+		FailedAssertion: failedAssertion,
+		Extracted:       extracted,
+		DependsOn:       task.DependsOn,
+	}
+}
+
+// newTimingsOutput converts request timings to the API output in milliseconds.
+func newTimingsOutput(timings *models.Timings) oas.OptTimings {
+	if timings == nil {
+		return oas.OptTimings{}
+	}
+	ms := func(d time.Duration) float64 {
+		return float64(d) / float64(time.Millisecond)
+	}
+	output := oas.Timings{
+		DNSMs:            ms(timings.DNS),
+		ConnectMs:        ms(timings.Connect),
+		TLSHandshakeMs:   ms(timings.TLSHandshake),
+		TtfbMs:           ms(timings.TimeToFirstByte),
+		TotalMs:          ms(timings.Total),
+		ConnectionReused: timings.ConnectionReused,
+	}
+	if timings.RemoteIP != "" {
+		output.RemoteIP = oas.NewOptString(timings.RemoteIP)
+	}
+	return oas.NewOptTimings(output)
 }
@@ -6,15 +6,25 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 	"net/http"
 	"net/http/httptest"
 	"requester/internal/api/oas"
+	"requester/internal/destination"
+	"requester/internal/encryption"
+	"requester/internal/models"
 	"requester/internal/repository"
+	"requester/internal/routing"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestTasksTestSuite(t *testing.T) {
@@ -25,6 +35,9 @@ type TasksTestSuite struct {
 	suite.Suite
 	handler *handler
 	server  *oas.Server
+	tx      pgx.Tx
+	cipher  *encryption.Cipher
+	keyring *encryption.Keyring
 }
 
 func (suite *TasksTestSuite) serve(req *http.Request) *http.Response {
@@ -37,11 +50,19 @@ func (suite *TasksTestSuite) serve(req *http.Request) *http.Response {
 
 func (suite *TasksTestSuite) SetupSuite() {
 	config := MustConfig(LoadConfig())
-	url := "sqs://test-queue"
+	queues := newTestTaskQueues()
 	logger := zaptest.NewLogger(suite.T(), zaptest.Level(zap.PanicLevel))
 
-	var err error
-	suite.server, suite.handler, err = newServer(&config, &testTaskSender{}, &url, dbPool, logger)
+	policy, err := destination.NewPolicy(destination.Config{})
+	suite.Require().NoError(err)
+
+	encryptionCfg := encryption.MustConfig(encryption.LoadConfig())
+	suite.cipher = encryption.MustCipher(encryptionCfg)
+	suite.keyring = encryption.MustKeyring(encryptionCfg)
+
+	suite.server, suite.handler, err = newServer(
+		&config, &testTaskSender{}, queues, newTestTaskWatcher(), policy, suite.cipher, suite.keyring, dbPool, logger,
+	)
 	suite.Require().NoError(err)
 }
 
@@ -51,7 +72,15 @@ func (suite *TasksTestSuite) SetupTest() {
 	ctx := context.Background()
 	tx, err := dbPool.Begin(ctx)
 	suite.Require().NoError(err)
-	suite.handler.taskRepository = repository.NewTaskDB(tx)
+	suite.tx = tx
+	suite.handler.taskRepository = repository.NewTaskDB(tx, suite.keyring)
+	suite.handler.limitRepository = repository.NewLimitDB(tx)
+	suite.handler.destinationRepository = repository.NewDestinationDB(tx)
+	suite.handler.secretRepository = repository.NewSecretDB(tx, suite.cipher)
+	suite.handler.oauth2ProfileRepository = repository.NewOAuth2ProfileDB(tx)
+	suite.handler.tlsProfileRepository = repository.NewTLSProfileDB(tx, suite.cipher)
+	suite.handler.taskGroupRepository = repository.NewTaskGroupDB(tx, suite.keyring)
+	suite.handler.templateRepository = repository.NewTemplateDB(tx)
 	suite.T().Cleanup(func() {
 		suite.Require().NoError(tx.Rollback(ctx))
 	})
@@ -59,8 +88,8 @@ func (suite *TasksTestSuite) SetupTest() {
 
 func (suite *TasksTestSuite) getValidTaskInput() oas.CreateTaskInput {
 	return oas.CreateTaskInput{
-		Method:  http.MethodGet,
-		URL:     "https://example.com",
+		Method:  oas.NewOptCreateTaskInputMethod(http.MethodGet),
+		URL:     oas.NewOptString("https://example.com"),
 		Headers: oas.NewOptCreateTaskInputHeaders(map[string]string{"Content-Type": "application/json"}),
 		Body:    oas.NewOptCreateTaskInputBody(map[string]jx.Raw{"field": jx.Raw(`"test"`)}),
 	}
@@ -69,7 +98,7 @@ func (suite *TasksTestSuite) getValidTaskInput() oas.CreateTaskInput {
 func (suite *TasksTestSuite) Test_HandleCreateTask_ok() {
 	ctx := context.Background()
 	sender := suite.handler.taskSender.(*testTaskSender)
-	sender.On("SendMessage", mock.Anything, suite.handler.taskQueueUrl, mock.Anything).
+	sender.On("SendMessage", mock.Anything, testTaskQueueURL("test-queue", models.TaskPriorityNormal), mock.Anything, mock.Anything).
 		Return(nil)
 	defer sender.AssertExpectations(suite.T())
 
@@ -90,10 +119,25 @@ func (suite *TasksTestSuite) Test_HandleCreateTask_ok() {
 	suite.Require().True(exists)
 
 	suite.Equal(response.ID, task.ID)
-	suite.EqualValues(data.Method, task.Method)
-	suite.EqualValues(data.URL, task.URL)
+	suite.EqualValues(data.Method.Value, task.Method)
+	suite.EqualValues(data.URL.Value, task.URL)
 	suite.EqualValues(data.Headers.Value, task.Headers)
 	suite.EqualValues(data.Body.Value, task.Body)
+
+	var keyID *string
+	var headers, body, headersEncrypted, bodyEncrypted []byte
+	err = suite.tx.QueryRow(
+		ctx,
+		"SELECT key_id, headers, body, headers_encrypted, body_encrypted FROM tasks WHERE id = $1",
+		response.ID,
+	).Scan(&keyID, &headers, &body, &headersEncrypted, &bodyEncrypted)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(keyID)
+	suite.Equal(suite.keyring.PrimaryID(), *keyID)
+	suite.Nil(headers)
+	suite.Nil(body)
+	suite.NotContains(string(headersEncrypted), "application/json")
+	suite.NotContains(string(bodyEncrypted), "test")
 }
 
 func (suite *TasksTestSuite) Test_HandleCreateTask_queueError() {
@@ -102,7 +146,7 @@ func (suite *TasksTestSuite) Test_HandleCreateTask_queueError() {
 	}
 
 	sender := suite.handler.taskSender.(*testTaskSender)
-	sender.On("SendMessage", mock.Anything, suite.handler.taskQueueUrl, mock.Anything).
+	sender.On("SendMessage", mock.Anything, testTaskQueueURL("test-queue", models.TaskPriorityNormal), mock.Anything, mock.Anything).
 		Return(errors.New("test error"))
 	defer sender.AssertExpectations(suite.T())
 
@@ -115,6 +159,542 @@ func (suite *TasksTestSuite) Test_HandleCreateTask_queueError() {
 	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&errorResponse{}))
 }
 
+func (suite *TasksTestSuite) Test_HandleCreateTask_limitExceeded() {
+	ctx := context.Background()
+	clientID := "limited-client"
+	_, err := suite.tx.Exec(
+		ctx, "INSERT INTO client_limits (client_id, max_outstanding_tasks) VALUES ($1, 1)", clientID,
+	)
+	suite.Require().NoError(err)
+
+	sender := suite.handler.taskSender.(*testTaskSender)
+	sender.On("SendMessage", mock.Anything, testTaskQueueURL("test-queue", models.TaskPriorityNormal), mock.Anything, mock.Anything).
+		Return(nil).Once()
+	defer sender.AssertExpectations(suite.T())
+
+	dataBytes, _ := json.Marshal(suite.getValidTaskInput())
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(dataBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Client-Id", clientID)
+		return req
+	}
+
+	resp := suite.serve(newRequest())
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	resp = suite.serve(newRequest())
+	suite.Require().Equal(http.StatusTooManyRequests, resp.StatusCode)
+	suite.Equal(
+		strconv.Itoa(int(suite.handler.cfg.OutstandingRetry/time.Second)),
+		resp.Header.Get("Retry-After"),
+	)
+	response := oas.ErrorOutput{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+	suite.NotEmpty(response.ErrorMessage)
+}
+
+func (suite *TasksTestSuite) Test_HandleCreateTask_rateLimitExceeded() {
+	ctx := context.Background()
+	sender := suite.handler.taskSender.(*testTaskSender)
+	sender.On("SendMessage", mock.Anything, testTaskQueueURL("test-queue", models.TaskPriorityNormal), mock.Anything, mock.Anything).
+		Return(nil)
+
+	tests := []struct {
+		name            string
+		column          string
+		maxRetryAfter   int
+		wantErrorPrefix string
+	}{
+		{"requests_per_second", "requests_per_second", 1, "Requests per second"},
+		{"daily_task_quota", "daily_task_quota", 24 * 60 * 60, "Daily task quota"},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Counters share the window, since now() is the time of the test transaction start.
+			clientID := "limited-" + tt.name
+			_, err := suite.tx.Exec(
+				ctx, "INSERT INTO client_limits (client_id, "+tt.column+") VALUES ($1, 1)", clientID,
+			)
+			suite.Require().NoError(err)
+
+			dataBytes, _ := json.Marshal(suite.getValidTaskInput())
+			newRequest := func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(dataBytes))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("X-Client-Id", clientID)
+				return req
+			}
+
+			resp := suite.serve(newRequest())
+			suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+			resp = suite.serve(newRequest())
+			suite.Require().Equal(http.StatusTooManyRequests, resp.StatusCode)
+			retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+			suite.Require().NoError(err)
+			suite.GreaterOrEqual(retryAfter, 1)
+			suite.LessOrEqual(retryAfter, tt.maxRetryAfter)
+			response := oas.ErrorOutput{}
+			suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+			suite.True(strings.HasPrefix(response.ErrorMessage, tt.wantErrorPrefix), response.ErrorMessage)
+		})
+	}
+}
+
+func (suite *TasksTestSuite) Test_CreateTask_outstandingLimit() {
+	ctx := context.Background()
+	input := func() *repository.CreateTaskInput {
+		return &repository.CreateTaskInput{
+			ClientID:            "outstanding-client",
+			Method:              http.MethodGet,
+			URL:                 "https://example.com",
+			MaxOutstandingTasks: 1,
+		}
+	}
+
+	_, err := suite.handler.taskRepository.CreateTask(ctx, input())
+	suite.Require().NoError(err)
+	_, err = suite.handler.taskRepository.CreateTask(ctx, input())
+	var limitErr *repository.OutstandingLimitError
+	suite.Require().ErrorAs(err, &limitErr)
+	suite.Equal(1, limitErr.Limit)
+
+	exceeded := creationExceeded(err, LimitsConfig{OutstandingRetry: 5 * time.Second})
+	suite.Require().NotNil(exceeded)
+	suite.Equal(5, exceeded.response().RetryAfter)
+}
+
+func (suite *TasksTestSuite) Test_CreateTask_counterLimits() {
+	ctx := context.Background()
+	clientID := "counted-client"
+	input := func() *repository.CreateTaskInput {
+		return &repository.CreateTaskInput{
+			ClientID:            clientID,
+			Method:              http.MethodGet,
+			URL:                 "https://example.com",
+			MaxOutstandingTasks: 1,
+			CounterLimits:       LimitsConfig{RequestsPerSecond: 1, DailyTaskQuota: 5}.counterLimits(1),
+		}
+	}
+	count := func(bucket models.RateBucket) int {
+		var count int
+		err := suite.tx.QueryRow(
+			ctx, "SELECT count FROM client_rate_counters WHERE client_id = $1 AND bucket = $2", clientID, bucket,
+		).Scan(&count)
+		suite.Require().NoError(err)
+		return count
+	}
+
+	_, err := suite.handler.taskRepository.CreateTask(ctx, input())
+	suite.Require().NoError(err)
+
+	// Counters of creations rejected by the outstanding tasks limit are rolled back.
+	_, err = suite.handler.taskRepository.CreateTask(ctx, input())
+	var outstandingErr *repository.OutstandingLimitError
+	suite.Require().ErrorAs(err, &outstandingErr)
+	suite.Equal(1, count(models.RateBucketDay))
+
+	taskInput := input()
+	taskInput.MaxOutstandingTasks = 0
+	_, err = suite.handler.taskRepository.CreateTask(ctx, taskInput)
+	var counterErr *repository.CounterLimitError
+	suite.Require().ErrorAs(err, &counterErr)
+	suite.Equal(models.RateBucketSecond, counterErr.Bucket)
+	suite.Equal(1, count(models.RateBucketSecond))
+	suite.Equal(1, count(models.RateBucketDay))
+
+	exceeded := creationExceeded(err, LimitsConfig{})
+	suite.Require().NotNil(exceeded)
+	suite.Equal("Requests per second limit exceeded.", exceeded.message)
+}
+
+func (suite *TasksTestSuite) Test_HandleCreateTask_priority() {
+	ctx := context.Background()
+	sender := suite.handler.taskSender.(*testTaskSender)
+	sender.On("SendMessage", mock.Anything, testTaskQueueURL("test-queue", models.TaskPriorityHigh), mock.Anything, mock.Anything).
+		Return(nil)
+	defer sender.AssertExpectations(suite.T())
+
+	data := suite.getValidTaskInput()
+	data.Priority = oas.NewOptTaskPriority(oas.TaskPriorityHigh)
+	dataBytes, _ := json.Marshal(data)
+	req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(dataBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := suite.serve(req)
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	response := oas.CreateTaskOutput{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+
+	task, exists, err := suite.handler.taskRepository.GetTask(ctx, response.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal(models.TaskPriorityHigh, task.Priority)
+}
+
+func (suite *TasksTestSuite) Test_HandleCreateTask_routing() {
+	ctx := context.Background()
+	suite.handler.cfg.RoutingRules = routing.Rules{
+		{Host: "*.example.org", Queue: "slow"},
+		{Label: "bulk", Queue: "bulk"},
+	}
+	defer func() { suite.handler.cfg.RoutingRules = nil }()
+
+	tests := []struct {
+		name  string
+		url   string
+		label string
+		queue string
+	}{
+		{"host", "https://api.example.org", "bulk", "slow"},
+		{"label", "https://example.com", "bulk", "bulk"},
+		{"default", "https://example.com", "", ""},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			sender := &testTaskSender{}
+			suite.handler.taskSender = sender
+			queueName := tt.queue
+			if queueName == "" {
+				queueName = "test-queue"
+			}
+			sender.On("SendMessage", mock.Anything, testTaskQueueURL(queueName, models.TaskPriorityNormal), mock.Anything, mock.Anything).
+				Return(nil)
+			defer sender.AssertExpectations(suite.T())
+
+			data := suite.getValidTaskInput()
+			data.URL = oas.NewOptString(tt.url)
+			if tt.label != "" {
+				data.Label = oas.NewOptString(tt.label)
+			}
+			dataBytes, _ := json.Marshal(data)
+			req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(dataBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp := suite.serve(req)
+			suite.Require().Equal(http.StatusOK, resp.StatusCode)
+			response := oas.CreateTaskOutput{}
+			suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+
+			task, exists, err := suite.handler.taskRepository.GetTask(ctx, response.ID)
+			suite.Require().NoError(err)
+			suite.Require().True(exists)
+			suite.Equal(tt.queue, task.Queue)
+		})
+	}
+}
+
+func (suite *TasksTestSuite) Test_HandleCreateTask_orderingKey() {
+	ctx := context.Background()
+	sender := suite.handler.taskSender.(*testTaskSender)
+	sender.On("SendMessage", mock.Anything, mock.Anything, mock.Anything, "account-1").Return(nil).Once()
+	defer sender.AssertExpectations(suite.T())
+
+	data := suite.getValidTaskInput()
+	data.OrderingKey = oas.NewOptString("account-1")
+	dataBytes, _ := json.Marshal(data)
+	req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(dataBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := suite.serve(req)
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	response := oas.CreateTaskOutput{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+
+	task, exists, err := suite.handler.taskRepository.GetTask(ctx, response.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal("account-1", task.OrderingKey)
+
+	data.OrderingKey = oas.NewOptString("account 1")
+	dataBytes, _ = json.Marshal(data)
+	req = httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(dataBytes))
+	req.Header.Set("Content-Type", "application/json")
+	resp = suite.serve(req)
+	suite.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *TasksTestSuite) Test_HandleCreateTask_wait() {
+	ctx := context.Background()
+	watcher := suite.handler.taskWatcher.(*testTaskWatcher)
+	sender := suite.handler.taskSender.(*testTaskSender)
+	sender.On("SendMessage", mock.Anything, testTaskQueueURL("test-queue", models.TaskPriorityNormal), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			taskID := args.Get(2).(uuid.UUID)
+			suite.Require().NoError(setTaskStatus(
+				ctx, suite.handler.taskRepository, taskID, models.TaskStatusInProcess, models.TaskStatusDone,
+			))
+			watcher.notify(&repository.TaskUpdate{ID: taskID, Status: models.TaskStatusDone})
+		}).
+		Return(nil)
+	defer sender.AssertExpectations(suite.T())
+
+	dataBytes, _ := json.Marshal(suite.getValidTaskInput())
+	for name, prepare := range map[string]func(req *http.Request){
+		"query":  func(req *http.Request) { req.URL.RawQuery = "wait=5s" },
+		"prefer": func(req *http.Request) { req.Header.Set("Prefer", "respond-async, wait=5") },
+	} {
+		suite.Run(name, func() {
+			req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(dataBytes))
+			req.Header.Set("Content-Type", "application/json")
+			prepare(req)
+
+			resp := suite.serve(req)
+			suite.Require().Equal(http.StatusCreated, resp.StatusCode)
+			output := oas.TaskStatusOutput{}
+			suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&output))
+			suite.Equal(oas.TaskStatusDone, output.Status)
+		})
+	}
+}
+
+func (suite *TasksTestSuite) Test_HandleCreateTask_waitSkippedDependent() {
+	ctx := context.Background()
+	// Notifications are sent on commit, so the tasks are created outside of the test transaction.
+	taskRepository := repository.NewTaskDB(dbPool, suite.keyring)
+	clientID := "wait-" + uuid.NewString()
+	defer func() {
+		_, err := dbPool.Exec(ctx, "DELETE FROM tasks WHERE client_id = $1", clientID)
+		suite.NoError(err)
+	}()
+	input := suite.getValidTaskInput()
+	parent, err := taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		ClientID: clientID,
+		Method:   string(input.Method.Value),
+		URL:      input.URL.Value,
+	})
+	suite.Require().NoError(err)
+	suite.Require().NoError(setTaskStatus(ctx, taskRepository, parent.ID, models.TaskStatusInProcess))
+
+	listener, err := repository.NewTaskListener(dbPool)
+	suite.Require().NoError(err)
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		_ = listener.Listen(listenCtx)
+	}()
+	_, unsubscribe, err := subscribeListener(ctx, listener, repository.TaskFilter{ClientID: clientID}, parent.ID)
+	suite.Require().NoError(err)
+	unsubscribe()
+
+	taskWatcher, txTaskRepository := suite.handler.taskWatcher, suite.handler.taskRepository
+	suite.handler.taskWatcher, suite.handler.taskRepository = listener, taskRepository
+	defer func() {
+		suite.handler.taskWatcher, suite.handler.taskRepository = taskWatcher, txTaskRepository
+	}()
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		suite.NoError(setTaskStatus(ctx, taskRepository, parent.ID, models.TaskStatusFailed))
+		_, err := taskRepository.SkipDependents(ctx, parent.ID)
+		suite.NoError(err)
+	}()
+
+	input.DependsOn = []uuid.UUID{parent.ID}
+	dataBytes, _ := json.Marshal(input)
+	req := httptest.NewRequest(http.MethodPost, "/tasks?wait=5s", bytes.NewReader(dataBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Client-Id", clientID)
+
+	start := time.Now()
+	resp := suite.serve(req)
+	suite.Require().Equal(http.StatusCreated, resp.StatusCode)
+	output := oas.TaskStatusOutput{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&output))
+	suite.Equal(oas.TaskStatusSkipped, output.Status)
+	suite.Less(time.Since(start), 5*time.Second)
+}
+
+func (suite *TasksTestSuite) Test_HandleCreateTask_waitExpired() {
+	sender := suite.handler.taskSender.(*testTaskSender)
+	sender.On("SendMessage", mock.Anything, testTaskQueueURL("test-queue", models.TaskPriorityNormal), mock.Anything, mock.Anything).
+		Return(nil)
+	defer sender.AssertExpectations(suite.T())
+
+	dataBytes, _ := json.Marshal(suite.getValidTaskInput())
+	req := httptest.NewRequest(http.MethodPost, "/tasks?wait=10ms", bytes.NewReader(dataBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := suite.serve(req)
+	suite.Require().Equal(http.StatusAccepted, resp.StatusCode)
+	output := oas.TaskStatusOutput{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&output))
+	suite.Equal(oas.TaskStatusNew, output.Status)
+
+	req = httptest.NewRequest(http.MethodPost, "/tasks?wait=soon", bytes.NewReader(dataBytes))
+	req.Header.Set("Content-Type", "application/json")
+	resp = suite.serve(req)
+	suite.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *TasksTestSuite) Test_HandleGetTask_waitForChange() {
+	ctx := context.Background()
+	input := suite.getValidTaskInput()
+	task, err := suite.handler.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		Method: string(input.Method.Value),
+		URL:    input.URL.Value,
+	})
+	suite.Require().NoError(err)
+
+	watcher := suite.handler.taskWatcher.(*testTaskWatcher)
+	go func() {
+		// The handler doesn't use the transaction while it waits for updates.
+		for !watcher.subscribed() {
+			time.Sleep(time.Millisecond)
+		}
+		suite.NoError(suite.handler.taskRepository.UpdateTask(ctx, &repository.UpdateTaskInput{
+			ID:     task.ID,
+			Status: models.TaskStatusInProcess.Pointer(),
+		}))
+		watcher.notify(&repository.TaskUpdate{ID: task.ID, Status: models.TaskStatusInProcess})
+	}()
+
+	resp := suite.serve(httptest.NewRequest(http.MethodGet, "/tasks/"+task.ID.String()+"?wait_for_change=5s", nil))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	output := oas.TaskStatusOutput{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&output))
+	suite.Equal(oas.TaskStatusInProcess, output.Status)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/tasks/"+task.ID.String()+"?wait_for_change=10ms", nil))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&output))
+	suite.Equal(oas.TaskStatusInProcess, output.Status)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/tasks/"+task.ID.String()+"?wait_for_change=-1s", nil))
+	suite.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *TasksTestSuite) Test_HandleTaskEvents() {
+	ctx := context.Background()
+	input := suite.getValidTaskInput()
+	task, err := suite.handler.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		ClientID: "events-client",
+		Method:   string(input.Method.Value),
+		URL:      input.URL.Value,
+	})
+	suite.Require().NoError(err)
+
+	streamDuration := suite.handler.cfg.EventStreamDuration
+	suite.handler.cfg.EventStreamDuration = 200 * time.Millisecond
+	defer func() {
+		suite.handler.cfg.EventStreamDuration = streamDuration
+	}()
+
+	watcher := suite.handler.taskWatcher.(*testTaskWatcher)
+	go func() {
+		for !watcher.subscribed() {
+			time.Sleep(time.Millisecond)
+		}
+		watcher.notify(&repository.TaskUpdate{ID: uuid.New(), ClientID: "other-client", Status: models.TaskStatusDone})
+		watcher.notify(&repository.TaskUpdate{ID: task.ID, ClientID: "events-client", Status: models.TaskStatusDone})
+	}()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/tasks/events?task_id="+task.ID.String()+"&client_id=other-client", nil)
+	req.Header.Set("X-Client-Id", "events-client")
+	eventsHandler{suite.handler}.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Equal("text/event-stream", w.Header().Get("Content-Type"))
+	suite.Equal(
+		"retry: 1000\n\n"+
+			`event: status`+"\n"+`data: {"id":"`+task.ID.String()+`","client_id":"events-client","status":"new"}`+"\n\n"+
+			`event: status`+"\n"+`data: {"id":"`+task.ID.String()+`","client_id":"events-client","status":"done"}`+"\n\n",
+		w.Body.String(),
+	)
+
+	// Tasks of other clients aren't streamed, even by their IDs.
+	suite.handler.cfg.EventStreamDuration = 50 * time.Millisecond
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/tasks/events?task_id="+task.ID.String(), nil)
+	req.Header.Set("X-Client-Id", "other-client")
+	eventsHandler{suite.handler}.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Equal("retry: 1000\n\n", w.Body.String())
+
+	for name, tt := range map[string]struct {
+		target   string
+		clientID string
+	}{
+		"no_client":       {"/tasks/events", ""},
+		"client_id_query": {"/tasks/events?client_id=events-client&task_id=" + task.ID.String(), ""},
+		"invalid_task_id": {"/tasks/events?task_id=test", "events-client"},
+	} {
+		suite.Run(name, func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.clientID != "" {
+				req.Header.Set("X-Client-Id", tt.clientID)
+			}
+			eventsHandler{suite.handler}.ServeHTTP(w, req)
+			suite.Equal(http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func (suite *TasksTestSuite) Test_TaskListener_skippedDependent() {
+	ctx := context.Background()
+	// Notifications are sent on commit, so the tasks are created outside of the test transaction.
+	taskRepository := repository.NewTaskDB(dbPool, suite.keyring)
+	clientID := "listener-" + uuid.NewString()
+	defer func() {
+		_, err := dbPool.Exec(ctx, "DELETE FROM tasks WHERE client_id = $1", clientID)
+		suite.NoError(err)
+	}()
+	input := suite.getValidTaskInput()
+	parent, err := taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		ClientID: clientID,
+		Method:   string(input.Method.Value),
+		URL:      input.URL.Value,
+	})
+	suite.Require().NoError(err)
+	dependent, err := taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		ClientID:  clientID,
+		Method:    string(input.Method.Value),
+		URL:       input.URL.Value,
+		DependsOn: []uuid.UUID{parent.ID},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(models.TaskStatusWaiting, dependent.Status)
+
+	listener, err := repository.NewTaskListener(dbPool)
+	suite.Require().NoError(err)
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		_ = listener.Listen(listenCtx)
+	}()
+	updates, unsubscribe, err := subscribeListener(
+		ctx, listener, repository.TaskFilter{TaskIDs: []uuid.UUID{dependent.ID}}, dependent.ID,
+	)
+	suite.Require().NoError(err)
+	defer unsubscribe()
+
+	suite.Require().NoError(setTaskStatus(
+		ctx, taskRepository, parent.ID, models.TaskStatusInProcess, models.TaskStatusFailed,
+	))
+	skipped, err := taskRepository.SkipDependents(ctx, parent.ID)
+	suite.Require().NoError(err)
+	suite.Require().EqualValues(1, skipped)
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case update, ok := <-updates:
+			suite.Require().True(ok)
+			// Probes of the subscription may still arrive.
+			if update.Status == models.TaskStatusWaiting {
+				continue
+			}
+			suite.Equal(&repository.TaskUpdate{ID: dependent.ID, ClientID: clientID, Status: models.TaskStatusSkipped}, update)
+			return
+		case <-timeout:
+			suite.Fail("dependent skip isn't notified")
+			return
+		}
+	}
+}
+
 func (suite *TasksTestSuite) Test_HandleCreateTask_badRequest() {
 	type errorResponse struct {
 		ErrorMessage string `json:"error_message"`
@@ -131,6 +711,74 @@ func (suite *TasksTestSuite) Test_HandleCreateTask_badRequest() {
 			"bad_values",
 			[]byte(`{"url": "/test", "method": "TEST"}`),
 		},
+		{
+			"relative_url",
+			[]byte(`{"url": "/test", "method": "GET"}`),
+		},
+		{
+			"unsupported_scheme",
+			[]byte(`{"url": "file:///etc/passwd", "method": "GET"}`),
+		},
+		{
+			"private_address",
+			[]byte(`{"url": "http://169.254.169.254/latest/meta-data", "method": "GET"}`),
+		},
+		{
+			"loopback_address",
+			[]byte(`{"url": "http://[::1]:8080", "method": "GET"}`),
+		},
+		{
+			"signing_without_options",
+			[]byte(`{"url": "https://example.com", "method": "GET", "signing": {"type": "aws_sigv4"}}`),
+		},
+		{
+			"unknown_oauth2_profile",
+			[]byte(`{"url": "https://example.com", "method": "GET", "oauth2_profile": "unknown"}`),
+		},
+		{
+			"unknown_tls_profile",
+			[]byte(`{"url": "https://example.com", "method": "GET", "tls_profile": "unknown"}`),
+		},
+		{
+			"invalid_proxy",
+			[]byte(`{"url": "https://example.com", "method": "GET", "proxy": {"url": "ftp://proxy:21"}}`),
+		},
+		{
+			"invalid_success_regex",
+			[]byte(`{"url": "https://example.com", "method": "GET", "success": {"body_regex": "("}}`),
+		},
+		{
+			"invalid_success_status_codes",
+			[]byte(`{"url": "https://example.com", "method": "GET", "success": {"status_codes": [{"from": 299, "to": 200}]}}`),
+		},
+		{
+			"invalid_success_json_path",
+			[]byte(`{"url": "https://example.com", "method": "GET", "success": {"json": [{"path": "id", "equals": 1}]}}`),
+		},
+		{
+			"invalid_extract_path",
+			[]byte(`{"url": "https://example.com", "method": "GET", "extract": {"id": "$.data[id]"}}`),
+		},
+		{
+			"unknown_parent",
+			[]byte(`{"url": "https://example.com", "method": "GET", "depends_on": ["6f1c4f6e-3a0b-4b8e-9c1d-2b7f0e5ae2a4"]}`),
+		},
+		{
+			"reference_not_parent",
+			[]byte(`{"url": "https://example.com/{{parent:6f1c4f6e-3a0b-4b8e-9c1d-2b7f0e5ae2a4.id}}", "method": "GET"}`),
+		},
+		{
+			"undefined_variable",
+			[]byte(`{"url": "https://example.com/{{var:id}}", "method": "GET", "variables": {"name": "x"}}`),
+		},
+		{
+			"ordering_key_with_priority",
+			[]byte(`{"url": "https://example.com", "method": "GET", "ordering_key": "account-1", "priority": "high"}`),
+		},
+		{
+			"forbidden_proxy",
+			[]byte(`{"url": "https://example.com", "method": "GET", "proxy": {"url": "http://127.0.0.1:3128"}}`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -145,13 +793,120 @@ func (suite *TasksTestSuite) Test_HandleCreateTask_badRequest() {
 	}
 }
 
+func (suite *TasksTestSuite) Test_HandleCreateTaskFanout() {
+	ctx := context.Background()
+	sender := suite.handler.taskSender.(*testTaskSender)
+	sender.On("SendMessage", mock.Anything, testTaskQueueURL("test-queue", models.TaskPriorityNormal), mock.Anything, mock.Anything).
+		Return(nil).Times(2)
+	defer sender.AssertExpectations(suite.T())
+
+	reqData := []byte(`{
+		"request": {"method": "POST", "url": "https://example.com/hooks/{{var:id}}",
+			"body": {"subscriber": "{{var:id}}"}},
+		"targets": [{"variables": {"id": "1"}}, {"url": "https://example.org/hook", "variables": {"id": "2"}}]
+	}`)
+	req := httptest.NewRequest(http.MethodPost, "/tasks/fanout", bytes.NewReader(reqData))
+	req.Header.Set("Content-Type", "application/json")
+	resp := suite.serve(req)
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var created oas.FanoutOutput
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&created))
+	suite.Require().Len(created.TaskIds, 2)
+	first, _, err := suite.handler.taskRepository.GetTask(ctx, created.TaskIds[0])
+	suite.Require().NoError(err)
+	suite.Equal("https://example.com/hooks/{{var:id}}", first.URL)
+	suite.Equal(map[string]string{"id": "1"}, first.Variables)
+	second, _, err := suite.handler.taskRepository.GetTask(ctx, created.TaskIds[1])
+	suite.Require().NoError(err)
+	suite.Equal("https://example.org/hook", second.URL)
+	suite.Equal(map[string]string{"id": "2"}, second.Variables)
+
+	err = setTaskStatus(ctx, suite.handler.taskRepository, first.ID, models.TaskStatusInProcess, models.TaskStatusDone)
+	suite.Require().NoError(err)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/task-groups/"+created.GroupID.String(), nil))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	var group oas.TaskGroupOutput
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&group))
+	suite.Equal(2, group.Total)
+	suite.Equal(oas.TaskGroupOutputCounts{"done": 1, "new": 1}, group.Counts)
+	suite.False(group.Complete)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/task-groups/"+uuid.NewString(), nil))
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *TasksTestSuite) Test_HandleCreateTaskFanout_queueError() {
+	ctx := context.Background()
+	sender := suite.handler.taskSender.(*testTaskSender)
+	sender.On("SendMessage", mock.Anything, testTaskQueueURL("test-queue", models.TaskPriorityNormal), mock.Anything, mock.Anything).
+		Return(errors.New("test error")).Once()
+	sender.On("SendMessage", mock.Anything, testTaskQueueURL("test-queue", models.TaskPriorityNormal), mock.Anything, mock.Anything).
+		Return(nil).Twice()
+	defer sender.AssertExpectations(suite.T())
+
+	reqData := []byte(`{
+		"request": {"method": "GET", "url": "https://example.com/hooks/{{var:id}}"},
+		"targets": [{"variables": {"id": "1"}}, {"variables": {"id": "2"}}, {"variables": {"id": "3"}}]
+	}`)
+	req := httptest.NewRequest(http.MethodPost, "/tasks/fanout", bytes.NewReader(reqData))
+	req.Header.Set("Content-Type", "application/json")
+	resp := suite.serve(req)
+	suite.Require().Equal(http.StatusInternalServerError, resp.StatusCode)
+
+	// All tasks are sent despite the failure, the unsent one is errored.
+	suite.Require().Len(sender.Calls, 3)
+	statuses := make([]models.TaskStatus, 0, len(sender.Calls))
+	for _, call := range sender.Calls {
+		task, exists, err := suite.handler.taskRepository.GetTask(ctx, call.Arguments.Get(2).(uuid.UUID))
+		suite.Require().NoError(err)
+		suite.Require().True(exists)
+		statuses = append(statuses, task.Status)
+	}
+	suite.Equal([]models.TaskStatus{models.TaskStatusError, models.TaskStatusNew, models.TaskStatusNew}, statuses)
+
+	var groupID uuid.UUID
+	err := suite.tx.QueryRow(ctx, "SELECT group_id FROM tasks WHERE id = $1", sender.Calls[0].Arguments.Get(2)).
+		Scan(&groupID)
+	suite.Require().NoError(err)
+	for _, call := range sender.Calls[1:] {
+		err = setTaskStatus(ctx, suite.handler.taskRepository, call.Arguments.Get(2).(uuid.UUID),
+			models.TaskStatusInProcess, models.TaskStatusDone)
+		suite.Require().NoError(err)
+	}
+
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/task-groups/"+groupID.String(), nil))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	var group oas.TaskGroupOutput
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&group))
+	suite.Equal(oas.TaskGroupOutputCounts{"done": 2, "error": 1}, group.Counts)
+	suite.True(group.Complete)
+}
+
+func (suite *TasksTestSuite) Test_HandleCreateTaskFanout_badRequest() {
+	for name, reqData := range map[string]string{
+		"undefined_variable": `{"request": {"method": "GET", "url": "https://example.com/{{var:id}}"}, "targets": [{}]}`,
+		"forbidden_target": `{"request": {"method": "GET", "url": "https://example.com"},
+			"targets": [{"url": "http://169.254.169.254/latest/meta-data"}]}`,
+		"no_targets": `{"request": {"method": "GET", "url": "https://example.com"}, "targets": []}`,
+	} {
+		suite.Run(name, func() {
+			req := httptest.NewRequest(http.MethodPost, "/tasks/fanout", bytes.NewReader([]byte(reqData)))
+			req.Header.Set("Content-Type", "application/json")
+			resp := suite.serve(req)
+			suite.Equal(http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
 func (suite *TasksTestSuite) Test_HandleGetTask() {
 	input := suite.getValidTaskInput()
 	task, err := suite.handler.taskRepository.CreateTask(
 		context.Background(),
 		&repository.CreateTaskInput{
-			Method:  string(input.Method),
-			URL:     input.URL,
+			Method:  string(input.Method.Value),
+			URL:     input.URL.Value,
 			Headers: input.Headers.Value,
 			Body:    input.Body.Value,
 		},
@@ -196,7 +951,26 @@ func (suite *TasksTestSuite) Test_HandleGetTask() {
 				suite.False(data.HTTPStatusCode.Set)
 				suite.False(data.Headers.Set)
 				suite.False(data.Length.Set)
+				suite.False(data.Timings.Set)
 			}
 		})
 	}
 }
+
+func Test_preferredWait(t *testing.T) {
+	tests := []struct {
+		prefer string
+		want   time.Duration
+	}{
+		{"wait=5", 5 * time.Second},
+		{"respond-async, Wait = 10", 10 * time.Second},
+		{"handling=lenient; foo=bar, wait=3", 3 * time.Second},
+		{"return=minimal", 0},
+		{"wait=soon", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefer, func(t *testing.T) {
+			require.Equal(t, tt.want, preferredWait(tt.prefer))
+		})
+	}
+}
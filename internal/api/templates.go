@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"requester/internal/api/oas"
+	"requester/internal/models"
+	"requester/internal/repository"
+	"requester/internal/templates"
+)
+
+// newTemplateOutput converts a template to the API output.
+func newTemplateOutput(template *models.RequestTemplate) oas.TemplateOutput {
+	output := oas.TemplateOutput{
+		Name:       template.Name,
+		Version:    template.Version,
+		Method:     template.Method,
+		URL:        template.URL,
+		Parameters: make(oas.TemplateOutputParameters, len(template.Parameters)),
+		CreatedAt:  template.CreatedAt,
+	}
+	if template.Headers != nil {
+		output.Headers = oas.NewOptTemplateOutputHeaders(template.Headers)
+	}
+	if template.Body != nil {
+		output.Body = oas.NewOptTemplateOutputBody(template.Body)
+	}
+	for name, p := range template.Parameters {
+		parameter := oas.TemplateParameter{
+			Type:     oas.TemplateParameterType(p.Type),
+			Required: oas.NewOptBool(p.Required),
+			Default:  p.Default,
+		}
+		if p.Pattern != "" {
+			parameter.Pattern = oas.NewOptString(p.Pattern)
+		}
+		if p.Description != "" {
+			parameter.Description = oas.NewOptString(p.Description)
+		}
+		output.Parameters[name] = parameter
+	}
+	return output
+}
+
+// ListTemplates lists the latest versions of client request templates.
+func (h *handler) ListTemplates(
+	ctx context.Context,
+	params oas.ListTemplatesParams,
+) (*oas.TemplateListOutput, error) {
+	found, err := h.templateRepository.ListTemplates(ctx, params.XClientID.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &oas.TemplateListOutput{Templates: make([]oas.TemplateOutput, 0, len(found))}
+	for _, template := range found {
+		output.Templates = append(output.Templates, newTemplateOutput(template))
+	}
+	return output, nil
+}
+
+// CreateTemplate creates the next version of client request template.
+// Responds with 400 if the URL is invalid or forbidden for the client or the parameters are invalid.
+func (h *handler) CreateTemplate(
+	ctx context.Context,
+	req *oas.TemplateInput,
+	params oas.CreateTemplateParams,
+) (oas.CreateTemplateRes, error) {
+	clientID := params.XClientID.Value
+	invalid, err := h.validateTaskURL(ctx, clientID, req.URL)
+	if err != nil {
+		return nil, err
+	}
+	if invalid != nil {
+		return invalid, nil
+	}
+
+	input := &repository.CreateTemplateInput{
+		ClientID:   clientID,
+		Name:       req.Name,
+		Method:     string(req.Method),
+		URL:        req.URL,
+		Headers:    req.Headers.Value,
+		Body:       req.Body.Value,
+		Parameters: make(map[string]models.TemplateParameter, len(req.Parameters.Value)),
+	}
+	for name, p := range req.Parameters.Value {
+		input.Parameters[name] = models.TemplateParameter{
+			Type:        models.ParameterType(p.Type),
+			Required:    p.Required.Value,
+			Default:     p.Default,
+			Pattern:     p.Pattern.Value,
+			Description: p.Description.Value,
+		}
+	}
+	err = templates.Validate(&models.RequestTemplate{
+		URL:        input.URL,
+		Headers:    input.Headers,
+		Body:       input.Body,
+		Parameters: input.Parameters,
+	})
+	if err != nil {
+		return &oas.ErrorOutput{ErrorMessage: "Invalid template: " + err.Error()}, nil
+	}
+
+	template, err := h.templateRepository.CreateTemplate(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	output := newTemplateOutput(template)
+	return &output, nil
+}
+
+// GetTemplate returns a version of client request template, the latest one by default.
+func (h *handler) GetTemplate(ctx context.Context, params oas.GetTemplateParams) (oas.GetTemplateRes, error) {
+	template, exists, err := h.templateRepository.GetTemplate(
+		ctx, params.XClientID.Value, params.Name, params.Version.Value,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &oas.GetTemplateNotFound{}, nil
+	}
+
+	output := newTemplateOutput(template)
+	return &output, nil
+}
+
+// DeleteTemplate deletes all versions of client request template.
+func (h *handler) DeleteTemplate(
+	ctx context.Context,
+	params oas.DeleteTemplateParams,
+) (oas.DeleteTemplateRes, error) {
+	deleted, err := h.templateRepository.DeleteTemplate(ctx, params.XClientID.Value, params.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return &oas.DeleteTemplateNotFound{}, nil
+	}
+	return &oas.DeleteTemplateNoContent{}, nil
+}
+
+// applyTemplate replaces the template reference of the task request with the template method, URL,
+// headers and body and adds the bound parameters to its variables.
+// Returns an error response if the template doesn't exist, the request sets the replaced fields
+// or the parameters don't match the template parameter schemas.
+func (h *handler) applyTemplate(
+	ctx context.Context,
+	clientID string,
+	req *oas.CreateTaskInput,
+) (*oas.ErrorOutput, error) {
+	name, ok := req.Template.Get()
+	if !ok {
+		if req.Params.Set || req.TemplateVersion.Set {
+			return &oas.ErrorOutput{ErrorMessage: "Params and template version require a template."}, nil
+		}
+		return nil, nil
+	}
+	if req.Method.Set || req.URL.Set || req.Headers.Set || req.Body.Set {
+		return &oas.ErrorOutput{ErrorMessage: "Method, URL, headers and body must not be set with a template."}, nil
+	}
+
+	template, exists, err := h.templateRepository.GetTemplate(ctx, clientID, name, req.TemplateVersion.Value)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &oas.ErrorOutput{ErrorMessage: "Template " + name + " not found."}, nil
+	}
+	values, err := templates.Bind(template, req.Params.Value)
+	if err != nil {
+		return &oas.ErrorOutput{ErrorMessage: "Invalid params: " + err.Error()}, nil
+	}
+
+	req.Method = oas.NewOptCreateTaskInputMethod(oas.CreateTaskInputMethod(template.Method))
+	req.URL = oas.NewOptString(template.URL)
+	if template.Headers != nil {
+		req.Headers = oas.NewOptCreateTaskInputHeaders(template.Headers)
+	}
+	if template.Body != nil {
+		req.Body = oas.NewOptCreateTaskInputBody(template.Body)
+	}
+	req.Variables = oas.NewOptCreateTaskInputVariables(mergeVariables(req.Variables.Value, values))
+	req.Template.Reset()
+	req.TemplateVersion.Reset()
+	req.Params.Reset()
+	return nil, nil
+}
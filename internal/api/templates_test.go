@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/go-faster/jx"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"requester/internal/api/oas"
+	"requester/internal/destination"
+	"requester/internal/encryption"
+	"requester/internal/models"
+	"requester/internal/repository"
+	"requester/internal/variables"
+	"testing"
+)
+
+func TestTemplatesTestSuite(t *testing.T) {
+	suite.Run(t, &TemplatesTestSuite{})
+}
+
+type TemplatesTestSuite struct {
+	suite.Suite
+	handler *handler
+	server  *oas.Server
+	keyring *encryption.Keyring
+}
+
+func (suite *TemplatesTestSuite) serve(req *http.Request) *http.Response {
+	suite.T().Helper()
+	req.Header.Set("X-Client-Id", "templates-client")
+	w := httptest.NewRecorder()
+	suite.server.ServeHTTP(w, req)
+	return w.Result()
+}
+
+func (suite *TemplatesTestSuite) post(path string, body interface{}) *http.Response {
+	suite.T().Helper()
+	dataBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(dataBytes))
+	req.Header.Set("Content-Type", "application/json")
+	return suite.serve(req)
+}
+
+func (suite *TemplatesTestSuite) SetupSuite() {
+	config := MustConfig(LoadConfig())
+	queues := newTestTaskQueues()
+	logger := zaptest.NewLogger(suite.T(), zaptest.Level(zap.PanicLevel))
+
+	policy, err := destination.NewPolicy(destination.Config{})
+	suite.Require().NoError(err)
+	encryptionCfg := encryption.MustConfig(encryption.LoadConfig())
+	suite.keyring = encryption.MustKeyring(encryptionCfg)
+
+	suite.server, suite.handler, err = newServer(
+		&config,
+		&testTaskSender{},
+		queues,
+		newTestTaskWatcher(),
+		policy,
+		encryption.MustCipher(encryptionCfg),
+		suite.keyring,
+		dbPool,
+		logger,
+	)
+	suite.Require().NoError(err)
+}
+
+func (suite *TemplatesTestSuite) SetupTest() {
+	suite.handler.taskSender = &testTaskSender{}
+
+	ctx := context.Background()
+	tx, err := dbPool.Begin(ctx)
+	suite.Require().NoError(err)
+	suite.handler.taskRepository = repository.NewTaskDB(tx, suite.keyring)
+	suite.handler.limitRepository = repository.NewLimitDB(tx)
+	suite.handler.destinationRepository = repository.NewDestinationDB(tx)
+	suite.handler.templateRepository = repository.NewTemplateDB(tx)
+	suite.T().Cleanup(func() {
+		suite.Require().NoError(tx.Rollback(ctx))
+	})
+}
+
+// orderTemplate returns a template input of the version with the given URL path.
+func orderTemplate(path string) oas.TemplateInput {
+	return oas.TemplateInput{
+		Name:    "order",
+		Method:  oas.TemplateInputMethodPOST,
+		URL:     "https://example.com" + path,
+		Headers: oas.NewOptTemplateInputHeaders(map[string]string{"X-Customer": "{{var:customer}}"}),
+		Body:    oas.NewOptTemplateInputBody(map[string]jx.Raw{"quantity": jx.Raw(`"{{var:quantity}}"`)}),
+		Parameters: oas.NewOptTemplateInputParameters(map[string]oas.TemplateParameter{
+			"customer": {Type: oas.TemplateParameterTypeString, Required: oas.NewOptBool(true), Pattern: oas.NewOptString(`^\d+$`)},
+			"quantity": {Type: oas.TemplateParameterTypeInteger, Default: jx.Raw(`1`)},
+		}),
+	}
+}
+
+func (suite *TemplatesTestSuite) Test_HandleTemplates() {
+	resp := suite.post("/templates", orderTemplate("/orders"))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	resp = suite.post("/templates", orderTemplate("/v2/orders"))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	output := oas.TemplateOutput{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&output))
+	suite.Equal(2, output.Version)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/templates", nil))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	list := oas.TemplateListOutput{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&list))
+	suite.Require().Len(list.Templates, 1)
+	suite.Equal("https://example.com/v2/orders", list.Templates[0].URL)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/templates/order?version=1", nil))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&output))
+	suite.Equal(1, output.Version)
+	suite.Equal("https://example.com/orders", output.URL)
+	suite.Equal(oas.TemplateParameterTypeInteger, output.Parameters["quantity"].Type)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/templates/order?version=3", nil))
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodDelete, "/templates/order", nil))
+	suite.Equal(http.StatusNoContent, resp.StatusCode)
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/templates/order", nil))
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *TemplatesTestSuite) Test_HandleCreateTemplate_badRequest() {
+	undeclared := orderTemplate("/customers/{{var:id}}")
+	invalidDefault := orderTemplate("/orders")
+	invalidDefault.Parameters.Value["quantity"] = oas.TemplateParameter{
+		Type: oas.TemplateParameterTypeInteger, Default: jx.Raw(`"one"`),
+	}
+	forbidden := orderTemplate("/orders")
+	forbidden.URL = "http://169.254.169.254/orders"
+
+	for name, input := range map[string]oas.TemplateInput{
+		"undeclared_parameter": undeclared,
+		"invalid_default":      invalidDefault,
+		"forbidden_url":        forbidden,
+	} {
+		suite.Run(name, func() {
+			resp := suite.post("/templates", input)
+			suite.Equal(http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func (suite *TemplatesTestSuite) Test_HandleCreateTask_template() {
+	ctx := context.Background()
+	sender := suite.handler.taskSender.(*testTaskSender)
+	sender.On("SendMessage", mock.Anything, testTaskQueueURL("test-queue", models.TaskPriorityNormal), mock.Anything, mock.Anything).Return(nil)
+	defer sender.AssertExpectations(suite.T())
+
+	resp := suite.post("/templates", orderTemplate("/orders"))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	resp = suite.post("/tasks", oas.CreateTaskInput{
+		Template: oas.NewOptString("order"),
+		Params:   oas.NewOptCreateTaskInputParams(map[string]jx.Raw{"customer": jx.Raw(`"42"`)}),
+	})
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	output := oas.CreateTaskOutput{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&output))
+
+	task, exists, err := suite.handler.taskRepository.GetTask(ctx, output.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal(http.MethodPost, task.Method)
+	suite.Equal("https://example.com/orders", task.URL)
+	suite.Equal("{{var:customer}}", task.Headers["X-Customer"])
+	suite.Equal(map[string]string{"customer": "42", "quantity": "1"}, task.Variables)
+}
+
+func (suite *TemplatesTestSuite) Test_HandleCreateTask_templateURLParams() {
+	ctx := context.Background()
+	sender := suite.handler.taskSender.(*testTaskSender)
+	sender.On("SendMessage", mock.Anything, testTaskQueueURL("test-queue", models.TaskPriorityNormal), mock.Anything, mock.Anything).Return(nil)
+	defer sender.AssertExpectations(suite.T())
+
+	input := orderTemplate("/customers/{{var:customer}}/orders?ref={{var:ref}}")
+	input.Parameters.Value["customer"] = oas.TemplateParameter{Type: oas.TemplateParameterTypeString, Required: oas.NewOptBool(true)}
+	input.Parameters.Value["ref"] = oas.TemplateParameter{Type: oas.TemplateParameterTypeString, Required: oas.NewOptBool(true)}
+	resp := suite.post("/templates", input)
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	resp = suite.post("/tasks", oas.CreateTaskInput{
+		Template: oas.NewOptString("order"),
+		Params: oas.NewOptCreateTaskInputParams(map[string]jx.Raw{
+			"customer": jx.Raw(`"42/../admin#x"`),
+			"ref":      jx.Raw(`"a&admin=true#y"`),
+		}),
+	})
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	output := oas.CreateTaskOutput{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&output))
+
+	task, exists, err := suite.handler.taskRepository.GetTask(ctx, output.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	rendered, err := variables.Render(&task.Task, task.Variables, variables.Builtins{})
+	suite.Require().NoError(err)
+	target, err := url.Parse(rendered.URL)
+	suite.Require().NoError(err)
+	suite.Equal("example.com", target.Host)
+	suite.Equal("/customers/42%2F..%2Fadmin%23x/orders", target.EscapedPath())
+	suite.Equal(url.Values{"ref": {"a&admin=true#y"}}, target.Query())
+	suite.Empty(target.Fragment)
+}
+
+func (suite *TemplatesTestSuite) Test_HandleCreateTask_templateBadRequest() {
+	resp := suite.post("/templates", orderTemplate("/orders"))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	params := func(customer string) oas.OptCreateTaskInputParams {
+		return oas.NewOptCreateTaskInputParams(map[string]jx.Raw{"customer": jx.Raw(customer)})
+	}
+	for name, input := range map[string]oas.CreateTaskInput{
+		"unknown_template": {Template: oas.NewOptString("refund"), Params: params(`"42"`)},
+		"unknown_version":  {Template: oas.NewOptString("order"), TemplateVersion: oas.NewOptInt(2), Params: params(`"42"`)},
+		"missing_param":    {Template: oas.NewOptString("order")},
+		"invalid_param":    {Template: oas.NewOptString("order"), Params: params(`"abc"`)},
+		"raw_fields":       {Template: oas.NewOptString("order"), Params: params(`"42"`), URL: oas.NewOptString("https://example.org")},
+		"params_only":      {Params: params(`"42"`)},
+		"no_url":           {Method: oas.NewOptCreateTaskInputMethod(oas.CreateTaskInputMethodGET)},
+	} {
+		suite.Run(name, func() {
+			resp := suite.post("/tasks", input)
+			suite.Equal(http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"requester/internal/api/oas"
+	"requester/internal/models"
+	"requester/internal/repository"
+	"requester/internal/tlsprofile"
+)
+
+// newTLSProfileOutput converts a profile to the API output without its private key and proxy password.
+func newTLSProfileOutput(profile *models.TLSProfile) oas.TlsProfileOutput {
+	output := oas.TlsProfileOutput{
+		Name:      profile.Name,
+		CreatedAt: profile.CreatedAt,
+		UpdatedAt: profile.UpdatedAt,
+	}
+	if profile.ClientCert != "" {
+		output.ClientCert = oas.NewOptString(profile.ClientCert)
+	}
+	if profile.CABundle != "" {
+		output.CaBundle = oas.NewOptString(profile.CABundle)
+	}
+	if profile.MinVersion != "" {
+		output.MinVersion = oas.NewOptTlsVersion(oas.TlsVersion(profile.MinVersion))
+	}
+	if profile.ServerName != "" {
+		output.ServerName = oas.NewOptString(profile.ServerName)
+	}
+	output.Proxy = newProxyOutput(profile.Proxy)
+	return output
+}
+
+// ListTLSProfiles lists client TLS profiles without private keys.
+func (h *handler) ListTLSProfiles(
+	ctx context.Context,
+	params oas.ListTLSProfilesParams,
+) (*oas.TlsProfileListOutput, error) {
+	profiles, err := h.tlsProfileRepository.ListProfiles(ctx, params.XClientID.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &oas.TlsProfileListOutput{Profiles: make([]oas.TlsProfileOutput, 0, len(profiles))}
+	for _, profile := range profiles {
+		output.Profiles = append(output.Profiles, newTLSProfileOutput(profile))
+	}
+	return output, nil
+}
+
+// PutTLSProfile creates or updates client TLS profile.
+// Responds with 400 if the certificates or the key are invalid or the proxy is invalid or forbidden for the client.
+func (h *handler) PutTLSProfile(
+	ctx context.Context,
+	req *oas.TlsProfileInput,
+	params oas.PutTLSProfileParams,
+) (oas.PutTLSProfileRes, error) {
+	proxy, invalid, err := h.inputProxy(ctx, params.XClientID.Value, req.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	if invalid != nil {
+		return invalid, nil
+	}
+
+	input := &repository.PutTLSProfileInput{
+		ClientID:   params.XClientID.Value,
+		Name:       req.Name,
+		ClientCert: req.ClientCert.Value,
+		ClientKey:  req.ClientKey.Value,
+		CABundle:   req.CaBundle.Value,
+		MinVersion: string(req.MinVersion.Value),
+		ServerName: req.ServerName.Value,
+		Proxy:      proxy,
+	}
+	_, err = tlsprofile.ClientConfig(&models.TLSProfile{
+		ClientCert: input.ClientCert,
+		ClientKey:  input.ClientKey,
+		CABundle:   input.CABundle,
+		MinVersion: input.MinVersion,
+		ServerName: input.ServerName,
+	})
+	if err != nil {
+		return &oas.ErrorOutput{ErrorMessage: "Invalid TLS profile: " + err.Error()}, nil
+	}
+
+	profile, err := h.tlsProfileRepository.PutProfile(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	output := newTLSProfileOutput(profile)
+	return &output, nil
+}
+
+// DeleteTLSProfile deletes client TLS profile.
+func (h *handler) DeleteTLSProfile(
+	ctx context.Context,
+	params oas.DeleteTLSProfileParams,
+) (oas.DeleteTLSProfileRes, error) {
+	deleted, err := h.tlsProfileRepository.DeleteProfile(ctx, params.XClientID.Value, params.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return &oas.DeleteTLSProfileNotFound{}, nil
+	}
+	return &oas.DeleteTLSProfileNoContent{}, nil
+}
+
+// validateTLSProfile checks that the client TLS profile referenced by the task exists.
+// Returns nil if the profile isn't set or exists.
+func (h *handler) validateTLSProfile(ctx context.Context, clientID, name string) (*oas.ErrorOutput, error) {
+	if name == "" {
+		return nil, nil
+	}
+	_, exists, err := h.tlsProfileRepository.GetProfile(ctx, clientID, name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &oas.ErrorOutput{ErrorMessage: "TLS profile " + name + " not found."}, nil
+	}
+	return nil, nil
+}
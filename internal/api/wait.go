@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"requester/internal/api/oas"
+	"requester/internal/models"
+	"requester/internal/repository"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// taskWait returns the time to wait for the created task to finish, zero if the caller doesn't wait.
+// The wait query parameter takes precedence over the wait preference in seconds.
+// Returns an error response if the wait parameter is invalid.
+func (h *handler) taskWait(params oas.CreateTaskParams) (time.Duration, *oas.ErrorOutput) {
+	if value, ok := params.Wait.Get(); ok {
+		return parseWait(value, h.cfg.MaxTaskWait)
+	}
+	wait := preferredWait(params.Prefer.Value)
+	if wait > h.cfg.MaxTaskWait {
+		wait = h.cfg.MaxTaskWait
+	}
+	return wait, nil
+}
+
+// parseWait parses the wait duration and caps it by the maximum.
+// Returns an error response if the duration is invalid.
+func parseWait(value string, max time.Duration) (time.Duration, *oas.ErrorOutput) {
+	wait, err := time.ParseDuration(value)
+	if err != nil || wait < 0 {
+		return 0, &oas.ErrorOutput{ErrorMessage: "Invalid wait " + value + "."}
+	}
+	if wait > max {
+		wait = max
+	}
+	return wait, nil
+}
+
+// preferredWait returns the wait preference (RFC 7240) of the Prefer header value,
+// zero if it isn't set or invalid.
+func preferredWait(prefer string) time.Duration {
+	for _, preference := range strings.Split(prefer, ",") {
+		// Parameters of the preference follow a semicolon.
+		preference, _, _ = strings.Cut(preference, ";")
+		name, value, _ := strings.Cut(preference, "=")
+		if !strings.EqualFold(strings.TrimSpace(name), "wait") {
+			continue
+		}
+		seconds, err := strconv.Atoi(strings.Trim(strings.TrimSpace(value), `"`))
+		if err != nil || seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	return 0
+}
+
+// waitTask waits until the stop function accepts the task or the wait expires, re-reading the task
+// on each of its status updates. Returns the last read task and whether it was accepted.
+func (h *handler) waitTask(
+	ctx context.Context,
+	taskID uuid.UUID,
+	wait time.Duration,
+	stop func(task *models.TaskWithResponseData) bool,
+) (_ *models.TaskWithResponseData, stopped bool, _ error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	filter := repository.TaskFilter{TaskIDs: []uuid.UUID{taskID}}
+	updates, unsubscribe := h.taskWatcher.Subscribe(filter)
+	defer func() {
+		unsubscribe()
+	}()
+
+	for {
+		// The task is read after subscribing, so no update after the read is missed.
+		task, exists, err := h.taskRepository.GetTask(ctx, taskID)
+		if err != nil {
+			return nil, false, err
+		}
+		if !exists {
+			return nil, false, fmt.Errorf("task %s not found", taskID)
+		}
+		if stop(task) {
+			return task, true, nil
+		}
+
+		select {
+		case _, ok := <-updates:
+			if !ok {
+				unsubscribe()
+				updates, unsubscribe = h.taskWatcher.Subscribe(filter)
+			}
+		case <-timer.C:
+			return task, false, nil
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+}
+
+// isTerminal reports whether the task has reached a terminal status.
+func isTerminal(task *models.TaskWithResponseData) bool {
+	return task.Status.Terminal()
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"requester/internal/api/oas"
+	"requester/internal/models"
+	"requester/internal/repository"
+	"requester/internal/workflow"
+	"time"
+)
+
+// CreateWorkflow creates a workflow and sends its steps without parents to the queue.
+// Responds with 400 if the steps don't form a DAG, their references or conditions are invalid
+// or any step request is invalid as a task and with 429 if the client has exceeded its limits.
+// Steps which can't be sent are errored and the rest are sent, responds with 500 then.
+func (h *handler) CreateWorkflow(
+	ctx context.Context,
+	req *oas.CreateWorkflowInput,
+	params oas.CreateWorkflowParams,
+) (oas.CreateWorkflowRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	clientID := params.XClientID.Value
+	steps := make([]workflow.Step, 0, len(req.Steps))
+	requests := make(map[string]*oas.CreateTaskInput, len(req.Steps))
+	for i := range req.Steps {
+		input := &req.Steps[i]
+		if len(input.Request.DependsOn) > 0 {
+			return invalidWorkflow(fmt.Sprintf("step %s must depend on steps, not tasks", input.Name)), nil
+		}
+		invalid, err := h.applyTemplate(ctx, clientID, &input.Request)
+		if err != nil {
+			return nil, err
+		}
+		if invalid != nil {
+			invalid.ErrorMessage = "Step " + input.Name + ": " + invalid.ErrorMessage
+			return invalid, nil
+		}
+		requests[input.Name] = &input.Request
+
+		step := workflow.Step{
+			Name:      input.Name,
+			DependsOn: input.DependsOn,
+			Task: &models.Task{
+				URL:     input.Request.URL.Value,
+				Headers: input.Request.Headers.Value,
+				Body:    input.Request.Body.Value,
+				Extract: input.Request.Extract.Value,
+			},
+		}
+		if condition, ok := input.Condition.Get(); ok {
+			step.Condition = &workflow.Condition{
+				Step:      condition.Step,
+				Value:     condition.Value,
+				Equals:    condition.Equals,
+				NotEquals: condition.NotEquals,
+			}
+		}
+		steps = append(steps, step)
+	}
+
+	planned, err := workflow.Plan(steps)
+	if err != nil {
+		return invalidWorkflow(err.Error()), nil
+	}
+
+	input := &repository.CreateWorkflowInput{ClientID: clientID}
+	for _, step := range planned {
+		request := *requests[step.Name]
+		request.URL = oas.NewOptString(step.Task.URL)
+		request.Headers.Value = step.Task.Headers
+		request.Body.Value = step.Task.Body
+		if workflowVariables, ok := req.Variables.Get(); ok {
+			request.Variables = oas.NewOptCreateTaskInputVariables(
+				mergeVariables(workflowVariables, request.Variables.Value),
+			)
+		}
+
+		taskInput, invalid, err := h.taskInput(ctx, clientID, &request)
+		if err != nil {
+			return nil, err
+		}
+		if invalid != nil {
+			invalid.ErrorMessage = "Step " + step.Name + ": " + invalid.ErrorMessage
+			return invalid, nil
+		}
+		taskInput.ID = step.Task.ID
+		taskInput.DependsOn = step.Task.DependsOn
+		taskInput.Condition = step.Task.Condition
+		input.Steps = append(input.Steps, repository.CreateWorkflowStepInput{Name: step.Name, Task: taskInput})
+	}
+
+	limits, exceeded, err := h.checkClientLimits(ctx, clientID, len(input.Steps))
+	if err != nil {
+		return nil, err
+	}
+	if exceeded != nil {
+		return exceeded.response(), nil
+	}
+
+	for _, step := range input.Steps {
+		step.Task.MaxOutstandingTasks = limits.MaxOutstandingTasks
+	}
+	input.CounterLimits = limits.counterLimits(len(input.Steps))
+	created, err := h.workflowRepository.CreateWorkflow(ctx, input)
+	if exceeded = creationExceeded(err, limits); exceeded != nil {
+		return exceeded.response(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Steps with parents are sent by the worker once the parents are done.
+	// The rest of the steps are sent even if one can't be, it's errored and its dependents are skipped then,
+	// so the workflow still finishes.
+	var sendErr error
+	for i, step := range created.Steps {
+		if step.Status != models.TaskStatusNew {
+			continue
+		}
+		if err = h.sendTask(ctx, input.Steps[i].Task.QueuedTask(step.TaskID)); err != nil {
+			if _, skipErr := h.taskRepository.SkipDependents(ctx, step.TaskID); skipErr != nil {
+				err = fmt.Errorf("failed to skip dependents of unsent step: %w: %s", err, skipErr)
+			}
+			if sendErr == nil {
+				sendErr = err
+			}
+		}
+	}
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	return newWorkflowOutput(created), nil
+}
+
+// GetWorkflow returns workflow progress.
+func (h *handler) GetWorkflow(ctx context.Context, params oas.GetWorkflowParams) (oas.GetWorkflowRes, error) {
+	found, exists, err := h.workflowRepository.GetWorkflow(ctx, params.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &oas.GetWorkflowNotFound{}, nil
+	}
+	return newWorkflowOutput(found), nil
+}
+
+// newWorkflowOutput converts the workflow to the API output.
+func newWorkflowOutput(w *models.Workflow) *oas.WorkflowOutput {
+	output := &oas.WorkflowOutput{
+		ID:        w.ID,
+		Status:    oas.WorkflowStatus(w.Status),
+		Steps:     make([]oas.WorkflowStepOutput, 0, len(w.Steps)),
+		CreatedAt: w.CreatedAt,
+	}
+	for _, step := range w.Steps {
+		output.Steps = append(output.Steps, oas.WorkflowStepOutput{
+			Name:   step.Name,
+			TaskID: step.TaskID,
+			Status: oas.TaskStatus(step.Status),
+		})
+	}
+	return output
+}
+
+// invalidWorkflow returns the error response of an invalid workflow definition.
+func invalidWorkflow(reason string) *oas.ErrorOutput {
+	return &oas.ErrorOutput{ErrorMessage: "Invalid workflow: " + reason}
+}
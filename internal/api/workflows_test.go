@@ -0,0 +1,224 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"net/http"
+	"net/http/httptest"
+	"requester/internal/api/oas"
+	"requester/internal/destination"
+	"requester/internal/encryption"
+	"requester/internal/models"
+	"requester/internal/repository"
+	"testing"
+)
+
+func TestWorkflowsTestSuite(t *testing.T) {
+	suite.Run(t, &WorkflowsTestSuite{})
+}
+
+type WorkflowsTestSuite struct {
+	suite.Suite
+	handler *handler
+	server  *oas.Server
+	keyring *encryption.Keyring
+	tx      pgx.Tx
+}
+
+func (suite *WorkflowsTestSuite) serve(req *http.Request) *http.Response {
+	suite.T().Helper()
+	req.Header.Set("X-Client-Id", "workflow-client")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.server.ServeHTTP(w, req)
+	return w.Result()
+}
+
+func (suite *WorkflowsTestSuite) SetupSuite() {
+	config := MustConfig(LoadConfig())
+	queues := newTestTaskQueues()
+	logger := zaptest.NewLogger(suite.T(), zaptest.Level(zap.PanicLevel))
+
+	policy, err := destination.NewPolicy(destination.Config{})
+	suite.Require().NoError(err)
+	encryptionCfg := encryption.MustConfig(encryption.LoadConfig())
+	suite.keyring = encryption.MustKeyring(encryptionCfg)
+
+	suite.server, suite.handler, err = newServer(
+		&config,
+		&testTaskSender{},
+		queues,
+		newTestTaskWatcher(),
+		policy,
+		encryption.MustCipher(encryptionCfg),
+		suite.keyring,
+		dbPool,
+		logger,
+	)
+	suite.Require().NoError(err)
+}
+
+func (suite *WorkflowsTestSuite) SetupTest() {
+	suite.handler.taskSender = &testTaskSender{}
+
+	ctx := context.Background()
+	tx, err := dbPool.Begin(ctx)
+	suite.Require().NoError(err)
+	suite.tx = tx
+	suite.handler.taskRepository = repository.NewTaskDB(tx, suite.keyring)
+	suite.handler.limitRepository = repository.NewLimitDB(tx)
+	suite.handler.destinationRepository = repository.NewDestinationDB(tx)
+	suite.handler.workflowRepository = repository.NewWorkflowDB(tx, suite.keyring)
+	suite.T().Cleanup(func() {
+		suite.Require().NoError(tx.Rollback(ctx))
+	})
+}
+
+func (suite *WorkflowsTestSuite) Test_HandleCreateWorkflow_ok() {
+	sender := suite.handler.taskSender.(*testTaskSender)
+	sender.On("SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	reqData := []byte(`{
+		"variables": {"customer": "42"},
+		"steps": [
+			{
+				"name": "order",
+				"depends_on": ["login"],
+				"condition": {"step": "login", "value": "active", "equals": true},
+				"request": {"method": "GET", "url": "https://example.com/customers/{{var:customer}}/orders",
+					"headers": {"Authorization": "Bearer {{step:login.token}}"}}
+			},
+			{
+				"name": "login",
+				"request": {"method": "POST", "url": "https://example.com/sessions",
+					"extract": {"token": "$.token", "active": "$.active"}}
+			}
+		]
+	}`)
+	resp := suite.serve(httptest.NewRequest(http.MethodPost, "/workflows", bytes.NewReader(reqData)))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	var created oas.WorkflowOutput
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&created))
+	suite.Equal(oas.WorkflowStatusRunning, created.Status)
+	suite.Require().Len(created.Steps, 2)
+	suite.Equal("login", created.Steps[0].Name)
+	suite.Equal(oas.TaskStatusNew, created.Steps[0].Status)
+	suite.Equal(oas.TaskStatusWaiting, created.Steps[1].Status)
+	sender.AssertCalled(suite.T(), "SendMessage", mock.Anything, mock.Anything, created.Steps[0].TaskID, mock.Anything)
+
+	order, exists, err := suite.handler.taskRepository.GetTask(context.Background(), created.Steps[1].TaskID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	loginID := created.Steps[0].TaskID.String()
+	suite.Equal("https://example.com/customers/{{var:customer}}/orders", order.URL)
+	suite.Equal(map[string]string{"customer": "42"}, order.Variables)
+	suite.Equal("Bearer {{parent:"+loginID+".token}}", order.Headers["Authorization"])
+	suite.Require().NotNil(order.Condition)
+	suite.Equal(created.Steps[0].TaskID, order.Condition.ParentID)
+
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/workflows/"+created.ID.String(), nil))
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	var found oas.WorkflowOutput
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&found))
+	suite.Equal(created.Steps, found.Steps)
+
+	err = setTaskStatus(
+		context.Background(), suite.handler.taskRepository, order.ID,
+		models.TaskStatusNew, models.TaskStatusInProcess, models.TaskStatusFailed,
+	)
+	suite.Require().NoError(err)
+	err = setTaskStatus(
+		context.Background(), suite.handler.taskRepository, created.Steps[0].TaskID,
+		models.TaskStatusInProcess, models.TaskStatusDone,
+	)
+	suite.Require().NoError(err)
+	resp = suite.serve(httptest.NewRequest(http.MethodGet, "/workflows/"+created.ID.String(), nil))
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&found))
+	suite.Equal(oas.WorkflowStatusFailed, found.Status)
+}
+
+func (suite *WorkflowsTestSuite) Test_HandleCreateWorkflow_queueError() {
+	ctx := context.Background()
+	sender := suite.handler.taskSender.(*testTaskSender)
+	sender.On("SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("test error")).Once()
+	sender.On("SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	defer sender.AssertExpectations(suite.T())
+
+	reqData := []byte(`{"steps": [
+		{"name": "a", "request": {"method": "GET", "url": "https://example.com/a"}},
+		{"name": "b", "request": {"method": "GET", "url": "https://example.com/b"}},
+		{"name": "c", "depends_on": ["a"], "request": {"method": "GET", "url": "https://example.com/c"}},
+		{"name": "d", "depends_on": ["b"], "request": {"method": "GET", "url": "https://example.com/d"}}
+	]}`)
+	resp := suite.serve(httptest.NewRequest(http.MethodPost, "/workflows", bytes.NewReader(reqData)))
+	suite.Require().Equal(http.StatusInternalServerError, resp.StatusCode)
+
+	// The unsent root is errored and its dependent skipped, the other root is still sent.
+	rows, err := suite.tx.Query(
+		ctx,
+		"SELECT s.name, t.status FROM workflow_steps s JOIN tasks t ON t.id = s.task_id "+
+			"JOIN workflows w ON w.id = s.workflow_id WHERE w.client_id = $1",
+		"workflow-client",
+	)
+	suite.Require().NoError(err)
+	defer rows.Close()
+	statuses := make(map[string]models.TaskStatus)
+	for rows.Next() {
+		var name string
+		var status models.TaskStatus
+		suite.Require().NoError(rows.Scan(&name, &status))
+		statuses[name] = status
+	}
+	suite.Require().NoError(rows.Err())
+	suite.Equal(map[string]models.TaskStatus{
+		"a": models.TaskStatusError,
+		"b": models.TaskStatusNew,
+		"c": models.TaskStatusSkipped,
+		"d": models.TaskStatusWaiting,
+	}, statuses)
+}
+
+func (suite *WorkflowsTestSuite) Test_HandleCreateWorkflow_badRequest() {
+	type errorResponse struct {
+		ErrorMessage string `json:"error_message"`
+	}
+	tests := []struct {
+		name    string
+		reqData string
+	}{
+		{"no_steps", `{"steps": []}`},
+		{"cycle", `{"steps": [
+			{"name": "a", "depends_on": ["b"], "request": {"method": "GET", "url": "https://example.com"}},
+			{"name": "b", "depends_on": ["a"], "request": {"method": "GET", "url": "https://example.com"}}
+		]}`},
+		{"not_extracted", `{"steps": [
+			{"name": "a", "request": {"method": "GET", "url": "https://example.com"}},
+			{"name": "b", "depends_on": ["a"], "request": {"method": "GET", "url": "https://example.com/{{step:a.id}}"}}
+		]}`},
+		{"task_parents", `{"steps": [
+			{"name": "a", "request": {"method": "GET", "url": "https://example.com",
+				"depends_on": ["6f1c4f6e-3a0b-4b8e-9c1d-2b7f0e5ae2a4"]}}
+		]}`},
+		{"invalid_step_url", `{"steps": [{"name": "a", "request": {"method": "GET", "url": "http://169.254.169.254/latest/meta-data"}}]}`},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			resp := suite.serve(httptest.NewRequest(http.MethodPost, "/workflows", bytes.NewReader([]byte(tt.reqData))))
+			suite.Equal(http.StatusBadRequest, resp.StatusCode)
+			suite.NoError(json.NewDecoder(resp.Body).Decode(&errorResponse{}))
+		})
+	}
+}
+
+func (suite *WorkflowsTestSuite) Test_HandleGetWorkflow_notFound() {
+	resp := suite.serve(httptest.NewRequest(http.MethodGet, "/workflows/6f1c4f6e-3a0b-4b8e-9c1d-2b7f0e5ae2a4", nil))
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,55 @@
+package dependency
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"requester/internal/jsonpath"
+	"requester/internal/models"
+)
+
+// ValidateCondition checks that the expected values of the condition are valid JSON.
+func ValidateCondition(c *models.Condition) error {
+	if c.Equals == nil && c.NotEquals == nil {
+		return fmt.Errorf("condition on %s must set equals or not_equals", c.Value)
+	}
+	for _, raw := range [][]byte{c.Equals, c.NotEquals} {
+		if raw != nil && !json.Valid(raw) {
+			return fmt.Errorf("expected value of %s is not valid JSON", c.Value)
+		}
+	}
+	return nil
+}
+
+// Holds reports whether the parent value satisfies the condition.
+// Values are compared as decoded JSON, so formatting doesn't matter.
+func Holds(c *models.Condition, lookup Lookup) (bool, error) {
+	raw, err := lookup(Reference{ParentID: c.ParentID, Name: c.Value})
+	if err != nil {
+		return false, err
+	}
+	value, err := jsonpath.Decode(raw)
+	if err != nil {
+		return false, err
+	}
+
+	if c.Equals != nil {
+		want, err := jsonpath.Decode(c.Equals)
+		if err != nil {
+			return false, err
+		}
+		if !reflect.DeepEqual(value, want) {
+			return false, nil
+		}
+	}
+	if c.NotEquals != nil {
+		unwanted, err := jsonpath.Decode(c.NotEquals)
+		if err != nil {
+			return false, err
+		}
+		if reflect.DeepEqual(value, unwanted) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
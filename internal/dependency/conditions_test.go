@@ -0,0 +1,45 @@
+package dependency
+
+import (
+	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"requester/internal/models"
+	"testing"
+)
+
+func Test_Holds(t *testing.T) {
+	parentID := uuid.New()
+	values := map[string]jx.Raw{
+		"state": jx.Raw(`"paid"`),
+		"items": jx.Raw(`[1, 2]`),
+		"none":  jx.Raw(`null`),
+	}
+	lookup := func(ref Reference) (jx.Raw, error) {
+		return values[ref.Name], nil
+	}
+
+	tests := []struct {
+		name      string
+		condition models.Condition
+		want      bool
+	}{
+		{"equals", models.Condition{Value: "state", Equals: jx.Raw(`"paid"`)}, true},
+		{"not_equal", models.Condition{Value: "state", Equals: jx.Raw(`"refunded"`)}, false},
+		{"equals_array", models.Condition{Value: "items", Equals: jx.Raw(`[1,2]`)}, true},
+		{"not_equals", models.Condition{Value: "none", NotEquals: jx.Raw(`null`)}, false},
+		{"not_equals_other", models.Condition{Value: "state", NotEquals: jx.Raw(`null`)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.condition.ParentID = parentID
+			require.NoError(t, ValidateCondition(&tt.condition))
+			got, err := Holds(&tt.condition, lookup)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	require.Error(t, ValidateCondition(&models.Condition{Value: "state"}))
+	require.Error(t, ValidateCondition(&models.Condition{Value: "state", Equals: jx.Raw(`{`)}))
+}
@@ -0,0 +1,132 @@
+// Package dependency resolves references to values extracted from parent tasks.
+package dependency
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	"net/url"
+	"regexp"
+	"requester/internal/models"
+)
+
+// referenceRe matches parent references like {{parent:6f1c...e2a4.token}}.
+var referenceRe = regexp.MustCompile(
+	`\{\{\s*parent:([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})\.([A-Za-z0-9_.-]+)\s*\}\}`,
+)
+
+// Reference is a reference to a value extracted from a parent task.
+type Reference struct {
+	ParentID uuid.UUID
+	Name     string
+}
+
+// Lookup returns the value extracted from the parent task by name.
+type Lookup func(ref Reference) (jx.Raw, error)
+
+// References returns the parent references of the task URL, headers and body.
+func References(task *models.Task) []Reference {
+	var refs []Reference
+	collect := func(s string) {
+		for _, match := range referenceRe.FindAllStringSubmatch(s, -1) {
+			refs = append(refs, Reference{ParentID: uuid.MustParse(match[1]), Name: match[2]})
+		}
+	}
+
+	collect(task.URL)
+	for _, v := range task.Headers {
+		collect(v)
+	}
+	for _, v := range task.Body {
+		collect(string(v))
+	}
+	return refs
+}
+
+// Resolve replaces parent references in the string with values escaped by the escape function.
+// String values are inserted as is, other JSON values as their JSON text. Null values can't be referenced.
+func Resolve(s string, lookup Lookup, escape func(string) string) (string, error) {
+	var err error
+	resolved := referenceRe.ReplaceAllStringFunc(s, func(match string) string {
+		if err != nil {
+			return match
+		}
+		groups := referenceRe.FindStringSubmatch(match)
+		ref := Reference{ParentID: uuid.MustParse(groups[1]), Name: groups[2]}
+
+		var raw jx.Raw
+		if raw, err = lookup(ref); err != nil {
+			return match
+		}
+		var value string
+		if value, err = stringValue(ref, raw); err != nil {
+			return match
+		}
+		return escape(value)
+	})
+	if err != nil {
+		return "", err
+	}
+	return resolved, nil
+}
+
+// ResolveTask returns a copy of the task with parent references resolved in URL, headers and body.
+// The task itself is left unchanged, so references are resolved again on retries.
+func ResolveTask(task *models.Task, lookup Lookup) (*models.Task, error) {
+	resolved := *task
+
+	var err error
+	if resolved.URL, err = Resolve(task.URL, lookup, url.QueryEscape); err != nil {
+		return nil, err
+	}
+
+	if task.Headers != nil {
+		resolved.Headers = make(map[string]string, len(task.Headers))
+		for k, v := range task.Headers {
+			if resolved.Headers[k], err = Resolve(v, lookup, noEscape); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if task.Body != nil {
+		resolved.Body = make(map[string]jx.Raw, len(task.Body))
+		for k, v := range task.Body {
+			value, err := Resolve(string(v), lookup, jsonEscape)
+			if err != nil {
+				return nil, err
+			}
+			resolved.Body[k] = jx.Raw(value)
+		}
+	}
+
+	return &resolved, nil
+}
+
+// stringValue converts the extracted JSON value to the string inserted in place of the reference.
+func stringValue(ref Reference, raw jx.Raw) (string, error) {
+	switch raw.Type() {
+	case jx.Invalid, jx.Null:
+		return "", fmt.Errorf("value %s of parent task %s is null", ref.Name, ref.ParentID)
+	case jx.String:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return "", err
+		}
+		return value, nil
+	default:
+		return string(raw), nil
+	}
+}
+
+// noEscape returns the value as is.
+func noEscape(value string) string {
+	return value
+}
+
+// jsonEscape escapes the value to be placed inside a JSON string.
+func jsonEscape(value string) string {
+	escaped, _ := json.Marshal(value)
+	return string(escaped[1 : len(escaped)-1])
+}
@@ -0,0 +1,57 @@
+package dependency
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"requester/internal/models"
+	"testing"
+)
+
+func Test_ResolveTask(t *testing.T) {
+	parentID := uuid.New()
+	values := map[string]jx.Raw{
+		"token": jx.Raw(`"t\"o&k en"`),
+		"id":    jx.Raw(`7`),
+		"none":  jx.Raw(`null`),
+	}
+	lookup := func(ref Reference) (jx.Raw, error) {
+		if ref.ParentID != parentID {
+			return nil, errors.New("not a parent")
+		}
+		value, ok := values[ref.Name]
+		if !ok {
+			return nil, errors.New("value not extracted")
+		}
+		return value, nil
+	}
+
+	ref := func(name string) string {
+		return "{{parent:" + parentID.String() + "." + name + "}}"
+	}
+	task := &models.Task{
+		URL:     "https://example.com/orders/" + ref("id") + "?token=" + ref("token"),
+		Headers: map[string]string{"Authorization": "Bearer " + ref("token")},
+		Body:    map[string]jx.Raw{"auth": jx.Raw(`{"token": "` + ref("token") + `"}`)},
+	}
+	require.Len(t, References(task), 4)
+	require.Equal(t, Reference{ParentID: parentID, Name: "id"}, References(&models.Task{URL: task.URL})[0])
+
+	resolved, err := ResolveTask(task, lookup)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/orders/7?token=t%22o%26k+en", resolved.URL)
+	require.Equal(t, `Bearer t"o&k en`, resolved.Headers["Authorization"])
+	var auth struct {
+		Token string `json:"token"`
+	}
+	require.NoError(t, json.Unmarshal(resolved.Body["auth"], &auth))
+	require.Equal(t, `t"o&k en`, auth.Token)
+	require.Equal(t, "Bearer "+ref("token"), task.Headers["Authorization"], "task must not change")
+
+	_, err = ResolveTask(&models.Task{URL: "https://example.com/" + ref("none")}, lookup)
+	require.Error(t, err, "null values can't be referenced")
+	_, err = ResolveTask(&models.Task{URL: "https://example.com/{{parent:" + uuid.NewString() + ".id}}"}, lookup)
+	require.Error(t, err)
+}
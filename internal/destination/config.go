@@ -0,0 +1,29 @@
+package destination
+
+import (
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config for destination policy.
+// Host patterns are either exact hosts or wildcards like "*.example.com".
+type Config struct {
+	AllowHosts []string `envconfig:"DESTINATION_ALLOW_HOSTS"`
+	DenyHosts  []string `envconfig:"DESTINATION_DENY_HOSTS"`
+	AllowCIDRs []string `envconfig:"DESTINATION_ALLOW_CIDRS"`
+	DenyCIDRs  []string `envconfig:"DESTINATION_DENY_CIDRS"`
+}
+
+// LoadConfig loads envs.
+func LoadConfig() (Config, error) {
+	c := Config{}
+	return c, envconfig.Process("", &c)
+}
+
+// MustConfig loads envs.
+// Panics in case of error.
+func MustConfig(c Config, err error) Config {
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
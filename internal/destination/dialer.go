@@ -0,0 +1,91 @@
+package destination
+
+import (
+	"context"
+	"net"
+)
+
+// policyKey is a context key of the destination policy.
+type policyKey struct{}
+
+// WithPolicy returns a copy of the context carrying the policy used by the dialer.
+func WithPolicy(ctx context.Context, policy *Policy) context.Context {
+	return context.WithValue(ctx, policyKey{}, policy)
+}
+
+// FromContext returns the policy carried by the context, falling back to the default one.
+func FromContext(ctx context.Context, defaultPolicy *Policy) *Policy {
+	if policy, ok := ctx.Value(policyKey{}).(*Policy); ok {
+		return policy
+	}
+	return defaultPolicy
+}
+
+// resolve resolves the host and checks it and all its addresses against the policy.
+func (p *Policy) resolve(ctx context.Context, resolver *net.Resolver, host string) ([]net.IPAddr, error) {
+	if err := p.CheckHost(host); err != nil {
+		return nil, err
+	}
+
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no addresses found", Name: host}
+	}
+	for _, addr := range addrs {
+		if err = p.CheckIP(addr.IP); err != nil {
+			return nil, err
+		}
+	}
+	return addrs, nil
+}
+
+// CheckResolved resolves the host and checks all its addresses.
+// Used for requests sent through proxies, which resolve the host themselves.
+func (p *Policy) CheckResolved(ctx context.Context, host string) error {
+	_, err := p.resolve(ctx, net.DefaultResolver, host)
+	return err
+}
+
+// DialContext wraps the dialer so that only destinations allowed by the policy can be reached.
+// The policy is taken from the context, falling back to the default one.
+// The host is resolved once and only the checked addresses are dialed,
+// so DNS rebinding can't bypass the policy.
+// Hosts trusted by the policy are dialed as is.
+func DialContext(
+	dialer *net.Dialer,
+	defaultPolicy *Policy,
+) func(ctx context.Context, network, address string) (net.Conn, error) {
+	resolver := dialer.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		policy := FromContext(ctx, defaultPolicy)
+
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		if policy.trusts(host) {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		addrs, err := policy.resolve(ctx, resolver, host)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, addr := range addrs {
+			var conn net.Conn
+			conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(addr.IP.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+		}
+		return nil, err
+	}
+}
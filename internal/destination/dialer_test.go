@@ -0,0 +1,37 @@
+package destination
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+)
+
+func Test_DialContext(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	defaultPolicy, err := NewPolicy(Config{})
+	require.NoError(t, err)
+	loopbackPolicy, err := NewPolicy(Config{AllowCIDRs: []string{"127.0.0.0/8"}})
+	require.NoError(t, err)
+
+	dial := DialContext(&net.Dialer{}, defaultPolicy)
+	ctx := context.Background()
+
+	_, err = dial(ctx, "tcp", listener.Addr().String())
+	var forbiddenErr *ForbiddenError
+	require.ErrorAs(t, err, &forbiddenErr)
+
+	conn, err := dial(WithPolicy(ctx, loopbackPolicy), "tcp", listener.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	conn, err = dial(WithPolicy(ctx, defaultPolicy.Trust("127.0.0.1")), "tcp", listener.Addr().String())
+	require.NoError(t, err, "trusted hosts are dialed without checks")
+	require.NoError(t, conn.Close())
+
+	require.ErrorAs(t, defaultPolicy.CheckResolved(ctx, "localhost"), &forbiddenErr)
+	require.NoError(t, loopbackPolicy.CheckResolved(ctx, "localhost"))
+}
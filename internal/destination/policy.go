@@ -0,0 +1,263 @@
+package destination
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"requester/internal/models"
+	"strings"
+)
+
+// ForbiddenError is returned when the destination isn't allowed by the policy.
+type ForbiddenError struct {
+	Destination string
+	Reason      string
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("destination %s is forbidden: %s", e.Destination, e.Reason)
+}
+
+// blockedNetworks can't be reached unless allowed explicitly:
+// private, loopback, link-local, multicast and reserved addresses.
+var blockedNetworks = mustParseCIDRs([]string{
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+})
+
+// hostnameRe matches a syntactically valid DNS hostname.
+var hostnameRe = regexp.MustCompile(`^(?i:[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)(\.(?i:[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?))*\.?$`)
+
+// Policy defines which destinations can be requested.
+type Policy struct {
+	allowHosts []string
+	denyHosts  []string
+	allowNets  []*net.IPNet
+	denyNets   []*net.IPNet
+	// trustedHosts are dialed without checks, e.g. operator-configured proxies.
+	trustedHosts []string
+}
+
+// NewPolicy creates a new policy from config.
+func NewPolicy(cfg Config) (*Policy, error) {
+	return (&Policy{}).with(cfg.AllowHosts, cfg.DenyHosts, cfg.AllowCIDRs, cfg.DenyCIDRs)
+}
+
+// WithRules returns a copy of the policy extended with the client rules.
+func (p *Policy) WithRules(rules *models.DestinationRules) (*Policy, error) {
+	if rules == nil {
+		return p, nil
+	}
+	return p.with(rules.AllowHosts, rules.DenyHosts, rules.AllowCIDRs, rules.DenyCIDRs)
+}
+
+// with returns a copy of the policy extended with the lists.
+func (p *Policy) with(allowHosts, denyHosts, allowCIDRs, denyCIDRs []string) (*Policy, error) {
+	allowNets, err := parseCIDRs(allowCIDRs)
+	if err != nil {
+		return nil, err
+	}
+	denyNets, err := parseCIDRs(denyCIDRs)
+	if err != nil {
+		return nil, err
+	}
+	return &Policy{
+		allowHosts:   append(append([]string{}, p.allowHosts...), normalizeHosts(allowHosts)...),
+		denyHosts:    append(append([]string{}, p.denyHosts...), normalizeHosts(denyHosts)...),
+		allowNets:    append(append([]*net.IPNet{}, p.allowNets...), allowNets...),
+		denyNets:     append(append([]*net.IPNet{}, p.denyNets...), denyNets...),
+		trustedHosts: p.trustedHosts,
+	}, nil
+}
+
+// Trust returns a copy of the policy that lets the dialer reach the host without checks.
+// Must be used only for hosts configured by the operator.
+func (p *Policy) Trust(host string) *Policy {
+	trusted := *p
+	trusted.trustedHosts = append(append([]string{}, p.trustedHosts...), normalizeHosts([]string{host})...)
+	return &trusted
+}
+
+// trusts reports whether the host is trusted.
+func (p *Policy) trusts(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, trusted := range p.trustedHosts {
+		if trusted == host {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckHost checks the host against the host lists.
+// If allowed hosts are set, the host must match one of them.
+func (p *Policy) CheckHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, pattern := range p.denyHosts {
+		if MatchHost(pattern, host) {
+			return &ForbiddenError{Destination: host, Reason: "host is denied"}
+		}
+	}
+	if len(p.allowHosts) == 0 {
+		return nil
+	}
+	for _, pattern := range p.allowHosts {
+		if MatchHost(pattern, host) {
+			return nil
+		}
+	}
+	return &ForbiddenError{Destination: host, Reason: "host is not allowed"}
+}
+
+// CheckIP checks the IP against the network lists.
+// Denied networks take precedence, allowed networks lift the block of private addresses.
+func (p *Policy) CheckIP(ip net.IP) error {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	if containsIP(p.denyNets, ip) {
+		return &ForbiddenError{Destination: ip.String(), Reason: "network is denied"}
+	}
+	if containsIP(p.allowNets, ip) {
+		return nil
+	}
+	if containsIP(blockedNetworks, ip) {
+		return &ForbiddenError{Destination: ip.String(), Reason: "address is private or reserved"}
+	}
+	return nil
+}
+
+// CheckURL checks the URL host and, if it's an IP literal, the address.
+func (p *Policy) CheckURL(u *url.URL) error {
+	host := u.Hostname()
+	if err := p.CheckHost(host); err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return p.CheckIP(ip)
+	}
+	return nil
+}
+
+// ParseURL parses the request URL and validates its scheme and host syntax.
+func ParseURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+	if u.User != nil {
+		return nil, fmt.Errorf("URL must not contain user info")
+	}
+	host := u.Hostname()
+	if host == "" {
+		return nil, fmt.Errorf("URL host is empty")
+	}
+	if net.ParseIP(host) == nil && (len(host) > 253 || !hostnameRe.MatchString(host)) {
+		return nil, fmt.Errorf("invalid URL host %q", host)
+	}
+	return u, nil
+}
+
+// ParseProxyURL parses the proxy URL and validates its scheme and host syntax.
+// Credentials are passed separately, so the URL must not contain user info.
+func ParseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5" {
+		return nil, fmt.Errorf("unsupported proxy scheme %q", u.Scheme)
+	}
+	if u.User != nil {
+		return nil, fmt.Errorf("proxy URL must not contain user info")
+	}
+	host := u.Hostname()
+	if host == "" {
+		return nil, fmt.Errorf("proxy host is empty")
+	}
+	if net.ParseIP(host) == nil && (len(host) > 253 || !hostnameRe.MatchString(host)) {
+		return nil, fmt.Errorf("invalid proxy host %q", host)
+	}
+	return u, nil
+}
+
+// MatchHost matches the host against an exact or a wildcard pattern.
+func MatchHost(pattern, host string) bool {
+	if suffix := strings.TrimPrefix(pattern, "*"); suffix != pattern {
+		return strings.HasSuffix(host, suffix)
+	}
+	return pattern == host
+}
+
+// normalizeHosts lowercases host patterns and drops trailing dots.
+func normalizeHosts(hosts []string) []string {
+	normalized := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		if host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), "."); host != "" {
+			normalized = append(normalized, host)
+		}
+	}
+	return normalized
+}
+
+// containsIP reports whether any of the networks contains the IP.
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCIDRs parses networks in CIDR notation.
+// Single addresses are treated as networks of one address.
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if ip := net.ParseIP(cidr); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// mustParseCIDRs parses networks in CIDR notation.
+// Panics in case of error.
+func mustParseCIDRs(cidrs []string) []*net.IPNet {
+	networks, err := parseCIDRs(cidrs)
+	if err != nil {
+		panic(err)
+	}
+	return networks
+}
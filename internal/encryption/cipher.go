@@ -0,0 +1,80 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// KeySize is the size of AES-256 keys.
+const KeySize = 32
+
+// Cipher encrypts data with AES-GCM.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// ParseKey decodes a base64-encoded key.
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode key: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// NewCipher creates a new cipher with the key.
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// MustCipher creates a new cipher from config.
+// Panics in case of error.
+func MustCipher(cfg Config) *Cipher {
+	key, err := ParseKey(cfg.MasterKey)
+	if err != nil {
+		panic(err)
+	}
+	c, err := NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Encrypt encrypts the plaintext bound to the additional data.
+// The random nonce is prepended to the ciphertext.
+func (c *Cipher) Encrypt(plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Decrypt decrypts the ciphertext produced by Encrypt with the same additional data.
+func (c *Cipher) Decrypt(ciphertext, additionalData []byte) ([]byte, error) {
+	nonceSize := c.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("ciphertext is too short")
+	}
+	return c.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], additionalData)
+}
@@ -0,0 +1,32 @@
+package encryption
+
+import (
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config for encryption.
+type Config struct {
+	// Base64-encoded 32-byte AES key of client secrets.
+	MasterKey string `envconfig:"SECRETS_MASTER_KEY" required:"true"`
+	// ID of the task data key used to encrypt new data.
+	TaskKeyID string `envconfig:"TASK_ENCRYPTION_KEY_ID" required:"true"`
+	// Task data keys as "id:base64key" pairs separated by commas.
+	TaskKeys map[string]string `envconfig:"TASK_ENCRYPTION_KEYS"`
+	// File with task data keys as "id:base64key" lines.
+	TaskKeysFile string `envconfig:"TASK_ENCRYPTION_KEYS_FILE"`
+}
+
+// LoadConfig loads envs.
+func LoadConfig() (Config, error) {
+	c := Config{}
+	return c, envconfig.Process("", &c)
+}
+
+// MustConfig loads envs.
+// Panics in case of error.
+func MustConfig(c Config, err error) Config {
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
@@ -0,0 +1,114 @@
+package encryption
+
+import (
+	"bufio"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Keyring holds master keys by ID and wraps data keys with them.
+// New data keys are always wrapped with the primary key,
+// older keys are kept to unwrap data written before rotation.
+type Keyring struct {
+	primaryID string
+	keys      map[string]*Cipher
+}
+
+// NewKeyring creates a new keyring from base64-encoded keys.
+func NewKeyring(primaryID string, keys map[string]string) (*Keyring, error) {
+	k := &Keyring{
+		primaryID: primaryID,
+		keys:      make(map[string]*Cipher, len(keys)),
+	}
+	for id, encoded := range keys {
+		key, err := ParseKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %w", id, err)
+		}
+		if k.keys[id], err = NewCipher(key); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := k.keys[primaryID]; !ok {
+		return nil, fmt.Errorf("primary key %s not found", primaryID)
+	}
+	return k, nil
+}
+
+// MustKeyring creates a new keyring of task data from config.
+// Keys from the file are merged with the keys from envs.
+// Panics in case of error.
+func MustKeyring(cfg Config) *Keyring {
+	keys := make(map[string]string, len(cfg.TaskKeys))
+	for id, key := range cfg.TaskKeys {
+		keys[id] = key
+	}
+	if cfg.TaskKeysFile != "" {
+		f, err := os.Open(cfg.TaskKeysFile)
+		if err != nil {
+			panic(err)
+		}
+		defer f.Close()
+		if err = readKeys(f, keys); err != nil {
+			panic(err)
+		}
+	}
+
+	k, err := NewKeyring(cfg.TaskKeyID, keys)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// readKeys reads "id:base64key" lines, skipping empty lines and comments.
+func readKeys(r io.Reader, keys map[string]string) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		id, key, ok := strings.Cut(line, ":")
+		if !ok {
+			return fmt.Errorf("invalid key line %q", line)
+		}
+		keys[strings.TrimSpace(id)] = strings.TrimSpace(key)
+	}
+	return scanner.Err()
+}
+
+// PrimaryID returns the ID of the primary key.
+func (k *Keyring) PrimaryID() string {
+	return k.primaryID
+}
+
+// GenerateDataKey returns a new random data key.
+func GenerateDataKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// WrapKey encrypts the data key with the primary key.
+func (k *Keyring) WrapKey(dataKey, additionalData []byte) (keyID string, wrapped []byte, _ error) {
+	wrapped, err := k.keys[k.primaryID].Encrypt(dataKey, additionalData)
+	if err != nil {
+		return "", nil, err
+	}
+	return k.primaryID, wrapped, nil
+}
+
+// UnwrapKey decrypts the data key wrapped with the key of the ID.
+func (k *Keyring) UnwrapKey(keyID string, wrapped, additionalData []byte) ([]byte, error) {
+	c, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found", keyID)
+	}
+	return c.Decrypt(wrapped, additionalData)
+}
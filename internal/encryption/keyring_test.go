@@ -0,0 +1,53 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func Test_Keyring_rotation(t *testing.T) {
+	oldKey, err := GenerateDataKey()
+	require.NoError(t, err)
+	newKey, err := GenerateDataKey()
+	require.NoError(t, err)
+
+	old, err := NewKeyring("v1", map[string]string{"v1": base64.StdEncoding.EncodeToString(oldKey)})
+	require.NoError(t, err)
+
+	dataKey, err := GenerateDataKey()
+	require.NoError(t, err)
+	keyID, wrapped, err := old.WrapKey(dataKey, []byte("tasks/1"))
+	require.NoError(t, err)
+	require.Equal(t, "v1", keyID)
+
+	rotated, err := NewKeyring("v2", map[string]string{
+		"v1": base64.StdEncoding.EncodeToString(oldKey),
+		"v2": base64.StdEncoding.EncodeToString(newKey),
+	})
+	require.NoError(t, err)
+
+	unwrapped, err := rotated.UnwrapKey(keyID, wrapped, []byte("tasks/1"))
+	require.NoError(t, err)
+	require.Equal(t, dataKey, unwrapped)
+
+	_, err = rotated.UnwrapKey(keyID, wrapped, []byte("tasks/2"))
+	require.Error(t, err, "additional data must match")
+
+	keyID, _, err = rotated.WrapKey(dataKey, []byte("tasks/1"))
+	require.NoError(t, err)
+	require.Equal(t, "v2", keyID)
+
+	_, err = NewKeyring("v3", map[string]string{"v1": base64.StdEncoding.EncodeToString(oldKey)})
+	require.Error(t, err, "primary key must exist")
+}
+
+func Test_readKeys(t *testing.T) {
+	keys := map[string]string{"v1": "env"}
+	err := readKeys(strings.NewReader("# keys\nv1: file1\n\nv2:file2\n"), keys)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"v1": "file1", "v2": "file2"}, keys)
+
+	require.Error(t, readKeys(strings.NewReader("invalid"), keys))
+}
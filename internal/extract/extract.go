@@ -0,0 +1,93 @@
+// Package extract extracts values from task responses.
+package extract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-faster/jx"
+	"net/http"
+	"requester/internal/jsonpath"
+	"strings"
+)
+
+// null is the value of expressions addressing nothing.
+var null = jx.Raw("null")
+
+// isPath reports whether the expression is a JSONPath of the body, otherwise it's a header name.
+func isPath(expr string) bool {
+	return strings.HasPrefix(expr, "$")
+}
+
+// Validate checks that names are set and JSONPaths are valid.
+func Validate(spec map[string]string) error {
+	for name, expr := range spec {
+		if name == "" {
+			return errors.New("name is required")
+		}
+		if expr == "" {
+			return fmt.Errorf("expression of %s is required", name)
+		}
+		if isPath(expr) {
+			if _, err := jsonpath.Parse(expr); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ReadsBody reports whether any of the expressions needs the response body.
+func ReadsBody(spec map[string]string) bool {
+	for _, expr := range spec {
+		if isPath(expr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Extract evaluates the expressions on the response.
+// JSONPaths are evaluated on the JSON body, other expressions are header names.
+// Values which aren't found, including all JSONPaths of a non-JSON body, are null.
+func Extract(spec map[string]string, header http.Header, body []byte) (map[string]jx.Raw, error) {
+	var doc interface{}
+	var docErr error
+	if ReadsBody(spec) {
+		doc, docErr = jsonpath.Decode(body)
+	}
+
+	values := make(map[string]jx.Raw, len(spec))
+	for name, expr := range spec {
+		values[name] = null
+		if !isPath(expr) {
+			if value, ok := header[http.CanonicalHeaderKey(expr)]; ok && len(value) > 0 {
+				encoded, err := json.Marshal(value[0])
+				if err != nil {
+					return nil, err
+				}
+				values[name] = encoded
+			}
+			continue
+		}
+
+		if docErr != nil {
+			continue
+		}
+		path, err := jsonpath.Parse(expr)
+		if err != nil {
+			return nil, err
+		}
+		value, err := path.Get(doc)
+		if err != nil {
+			if errors.Is(err, jsonpath.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if values[name], err = json.Marshal(value); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,44 @@
+package extract
+
+import (
+	"github.com/go-faster/jx"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func Test_Validate(t *testing.T) {
+	require.NoError(t, Validate(map[string]string{"id": "$.data.id", "request_id": "X-Request-Id"}))
+	require.Error(t, Validate(map[string]string{"": "$.id"}))
+	require.Error(t, Validate(map[string]string{"id": ""}))
+	require.Error(t, Validate(map[string]string{"id": "$.data[x]"}))
+}
+
+func Test_Extract(t *testing.T) {
+	spec := map[string]string{
+		"id":         "$.data.id",
+		"items":      "$.data.items",
+		"missing":    "$.data.missing",
+		"request_id": "x-request-id",
+		"no_header":  "X-Missing",
+	}
+	require.True(t, ReadsBody(spec))
+	header := http.Header{"X-Request-Id": {"req-1"}}
+
+	values, err := Extract(spec, header, []byte(`{"data": {"id": 7, "items": ["a", "b"]}}`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]jx.Raw{
+		"id":         jx.Raw(`7`),
+		"items":      jx.Raw(`["a","b"]`),
+		"missing":    jx.Raw(`null`),
+		"request_id": jx.Raw(`"req-1"`),
+		"no_header":  jx.Raw(`null`),
+	}, values)
+
+	values, err = Extract(spec, header, []byte(`not json`))
+	require.NoError(t, err)
+	require.Equal(t, jx.Raw(`null`), values["id"])
+	require.Equal(t, jx.Raw(`"req-1"`), values["request_id"], "headers must be extracted from any body")
+
+	require.False(t, ReadsBody(map[string]string{"request_id": "X-Request-Id"}))
+}
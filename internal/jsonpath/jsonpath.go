@@ -0,0 +1,127 @@
+// Package jsonpath implements the subset of JSONPath addressing a single value:
+// the root `$`, member access `.name` or `['name']` and array indexes `[0]`, negative from the end.
+package jsonpath
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound is returned when the path doesn't address a value of the document.
+var ErrNotFound = errors.New("value not found")
+
+// segment is a member name or an array index.
+type segment struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+// Path is a parsed JSONPath.
+type Path struct {
+	raw      string
+	segments []segment
+}
+
+// String returns the path as it was parsed.
+func (p *Path) String() string {
+	return p.raw
+}
+
+// Parse parses the path.
+func Parse(raw string) (*Path, error) {
+	if !strings.HasPrefix(raw, "$") {
+		return nil, fmt.Errorf("path %q must start with $", raw)
+	}
+
+	p := &Path{raw: raw}
+	rest := raw[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			name := rest[1 : end+1]
+			if name == "" {
+				return nil, fmt.Errorf("path %q has an empty member name", raw)
+			}
+			p.segments = append(p.segments, segment{name: name})
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("path %q has an unclosed bracket", raw)
+			}
+			inner := rest[1:end]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				p.segments = append(p.segments, segment{name: inner[1 : len(inner)-1]})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("path %q has an invalid index %q", raw, inner)
+				}
+				p.segments = append(p.segments, segment{index: index, isIndex: true})
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("path %q has an unexpected character %q", raw, rest[0])
+		}
+	}
+	return p, nil
+}
+
+// MustParse parses the path.
+// Panics in case of error.
+func MustParse(raw string) *Path {
+	p, err := Parse(raw)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Get returns the value addressed by the path in the decoded JSON document.
+// Returns ErrNotFound if there is no such value.
+func (p *Path) Get(doc interface{}) (interface{}, error) {
+	value := doc
+	for _, s := range p.segments {
+		if s.isIndex {
+			array, ok := value.([]interface{})
+			if !ok {
+				return nil, ErrNotFound
+			}
+			index := s.index
+			if index < 0 {
+				index += len(array)
+			}
+			if index < 0 || index >= len(array) {
+				return nil, ErrNotFound
+			}
+			value = array[index]
+			continue
+		}
+
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, ErrNotFound
+		}
+		if value, ok = object[s.name]; !ok {
+			return nil, ErrNotFound
+		}
+	}
+	return value, nil
+}
+
+// Decode decodes the JSON document to be queried with Get.
+func Decode(data []byte) (interface{}, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -0,0 +1,42 @@
+package jsonpath
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_Parse(t *testing.T) {
+	for _, raw := range []string{"", "data", "$.", "$..a", "$[", "$[a]", "$a"} {
+		_, err := Parse(raw)
+		require.Error(t, err, raw)
+	}
+}
+
+func Test_Path_Get(t *testing.T) {
+	doc, err := Decode([]byte(`{"data": {"items": [{"id": 1}, {"id": 2}], "a.b": "dotted"}, "ok": true}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		want interface{}
+	}{
+		{"$", doc},
+		{"$.ok", true},
+		{"$.data.items[0].id", float64(1)},
+		{"$.data.items[-1].id", float64(2)},
+		{"$['data']['a.b']", "dotted"},
+		{`$.data["items"][1]`, map[string]interface{}{"id": float64(2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := MustParse(tt.path).Get(doc)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, path := range []string{"$.missing", "$.data.items[2]", "$.data.items[-3]", "$.ok.value", "$.data[0]"} {
+		_, err := MustParse(path).Get(doc)
+		require.ErrorIs(t, err, ErrNotFound, path)
+	}
+}
@@ -0,0 +1,15 @@
+package models
+
+// DestinationRules are per-client destination lists added to the default policy.
+type DestinationRules struct {
+	// Client ID
+	ClientID string `json:"client_id"`
+	// Allowed host patterns
+	AllowHosts []string `json:"allow_hosts"`
+	// Denied host patterns
+	DenyHosts []string `json:"deny_hosts"`
+	// Allowed networks, including private ones
+	AllowCIDRs []string `json:"allow_cidrs"`
+	// Denied networks
+	DenyCIDRs []string `json:"deny_cidrs"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TaskGroup is a group of tasks created by a single fan-out request.
+type TaskGroup struct {
+	// ID
+	ID uuid.UUID `json:"id"`
+	// ID of the client that created the group
+	ClientID string `json:"client_id"`
+	// IDs of the group tasks, set only on creation
+	TaskIDs []uuid.UUID `json:"task_ids,omitempty"`
+	// Number of the group tasks by status
+	Counts map[TaskStatus]int `json:"counts"`
+	// Creation time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Total returns the number of the group tasks.
+func (g *TaskGroup) Total() int {
+	total := 0
+	for _, count := range g.Counts {
+		total += count
+	}
+	return total
+}
+
+// Complete reports whether none of the group tasks is to be sent or in process.
+// Errored tasks may still be retried, so complete groups can become incomplete again.
+func (g *TaskGroup) Complete() bool {
+	return g.Counts[TaskStatusNew] == 0 && g.Counts[TaskStatusWaiting] == 0 && g.Counts[TaskStatusInProcess] == 0
+}
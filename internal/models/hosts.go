@@ -0,0 +1,14 @@
+package models
+
+// HostLimits are per-host overrides of the default outbound limits.
+// Nil fields fall back to the defaults.
+type HostLimits struct {
+	// Target host
+	Host string `json:"host"`
+	// Max concurrent requests to the host
+	MaxConcurrent *int `json:"max_concurrent"`
+	// Max requests per second to the host
+	RequestsPerSecond *float64 `json:"requests_per_second"`
+	// Max requests sent at once after idle period
+	Burst *int `json:"burst"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// RateBucket is a kind of client rate counter.
+type RateBucket string
+
+const (
+	RateBucketSecond RateBucket = "second"
+	RateBucketDay    RateBucket = "day"
+)
+
+// Window returns the duration of the bucket window.
+func (b RateBucket) Window() time.Duration {
+	if b == RateBucketDay {
+		return 24 * time.Hour
+	}
+	return time.Second
+}
+
+// ClientLimits are per-client overrides of the default limits.
+// Nil fields fall back to the defaults.
+type ClientLimits struct {
+	// Client ID
+	ClientID string `json:"client_id"`
+	// Max requests per second on task creation
+	RequestsPerSecond *int `json:"requests_per_second"`
+	// Max tasks in new, waiting or in_process status
+	MaxOutstandingTasks *int `json:"max_outstanding_tasks"`
+	// Max tasks created per day
+	DailyTaskQuota *int `json:"daily_task_quota"`
+}
+
+// RateCounter is a client counter within a time window.
+type RateCounter struct {
+	// Client ID
+	ClientID string `json:"client_id"`
+	// Counter kind
+	Bucket RateBucket `json:"bucket"`
+	// Start of the window
+	WindowStart time.Time `json:"window_start"`
+	// Number of hits within the window
+	Count int `json:"count"`
+}
+
+// ResetsIn returns the duration until the counter window ends.
+func (c *RateCounter) ResetsIn(now time.Time) time.Duration {
+	return c.WindowStart.Add(c.Bucket.Window()).Sub(now)
+}
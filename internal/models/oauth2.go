@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// OAuth2Profile is a client credentials grant configuration referenced from tasks.
+type OAuth2Profile struct {
+	// ID of the client owning the profile
+	ClientID string `json:"client_id"`
+	// Profile name
+	Name string `json:"name"`
+	// Token endpoint URL
+	TokenURL string `json:"token_url"`
+	// OAuth2 client ID
+	OAuth2ClientID string `json:"oauth2_client_id"`
+	// Name of the client secret holding the OAuth2 client secret
+	ClientSecretName string `json:"client_secret_name"`
+	// Requested scopes
+	Scopes []string `json:"scopes"`
+	// Creation time
+	CreatedAt time.Time `json:"created_at"`
+	// Last update time
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// Proxy is an outbound proxy, HTTP CONNECT or SOCKS5.
+type Proxy struct {
+	// Proxy URL without credentials
+	URL string `json:"url"`
+	// Proxy username
+	Username string `json:"username,omitempty"`
+	// Proxy password, may contain secret references in tasks
+	Password string `json:"password,omitempty"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Secret is a client credential referenced from tasks.
+type Secret struct {
+	// ID of the client owning the secret
+	ClientID string `json:"client_id"`
+	// Secret name
+	Name string `json:"name"`
+	// Decrypted secret value
+	Value string `json:"-"`
+	// Creation time
+	CreatedAt time.Time `json:"created_at"`
+	// Last update time
+	UpdatedAt time.Time `json:"updated_at"`
+}
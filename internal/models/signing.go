@@ -0,0 +1,56 @@
+package models
+
+// SigningType is an algorithm of request signing.
+type SigningType string
+
+const (
+	SigningTypeHMACSHA256 SigningType = "hmac_sha256"
+	SigningTypeAWSSigV4   SigningType = "aws_sigv4"
+)
+
+// HMACPart is a canonical part of the request signed with HMAC.
+type HMACPart string
+
+const (
+	HMACPartMethod    HMACPart = "method"
+	HMACPartPath      HMACPart = "path"
+	HMACPartQuery     HMACPart = "query"
+	HMACPartTimestamp HMACPart = "timestamp"
+	HMACPartBody      HMACPart = "body"
+)
+
+// Signing of the task request.
+type Signing struct {
+	// Signing algorithm
+	Type SigningType `json:"type"`
+	// HMAC-SHA256 signing options
+	HMAC *HMACSigning `json:"hmac,omitempty"`
+	// AWS Signature V4 signing options
+	AWSSigV4 *AWSSigV4Signing `json:"aws_sigv4,omitempty"`
+}
+
+// HMACSigning is options of HMAC-SHA256 signing.
+type HMACSigning struct {
+	// HMAC key, may contain secret references
+	Key string `json:"key"`
+	// Canonical parts of the request in signing order
+	Parts []HMACPart `json:"parts,omitempty"`
+	// Signature header
+	Header string `json:"header,omitempty"`
+	// Timestamp header
+	TimestampHeader string `json:"timestamp_header,omitempty"`
+}
+
+// AWSSigV4Signing is options of AWS Signature V4 signing.
+type AWSSigV4Signing struct {
+	// AWS region
+	Region string `json:"region"`
+	// AWS service name
+	Service string `json:"service"`
+	// AWS access key ID, may contain secret references
+	AccessKeyID string `json:"access_key_id"`
+	// AWS secret access key, may contain secret references
+	SecretAccessKey string `json:"secret_access_key"`
+	// AWS session token, may contain secret references
+	SessionToken string `json:"session_token,omitempty"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "github.com/go-faster/jx"
+
+// Success is the rule set the task response must satisfy to be done.
+// All assertions must pass, otherwise the task fails.
+type Success struct {
+	// Accepted status code ranges, any status code is accepted if empty
+	StatusCodes []StatusCodeRange `json:"status_codes,omitempty"`
+	// Required response headers
+	Headers []HeaderAssertion `json:"headers,omitempty"`
+	// Substring the response body must contain
+	BodyContains string `json:"body_contains,omitempty"`
+	// Regular expression the response body must match
+	BodyRegex string `json:"body_regex,omitempty"`
+	// Values of the JSON response body
+	JSON []JSONAssertion `json:"json,omitempty"`
+	// Whether failed tasks are retried
+	Retry bool `json:"retry,omitempty"`
+}
+
+// StatusCodeRange is an inclusive range of status codes.
+type StatusCodeRange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// HeaderAssertion requires the response header.
+type HeaderAssertion struct {
+	// Header name
+	Name string `json:"name"`
+	// Exact header value, any value is accepted if empty
+	Value string `json:"value,omitempty"`
+}
+
+// JSONAssertion requires the value addressed by the JSONPath to equal the expected one.
+type JSONAssertion struct {
+	// JSONPath of the value
+	Path string `json:"path"`
+	// Expected JSON value
+	Equals jx.Raw `json:"equals"`
+}
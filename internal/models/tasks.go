@@ -3,6 +3,7 @@ package models
 import (
 	"github.com/go-faster/jx"
 	"github.com/google/uuid"
+	"time"
 )
 
 type TaskStatus string
@@ -12,6 +13,9 @@ const (
 	TaskStatusDone      TaskStatus = "done"
 	TaskStatusError     TaskStatus = "error"
 	TaskStatusInProcess TaskStatus = "in_process"
+	TaskStatusFailed    TaskStatus = "failed"
+	TaskStatusWaiting   TaskStatus = "waiting"
+	TaskStatusSkipped   TaskStatus = "skipped"
 )
 
 // Pointer returns *TaskStatus.
@@ -19,12 +23,74 @@ func (ts TaskStatus) Pointer() *TaskStatus {
 	return &ts
 }
 
+// Terminal reports whether the status is an outcome of the task.
+// Errored and failed tasks may still be retried.
+func (ts TaskStatus) Terminal() bool {
+	switch ts {
+	case TaskStatusDone, TaskStatusError, TaskStatusFailed, TaskStatusSkipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// taskTransitions are the statuses each status can change to.
+// A task is claimed by a worker moving it to in_process, it can't be claimed again until its outcome is set.
+var taskTransitions = map[TaskStatus][]TaskStatus{
+	TaskStatusNew:       {TaskStatusInProcess, TaskStatusSkipped, TaskStatusError},
+	TaskStatusWaiting:   {TaskStatusNew, TaskStatusSkipped},
+	TaskStatusInProcess: {TaskStatusDone, TaskStatusFailed, TaskStatusError},
+	TaskStatusError:     {TaskStatusInProcess, TaskStatusSkipped, TaskStatusError},
+	TaskStatusFailed:    {TaskStatusInProcess, TaskStatusSkipped},
+}
+
+// CanTransitionTo reports whether the status can change to the given one.
+func (ts TaskStatus) CanTransitionTo(to TaskStatus) bool {
+	for _, status := range taskTransitions[ts] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Predecessors returns the statuses which can change to the status.
+func (ts TaskStatus) Predecessors() []TaskStatus {
+	var predecessors []TaskStatus
+	for from := range taskTransitions {
+		if from.CanTransitionTo(ts) {
+			predecessors = append(predecessors, from)
+		}
+	}
+	return predecessors
+}
+
+// TaskPriority selects the queue of the task.
+type TaskPriority string
+
+const (
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityNormal TaskPriority = "normal"
+	TaskPriorityLow    TaskPriority = "low"
+)
+
+// TaskPriorities are the task priorities from the highest.
+var TaskPriorities = []TaskPriority{TaskPriorityHigh, TaskPriorityNormal, TaskPriorityLow}
+
 // Task to request a 3rd-party service.
 type Task struct {
 	// ID
 	ID uuid.UUID `json:"id"`
 	// Processing status
 	Status TaskStatus `json:"status"`
+	// ID of the client that created the task
+	ClientID string `json:"client_id"`
+	// Priority of the task queue
+	Priority TaskPriority `json:"priority"`
+	// Name of the task queue, the default queue if empty
+	Queue string `json:"queue,omitempty"`
+	// Key of the tasks delivered in order by FIFO queues
+	OrderingKey string `json:"ordering_key,omitempty"`
 	// Request method
 	Method string `json:"method"`
 	// Request URL
@@ -33,16 +99,46 @@ type Task struct {
 	Headers map[string]string `json:"headers"`
 	// Request body
 	Body map[string]jx.Raw `json:"body"`
+	// Request signing
+	Signing *Signing `json:"signing,omitempty"`
+	// Name of the client OAuth2 profile
+	OAuth2Profile string `json:"oauth2_profile,omitempty"`
+	// Name of the client TLS profile
+	TLSProfile string `json:"tls_profile,omitempty"`
+	// Proxy to send the request through
+	Proxy *Proxy `json:"proxy,omitempty"`
+	// Success criteria of the response
+	Success *Success `json:"success,omitempty"`
+	// Values to extract from the response by name, JSONPaths of the body or header names
+	Extract map[string]string `json:"extract,omitempty"`
+	// IDs of the tasks which must be done before the task is sent
+	DependsOn []uuid.UUID `json:"depends_on,omitempty"`
+	// Condition on a parent value which must hold for the task to be sent
+	Condition *Condition `json:"condition,omitempty"`
+	// Variables referenced in URL, headers and body, rendered when the request is sent
+	Variables map[string]string `json:"variables,omitempty"`
+	// ID of the worker holding the processing lease of the task in process
+	LeaseOwner *string `json:"lease_owner,omitempty"`
+	// Expiry of the processing lease, after which another worker can claim the task
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
 }
 
 // ResponseData to store response data.
 type ResponseData struct {
 	// Response status code
 	ResponseStatusCode *int `json:"http_status_code"`
-	// Response headers
-	ResponseHeaders map[string][]string `json:"headers"`
+	// Response headers, keyed apart from the request headers of TaskWithResponseData
+	ResponseHeaders map[string][]string `json:"response_headers"`
 	// Response content length
 	ResponseContentLength *int64 `json:"length"`
+	// Proxy the request was sent through, without credentials
+	UsedProxy *string `json:"used_proxy"`
+	// Timing breakdown of the last request attempt
+	Timings *Timings `json:"timings"`
+	// Assertion of the success criteria the response failed
+	FailedAssertion *string `json:"failed_assertion"`
+	// Values extracted from the response by name
+	Extracted map[string]jx.Raw `json:"extracted"`
 }
 
 // TaskWithResponseData is a task with response data.
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"github.com/go-faster/jx"
+	"time"
+)
+
+// ParameterType is the JSON type of a template parameter value.
+type ParameterType string
+
+const (
+	ParameterTypeString  ParameterType = "string"
+	ParameterTypeInteger ParameterType = "integer"
+	ParameterTypeNumber  ParameterType = "number"
+	ParameterTypeBoolean ParameterType = "boolean"
+)
+
+// TemplateParameter is the schema of a template parameter.
+type TemplateParameter struct {
+	// JSON type of the value
+	Type ParameterType `json:"type"`
+	// Whether the parameter must be set
+	Required bool `json:"required,omitempty"`
+	// JSON value of the parameter if not set
+	Default jx.Raw `json:"default,omitempty"`
+	// Regular expression string values must match
+	Pattern string `json:"pattern,omitempty"`
+	// Parameter description
+	Description string `json:"description,omitempty"`
+}
+
+// RequestTemplate is a named version of a client request tasks are created from.
+// The URL, headers and body reference parameters as variables like {{var:name}}.
+type RequestTemplate struct {
+	// ID of the client owning the template
+	ClientID string `json:"client_id"`
+	// Template name
+	Name string `json:"name"`
+	// Template version, starting from 1
+	Version int `json:"version"`
+	// Request method
+	Method string `json:"method"`
+	// Request URL
+	URL string `json:"url"`
+	// Request headers
+	Headers map[string]string `json:"headers,omitempty"`
+	// Request body
+	Body map[string]jx.Raw `json:"body,omitempty"`
+	// Parameter schemas by name
+	Parameters map[string]TemplateParameter `json:"parameters"`
+	// Creation time of the version
+	CreatedAt time.Time `json:"created_at"`
+}
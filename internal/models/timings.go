@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Timings is the timing breakdown of a request attempt.
+type Timings struct {
+	// DNS lookup duration
+	DNS time.Duration `json:"dns"`
+	// TCP connect duration
+	Connect time.Duration `json:"connect"`
+	// TLS handshake duration
+	TLSHandshake time.Duration `json:"tls_handshake"`
+	// Time from the request start to the first response byte
+	TimeToFirstByte time.Duration `json:"time_to_first_byte"`
+	// Total duration from sending the request until its body is read
+	Total time.Duration `json:"total"`
+	// Remote IP address of the connection
+	RemoteIP string `json:"remote_ip,omitempty"`
+	// Whether the connection was reused
+	ConnectionReused bool `json:"connection_reused"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// TLSProfile is a client TLS configuration referenced from tasks.
+type TLSProfile struct {
+	// ID of the client owning the profile
+	ClientID string `json:"client_id"`
+	// Profile name
+	Name string `json:"name"`
+	// PEM-encoded client certificate chain
+	ClientCert string `json:"client_cert"`
+	// Decrypted PEM-encoded client private key
+	ClientKey string `json:"-"`
+	// PEM-encoded CA certificates to verify the server
+	CABundle string `json:"ca_bundle"`
+	// Minimal TLS version, e.g. 1.2
+	MinVersion string `json:"min_version"`
+	// Server name for SNI and certificate verification
+	ServerName string `json:"server_name"`
+	// Proxy to send requests through, with decrypted password
+	Proxy *Proxy `json:"proxy,omitempty"`
+	// Creation time
+	CreatedAt time.Time `json:"created_at"`
+	// Last update time
+	UpdatedAt time.Time `json:"updated_at"`
+}
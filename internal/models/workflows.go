@@ -0,0 +1,69 @@
+package models
+
+import (
+	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	"time"
+)
+
+type WorkflowStatus string
+
+const (
+	WorkflowStatusRunning WorkflowStatus = "running"
+	WorkflowStatusDone    WorkflowStatus = "done"
+	WorkflowStatusFailed  WorkflowStatus = "failed"
+)
+
+// Workflow is a DAG of request steps run as tasks.
+type Workflow struct {
+	// ID
+	ID uuid.UUID `json:"id"`
+	// ID of the client that created the workflow
+	ClientID string `json:"client_id"`
+	// Status derived from the step statuses
+	Status WorkflowStatus `json:"status"`
+	// Steps in the order they were created, parents before dependents
+	Steps []WorkflowStep `json:"steps"`
+	// Creation time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkflowStep is a step of a workflow.
+type WorkflowStep struct {
+	// Step name, unique within the workflow
+	Name string `json:"name"`
+	// ID of the task running the step
+	TaskID uuid.UUID `json:"task_id"`
+	// Status of the task running the step
+	Status TaskStatus `json:"status"`
+}
+
+// NewWorkflowStatus returns the status of a workflow with the steps.
+// A workflow runs while any step is to be sent or in process, fails if any step has failed or errored,
+// and is done otherwise, steps skipped by their conditions included.
+// Errored steps may still be retried, so failed workflows can become running again.
+func NewWorkflowStatus(steps []WorkflowStep) WorkflowStatus {
+	status := WorkflowStatusDone
+	for _, step := range steps {
+		switch step.Status {
+		case TaskStatusNew, TaskStatusWaiting, TaskStatusInProcess:
+			return WorkflowStatusRunning
+		case TaskStatusFailed, TaskStatusError:
+			status = WorkflowStatusFailed
+		}
+	}
+	return status
+}
+
+// Condition is a condition on a value extracted from a parent task, which must hold for the task to be sent.
+// Tasks whose condition doesn't hold are skipped along with their dependents.
+type Condition struct {
+	// ID of the parent task
+	ParentID uuid.UUID `json:"parent_id"`
+	// Name of the value extracted from the parent
+	Value string `json:"value"`
+	// JSON value the extracted value must equal, if set
+	Equals jx.Raw `json:"equals,omitempty"`
+	// JSON value the extracted value must not equal, if set
+	NotEquals jx.Raw `json:"not_equals,omitempty"`
+}
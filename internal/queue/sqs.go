@@ -2,8 +2,9 @@ package queue
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -11,9 +12,21 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// maxDelay is the max delay of a message supported by SQS.
+const maxDelay = 15 * time.Minute
+
+// fifoSuffix is the name suffix of FIFO queues.
+const fifoSuffix = ".fifo"
+
+// IsFIFO reports whether the queue of the URL is a FIFO queue.
+func IsFIFO(queueURL *string) bool {
+	return queueURL != nil && strings.HasSuffix(*queueURL, fifoSuffix)
+}
+
 // Service represents SQS service.
 type Service struct {
 	client *sqs.SQS
@@ -46,9 +59,28 @@ func (svc *Service) VisibilityTimeout() time.Duration {
 	return svc.cfg.VisibilityTimeout
 }
 
+// ReceivesExceededError is returned when the message has been received more times than allowed
+// and has been deleted. The message is decoded, so its task can be abandoned.
+type ReceivesExceededError struct {
+	Count int
+}
+
+func (e *ReceivesExceededError) Error() string {
+	return fmt.Sprintf("message has been received %d times. Deleted", e.Count)
+}
+
 // DecodeMessage decodes message.
 // If message can't be decoded, it will be deleted.
+// Messages received more than the max attempts are deleted too, *ReceivesExceededError is returned then.
+// Deferrals of FIFO queue messages count as receives, so their tasks must be abandoned.
 func (svc *Service) DecodeMessage(ctx context.Context, queueURL *string, message *sqs.Message, output interface{}) error {
+	if err := json.Unmarshal([]byte(*message.Body), output); err != nil {
+		if delErr := svc.DeleteMessage(ctx, queueURL, message); delErr != nil {
+			return delErr
+		}
+		return fmt.Errorf("unable to decode the message: %w", err)
+	}
+
 	receiveCount, ok := message.Attributes[sqs.MessageSystemAttributeNameApproximateReceiveCount]
 	if ok && !svc.cfg.Debug {
 		cnt, _ := strconv.Atoi(*receiveCount)
@@ -57,21 +89,24 @@ func (svc *Service) DecodeMessage(ctx context.Context, queueURL *string, message
 			if err != nil {
 				return err
 			}
-			return errors.New(fmt.Sprintf("message has been received %d times. Deleted", cnt))
-		}
-	}
-
-	if err := json.Unmarshal([]byte(*message.Body), output); err != nil {
-		err = svc.DeleteMessage(ctx, queueURL, message)
-		if err != nil {
-			return err
+			return &ReceivesExceededError{Count: cnt}
 		}
-		return fmt.Errorf("unable to decode the message: %w", err)
 	}
 
 	return nil
 }
 
+// IsLastAttempt reports whether the message won't be received again unless it's deferred.
+// Messages are received any number of times in debug mode.
+func (svc *Service) IsLastAttempt(message *sqs.Message) bool {
+	receiveCount, ok := message.Attributes[sqs.MessageSystemAttributeNameApproximateReceiveCount]
+	if !ok || svc.cfg.Debug {
+		return false
+	}
+	cnt, _ := strconv.Atoi(*receiveCount)
+	return cnt >= svc.cfg.MaxMessageAttempts
+}
+
 // GetMessages returns messages from queue.
 func (svc *Service) GetMessages(ctx context.Context, input *sqs.ReceiveMessageInput) ([]*sqs.Message, error) {
 	msgResult, err := svc.client.ReceiveMessageWithContext(ctx, input)
@@ -101,31 +136,84 @@ func (svc *Service) GetQueueURL(ctx context.Context, queue string) (*string, err
 }
 
 // CreateQueue creates queue.
+// Queues with the .fifo suffix are created as FIFO queues.
 func (svc *Service) CreateQueue(ctx context.Context, queue string) (*string, error) {
-	outputCreateQueue, err := svc.client.CreateQueueWithContext(
-		ctx, &sqs.CreateQueueInput{
-			QueueName: aws.String(queue),
-		},
-	)
-
-	return outputCreateQueue.QueueUrl, err
+	input := &sqs.CreateQueueInput{
+		QueueName: aws.String(queue),
+	}
+	if strings.HasSuffix(queue, fifoSuffix) {
+		input.Attributes = map[string]*string{sqs.QueueAttributeNameFifoQueue: aws.String("true")}
+	}
+	outputCreateQueue, err := svc.client.CreateQueueWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return outputCreateQueue.QueueUrl, nil
 }
 
 // SendMessage sends message to queue.
-func (svc *Service) SendMessage(ctx context.Context, queue *string, message interface{}) error {
+// On FIFO queues messages with the same ordering key are delivered in order, as one message group.
+// Messages without the key get a group of their own. The deduplication ID is the hash of the message,
+// so the same message sent again within the deduplication interval is dropped.
+func (svc *Service) SendMessage(ctx context.Context, queue *string, message interface{}, orderingKey string) error {
 	messageBody, err := json.Marshal(message)
 	if err != nil {
 		return err
 	}
 
-	_, err = svc.client.SendMessageWithContext(ctx, &sqs.SendMessageInput{
+	input := &sqs.SendMessageInput{
 		MessageAttributes: make(map[string]*sqs.MessageAttributeValue),
 		MessageBody:       aws.String(string(messageBody)),
 		QueueUrl:          queue,
-	})
+	}
+	if IsFIFO(queue) {
+		hash := sha256.Sum256(messageBody)
+		input.MessageDeduplicationId = aws.String(hex.EncodeToString(hash[:]))
+		input.MessageGroupId = input.MessageDeduplicationId
+		if orderingKey != "" {
+			input.MessageGroupId = aws.String(orderingKey)
+		}
+	}
+	_, err = svc.client.SendMessageWithContext(ctx, input)
 	return err
 }
 
+// DeferMessage makes the message visible again after the delay.
+// The deferred message counts as received once more when it's received again after the delay,
+// so if it's about to run out of attempts, it's re-sent with the delay instead, which resets the receive count.
+// Visibility changes themselves don't count as receives.
+// Messages of FIFO queues are never re-sent, since that would reorder their group
+// and FIFO queues don't support per-message delays, so their tasks are abandoned once they run out of receives.
+func (svc *Service) DeferMessage(ctx context.Context, queue *string, message *sqs.Message, delay time.Duration) error {
+	receiveCount := 0
+	if cnt, ok := message.Attributes[sqs.MessageSystemAttributeNameApproximateReceiveCount]; ok {
+		receiveCount, _ = strconv.Atoi(*cnt)
+	}
+
+	if receiveCount < svc.cfg.MaxMessageAttempts || IsFIFO(queue) {
+		_, err := svc.client.ChangeMessageVisibilityWithContext(ctx, &sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          queue,
+			ReceiptHandle:     message.ReceiptHandle,
+			VisibilityTimeout: aws.Int64(int64(delay / time.Second)),
+		})
+		return err
+	}
+
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	_, err := svc.client.SendMessageWithContext(ctx, &sqs.SendMessageInput{
+		MessageAttributes: message.MessageAttributes,
+		MessageBody:       message.Body,
+		QueueUrl:          queue,
+		DelaySeconds:      aws.Int64(int64(delay / time.Second)),
+	})
+	if err != nil {
+		return err
+	}
+	return svc.DeleteMessage(ctx, queue, message)
+}
+
 // DeleteMessage deletes message from queue.
 func (svc *Service) DeleteMessage(ctx context.Context, queue *string, message *sqs.Message) error {
 	_, err := svc.client.DeleteMessageWithContext(ctx, &sqs.DeleteMessageInput{
@@ -0,0 +1,87 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"requester/internal/models"
+	"strings"
+	"sync"
+)
+
+// queueURLGetter is an interface for getting queue URLs by name.
+type queueURLGetter interface {
+	GetQueueURL(ctx context.Context, queue string) (*string, error)
+}
+
+// TaskQueues resolves the URLs of the task queues by name and priority.
+// URLs are cached once resolved. It's safe for concurrent use.
+type TaskQueues struct {
+	getter      queueURLGetter
+	defaultName string
+
+	mu   sync.Mutex
+	urls map[string]*string
+}
+
+// NewTaskQueues creates new TaskQueues, tasks without a queue name go to the default queue.
+func NewTaskQueues(getter queueURLGetter, defaultName string) (*TaskQueues, error) {
+	if getter == nil {
+		return nil, errors.New("must specify queueURLGetter")
+	}
+	if defaultName == "" {
+		return nil, errors.New("must specify default queue name")
+	}
+	return &TaskQueues{
+		getter:      getter,
+		defaultName: defaultName,
+		urls:        make(map[string]*string),
+	}, nil
+}
+
+// URL returns the URL of the queue of the name and priority.
+// The name is the default one if empty, the priority is normal if empty.
+// Creates the queue if it doesn't exist.
+func (q *TaskQueues) URL(ctx context.Context, name string, priority models.TaskPriority) (*string, error) {
+	if name == "" {
+		name = q.defaultName
+	}
+	if priority == "" {
+		priority = models.TaskPriorityNormal
+	}
+	queueName := TaskQueueName(name, priority)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if url, ok := q.urls[queueName]; ok {
+		return url, nil
+	}
+	url, err := q.getter.GetQueueURL(ctx, queueName)
+	if err != nil {
+		return nil, err
+	}
+	q.urls[queueName] = url
+	return url, nil
+}
+
+// Prepare resolves the URLs of the queues of the names and all priorities, so missing queues fail early.
+func (q *TaskQueues) Prepare(ctx context.Context, names ...string) error {
+	for _, name := range names {
+		for _, priority := range models.TaskPriorities {
+			if _, err := q.URL(ctx, name, priority); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// TaskQueueName returns the name of the task queue of the priority.
+// The normal priority queue is named as is, the others get the priority suffix: task-queue-high,
+// before the suffix of FIFO queues: task-queue-high.fifo.
+func TaskQueueName(name string, priority models.TaskPriority) string {
+	if priority == models.TaskPriorityNormal {
+		return name
+	}
+	base := strings.TrimSuffix(name, fifoSuffix)
+	return base + "-" + string(priority) + name[len(base):]
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"requester/internal/models"
+)
+
+// lockParents locks the parent tasks of the client until the dependent task is created,
+// so they can't finish before the dependency is stored, and returns the initial status of the dependent:
+// new if all parents are done, skipped if any of them has failed for good and waiting otherwise.
+func lockParents(ctx context.Context, tx pgx.Tx, clientID string, parentIDs []uuid.UUID) (models.TaskStatus, error) {
+	query := sq.Select("status", "COALESCE((success->>'retry')::boolean, false)").
+		From("tasks").
+		Where(sq.Eq{"id": parentIDs, "client_id": clientID}).
+		Suffix("FOR SHARE")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := tx.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	found := 0
+	status := models.TaskStatusNew
+	for rows.Next() {
+		var parentStatus models.TaskStatus
+		var retried bool
+		if err = rows.Scan(&parentStatus, &retried); err != nil {
+			return "", err
+		}
+		found++
+
+		switch {
+		case parentStatus == models.TaskStatusDone:
+		case parentStatus == models.TaskStatusSkipped,
+			parentStatus == models.TaskStatusFailed && !retried:
+			status = models.TaskStatusSkipped
+		case status != models.TaskStatusSkipped:
+			status = models.TaskStatusWaiting
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return "", err
+	}
+	if found != len(parentIDs) {
+		return "", fmt.Errorf("parent tasks not found")
+	}
+	return status, nil
+}
+
+// insertDependencies stores the parents of the task.
+func insertDependencies(ctx context.Context, tx pgx.Tx, taskID uuid.UUID, parentIDs []uuid.UUID) error {
+	if len(parentIDs) == 0 {
+		return nil
+	}
+
+	query := sq.Insert("task_dependencies").Columns("task_id", "parent_id")
+	for _, parentID := range parentIDs {
+		query = query.Values(taskID, parentID)
+	}
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, sqlQuery, args...)
+	return err
+}
+
+// QueuedTask is a task which must be sent to the queue.
+type QueuedTask struct {
+	ID       uuid.UUID
+	Priority models.TaskPriority
+	// Name of the task queue, the default queue if empty
+	Queue string
+	// Key of the tasks delivered in order by FIFO queues, empty if not ordered
+	OrderingKey string
+}
+
+// QueuedTask returns the task of the ID created with the input, to be sent to the queue.
+func (i *CreateTaskInput) QueuedTask(id uuid.UUID) QueuedTask {
+	return QueuedTask{ID: id, Priority: i.priority(), Queue: i.Queue, OrderingKey: i.OrderingKey}
+}
+
+// ReleaseDependents sets waiting dependents of the done task with all parents done to new.
+// Returns the released tasks, which must be sent to the queue.
+// Parents finishing concurrently can't both miss the release, since each releases after its update is committed.
+func (q taskDB) ReleaseDependents(ctx context.Context, parentID uuid.UUID) ([]QueuedTask, error) {
+	query := sq.Update("tasks").
+		Set("status", models.TaskStatusNew).
+		Where(sq.Eq{"status": models.TaskStatusWaiting}).
+		Where(sq.Expr("id IN (SELECT task_id FROM task_dependencies WHERE parent_id = ?)", parentID)).
+		Where(sq.Expr(
+			"NOT EXISTS (SELECT 1 FROM task_dependencies d JOIN tasks p ON p.id = d.parent_id "+
+				"WHERE d.task_id = tasks.id AND p.status <> ?)",
+			models.TaskStatusDone,
+		)).
+		Suffix("RETURNING id, priority, COALESCE(queue, ''), COALESCE(ordering_key, '')")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.db.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var released []QueuedTask
+	for rows.Next() {
+		var task QueuedTask
+		if err = rows.Scan(&task.ID, &task.Priority, &task.Queue, &task.OrderingKey); err != nil {
+			return nil, err
+		}
+		released = append(released, task)
+	}
+	return released, rows.Err()
+}
+
+// SkipDependents sets waiting dependents of the failed task and their dependents to skipped.
+// Returns the number of skipped tasks.
+func (q taskDB) SkipDependents(ctx context.Context, parentID uuid.UUID) (int64, error) {
+	query := sq.Update("tasks").
+		Prefix(
+			"WITH RECURSIVE dependents AS ("+
+				"SELECT task_id FROM task_dependencies WHERE parent_id = ? "+
+				"UNION SELECT d.task_id FROM task_dependencies d JOIN dependents ON d.parent_id = dependents.task_id"+
+				")",
+			parentID,
+		).
+		Set("status", models.TaskStatusSkipped).
+		Where(sq.Eq{"status": models.TaskStatusWaiting}).
+		Where("id IN (SELECT task_id FROM dependents)")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := q.db.Exec(ctx, sqlQuery, args...)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
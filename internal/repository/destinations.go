@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"requester/internal/models"
+)
+
+// DestinationRepository is a repository manager for client destination rules.
+type DestinationRepository interface {
+	// GetDestinationRules gets destination rules of the client.
+	GetDestinationRules(ctx context.Context, clientID string) (_ *models.DestinationRules, exists bool, _ error)
+}
+
+// destinationDB is a repository manager for client destination rules.
+type destinationDB struct {
+	db DBTX
+}
+
+// NewDestinationDB inits new instance of destinationDB.
+func NewDestinationDB(db DBTX) DestinationRepository {
+	return destinationDB{
+		db: db,
+	}
+}
+
+// GetDestinationRules gets destination rules of the client.
+func (q destinationDB) GetDestinationRules(
+	ctx context.Context,
+	clientID string,
+) (_ *models.DestinationRules, exists bool, _ error) {
+	query := sq.Select(
+		"client_id",
+		"allow_hosts",
+		"deny_hosts",
+		"allow_cidrs",
+		"deny_cidrs",
+	).
+		From("client_destination_rules").
+		Where(sq.Eq{"client_id": clientID})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	rules := &models.DestinationRules{}
+	err = q.db.QueryRow(ctx, sqlQuery, args...).Scan(
+		&rules.ClientID,
+		&rules.AllowHosts,
+		&rules.DenyHosts,
+		&rules.AllowCIDRs,
+		&rules.DenyCIDRs,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return rules, true, nil
+}
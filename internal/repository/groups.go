@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"requester/internal/encryption"
+	"requester/internal/models"
+)
+
+// TaskGroupRepository is a repository manager for fan-out task groups.
+type TaskGroupRepository interface {
+	// CreateTaskGroup creates a group with its tasks.
+	CreateTaskGroup(ctx context.Context, input *CreateTaskGroupInput) (*models.TaskGroup, error)
+	// GetTaskGroup gets group by id with counts of its tasks by status.
+	GetTaskGroup(ctx context.Context, id uuid.UUID) (_ *models.TaskGroup, exists bool, _ error)
+}
+
+// taskGroupDB is a repository manager for fan-out task groups.
+// Group tasks are created with the task repository, so they are encrypted as any other task.
+type taskGroupDB struct {
+	db      DBTX
+	keyring *encryption.Keyring
+}
+
+// NewTaskGroupDB inits new instance of taskGroupDB.
+func NewTaskGroupDB(db DBTX, keyring *encryption.Keyring) TaskGroupRepository {
+	return taskGroupDB{
+		db:      db,
+		keyring: keyring,
+	}
+}
+
+// CreateTaskGroupInput is input for CreateTaskGroup.
+type CreateTaskGroupInput struct {
+	ClientID string
+	Tasks    []*CreateTaskInput
+	// Limits of the client rate counters incremented on creation, nil if unlimited
+	CounterLimits []CounterLimit
+}
+
+// CreateTaskGroup creates a group with its tasks in a single transaction.
+// Only new tasks must be sent to the queue, tasks with parents may be waiting or skipped.
+func (q taskGroupDB) CreateTaskGroup(ctx context.Context, input *CreateTaskGroupInput) (*models.TaskGroup, error) {
+	if input == nil {
+		return nil, fmt.Errorf("input is nil")
+	}
+
+	tx, err := q.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err = incrementCounters(ctx, tx, input.ClientID, input.CounterLimits); err != nil {
+		return nil, err
+	}
+
+	group := &models.TaskGroup{
+		ID:       uuid.New(),
+		ClientID: input.ClientID,
+		Counts:   make(map[models.TaskStatus]int),
+	}
+	query := sq.Insert("task_groups").
+		Columns("id", "client_id").
+		Values(group.ID, group.ClientID).
+		Suffix("RETURNING created_at")
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.QueryRow(ctx, sqlQuery, args...).Scan(&group.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	tasks := NewTaskDB(tx, q.keyring)
+	for _, taskInput := range input.Tasks {
+		taskInput.ClientID = input.ClientID
+		taskInput.GroupID = group.ID
+		task, err := tasks.CreateTask(ctx, taskInput)
+		if err != nil {
+			return nil, err
+		}
+		group.TaskIDs = append(group.TaskIDs, task.ID)
+		group.Counts[task.Status]++
+	}
+
+	return group, tx.Commit(ctx)
+}
+
+// GetTaskGroup gets group by id with counts of its tasks by status.
+func (q taskGroupDB) GetTaskGroup(ctx context.Context, id uuid.UUID) (_ *models.TaskGroup, exists bool, _ error) {
+	query := sq.Select("id", "client_id", "created_at").
+		From("task_groups").
+		Where(sq.Eq{"id": id})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	group := &models.TaskGroup{Counts: make(map[models.TaskStatus]int)}
+	err = q.db.QueryRow(ctx, sqlQuery, args...).Scan(&group.ID, &group.ClientID, &group.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	countsQuery := sq.Select("status", "count(*)").
+		From("tasks").
+		Where(sq.Eq{"group_id": id}).
+		GroupBy("status")
+
+	sqlQuery, args, err = countsQuery.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	rows, err := q.db.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status models.TaskStatus
+		var count int
+		if err = rows.Scan(&status, &count); err != nil {
+			return nil, false, err
+		}
+		group.Counts[status] = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, false, err
+	}
+	return group, true, nil
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"requester/internal/models"
+	"time"
+)
+
+// HostRepository is a repository manager for outbound limits of target hosts.
+type HostRepository interface {
+	// GetHostLimits gets limit overrides of the host.
+	GetHostLimits(ctx context.Context, host string) (_ *models.HostLimits, exists bool, _ error)
+	// AcquireHostSlot acquires a request slot of the host for the task.
+	AcquireHostSlot(ctx context.Context, input *AcquireHostSlotInput) (acquired bool, _ error)
+	// ExtendHostSlot extends the request slot of the host held by the task.
+	ExtendHostSlot(ctx context.Context, host string, taskID uuid.UUID, ttl time.Duration) (extended bool, _ error)
+	// ReleaseHostSlot releases the request slot of the host held by the task.
+	ReleaseHostSlot(ctx context.Context, host string, taskID uuid.UUID) error
+	// ReturnRateToken returns a rate token taken for a request which wasn't sent.
+	ReturnRateToken(ctx context.Context, host string, burst int) error
+}
+
+// hostDB is a repository manager for outbound limits of target hosts.
+type hostDB struct {
+	db DBTX
+}
+
+// NewHostDB inits new instance of hostDB.
+func NewHostDB(db DBTX) HostRepository {
+	return hostDB{
+		db: db,
+	}
+}
+
+// GetHostLimits gets limit overrides of the host.
+func (q hostDB) GetHostLimits(ctx context.Context, host string) (_ *models.HostLimits, exists bool, _ error) {
+	query := sq.Select(
+		"host",
+		"max_concurrent",
+		"requests_per_second",
+		"burst",
+	).
+		From("host_limits").
+		Where(sq.Eq{"host": host})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	limits := &models.HostLimits{}
+	err = q.db.QueryRow(ctx, sqlQuery, args...).Scan(
+		&limits.Host,
+		&limits.MaxConcurrent,
+		&limits.RequestsPerSecond,
+		&limits.Burst,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return limits, true, nil
+}
+
+// AcquireHostSlotInput is input for AcquireHostSlot.
+// Zero limits are not enforced.
+type AcquireHostSlotInput struct {
+	Host              string
+	TaskID            uuid.UUID
+	MaxConcurrent     int
+	RequestsPerSecond float64
+	Burst             int
+	// TTL of the slot, after which it's considered abandoned.
+	TTL time.Duration
+}
+
+// AcquireHostSlot acquires a request slot of the host for the task.
+// Slots are serialized per host with an advisory lock, so limits hold across all workers.
+// A rate token is taken only if a concurrency slot is available.
+func (q hostDB) AcquireHostSlot(ctx context.Context, input *AcquireHostSlotInput) (acquired bool, _ error) {
+	tx, err := q.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", input.Host); err != nil {
+		return false, err
+	}
+
+	if input.MaxConcurrent > 0 {
+		available, err := q.hasFreeSlot(ctx, tx, input)
+		if err != nil || !available {
+			return false, err
+		}
+	}
+
+	if input.RequestsPerSecond > 0 {
+		taken, err := q.takeRateToken(ctx, tx, input)
+		if err != nil || !taken {
+			return false, err
+		}
+	}
+
+	if input.MaxConcurrent > 0 {
+		query := sq.Insert("host_slots").
+			Columns("host", "task_id", "expires_at").
+			Values(input.Host, input.TaskID, sq.Expr("now() + ? * interval '1 second'", input.TTL.Seconds())).
+			Suffix("ON CONFLICT (host, task_id) DO UPDATE SET expires_at = EXCLUDED.expires_at")
+
+		sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+		if err != nil {
+			return false, err
+		}
+		if _, err = tx.Exec(ctx, sqlQuery, args...); err != nil {
+			return false, err
+		}
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// hasFreeSlot checks whether the host has a free concurrency slot.
+// Expired slots are removed.
+func (q hostDB) hasFreeSlot(ctx context.Context, tx pgx.Tx, input *AcquireHostSlotInput) (bool, error) {
+	cleanup := sq.Delete("host_slots").
+		Where(sq.Eq{"host": input.Host}).
+		Where("expires_at <= now()")
+
+	sqlQuery, args, err := cleanup.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+	if _, err = tx.Exec(ctx, sqlQuery, args...); err != nil {
+		return false, err
+	}
+
+	query := sq.Select("count(*)").
+		From("host_slots").
+		Where(sq.Eq{"host": input.Host}).
+		Where(sq.NotEq{"task_id": input.TaskID})
+
+	sqlQuery, args, err = query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var count int
+	if err = tx.QueryRow(ctx, sqlQuery, args...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count < input.MaxConcurrent, nil
+}
+
+// takeRateToken takes a token from the host token bucket.
+// The bucket is refilled by the elapsed time and holds up to burst tokens.
+func (q hostDB) takeRateToken(ctx context.Context, tx pgx.Tx, input *AcquireHostSlotInput) (bool, error) {
+	burst := input.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	refilled := "LEAST(?::double precision, host_rate_buckets.tokens + " +
+		"EXTRACT(EPOCH FROM now() - host_rate_buckets.updated_at) * ?::double precision)"
+
+	query := sq.Insert("host_rate_buckets").
+		Columns("host", "tokens", "updated_at").
+		Values(input.Host, burst-1, sq.Expr("now()")).
+		Suffix(
+			"ON CONFLICT (host) DO UPDATE SET tokens = "+refilled+" - 1, updated_at = now() "+
+				"WHERE "+refilled+" >= 1 RETURNING tokens",
+			burst, input.RequestsPerSecond, burst, input.RequestsPerSecond,
+		)
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var tokens float64
+	err = tx.QueryRow(ctx, sqlQuery, args...).Scan(&tokens)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ExtendHostSlot extends the request slot of the host held by the task.
+// Returns false if the slot has expired and was removed.
+func (q hostDB) ExtendHostSlot(
+	ctx context.Context,
+	host string,
+	taskID uuid.UUID,
+	ttl time.Duration,
+) (extended bool, _ error) {
+	query := sq.Update("host_slots").
+		Set("expires_at", sq.Expr("now() + ? * interval '1 second'", ttl.Seconds())).
+		Where(sq.Eq{"host": host, "task_id": taskID})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	tag, err := q.db.Exec(ctx, sqlQuery, args...)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ReleaseHostSlot releases the request slot of the host held by the task.
+func (q hostDB) ReleaseHostSlot(ctx context.Context, host string, taskID uuid.UUID) error {
+	query := sq.Delete("host_slots").
+		Where(sq.Eq{"host": host, "task_id": taskID})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.db.Exec(ctx, sqlQuery, args...)
+	return err
+}
+
+// ReturnRateToken returns a rate token taken for a request which wasn't sent
+// to the host token bucket, which holds up to burst tokens.
+func (q hostDB) ReturnRateToken(ctx context.Context, host string, burst int) error {
+	if burst < 1 {
+		burst = 1
+	}
+	query := sq.Update("host_rate_buckets").
+		Set("tokens", sq.Expr("LEAST(?::double precision, tokens + 1)", burst)).
+		Where(sq.Eq{"host": host})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.db.Exec(ctx, sqlQuery, args...)
+	return err
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"requester/internal/models"
+	"time"
+)
+
+// LeaseError is returned when the task is leased by another worker.
+// Owner and ExpiresAt are empty if the task isn't leased anymore.
+type LeaseError struct {
+	ID        uuid.UUID
+	Owner     string
+	ExpiresAt time.Time
+}
+
+func (e *LeaseError) Error() string {
+	if e.Owner == "" {
+		return fmt.Sprintf("task %s lease is lost", e.ID)
+	}
+	return fmt.Sprintf("task %s is leased by %s until %s", e.ID, e.Owner, e.ExpiresAt.Format(time.RFC3339))
+}
+
+// ClaimTaskInput is input for ClaimTask and ExtendLease.
+type ClaimTaskInput struct {
+	ID uuid.UUID
+	// ID of the worker
+	Owner string
+	// TTL of the lease, after which the task can be claimed by another worker
+	TTL time.Duration
+}
+
+// ClaimTask moves the task to in_process leased by the worker.
+// Tasks in process whose lease has expired are reclaimed, their worker is considered gone.
+// Returns *LeaseError if the task is leased by another worker and *TransitionError if it can't be processed.
+func (q taskDB) ClaimTask(ctx context.Context, input *ClaimTaskInput) error {
+	if input == nil || input.ID == uuid.Nil || input.Owner == "" {
+		return fmt.Errorf("input is nil, id or owner is empty")
+	}
+
+	tx, err := q.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	query := sq.Update("tasks").
+		Set("status", models.TaskStatusInProcess).
+		Set("lease_owner", input.Owner).
+		Set("lease_expires_at", sq.Expr("now() + ? * interval '1 second'", input.TTL.Seconds())).
+		Where(sq.Eq{"id": input.ID}).
+		Where(sq.Or{
+			sq.Eq{"status": models.TaskStatusInProcess.Predecessors()},
+			sq.And{
+				sq.Eq{"status": models.TaskStatusInProcess},
+				sq.Expr("(lease_expires_at IS NULL OR lease_expires_at <= now())"),
+			},
+		})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, sqlQuery, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		lease, exists, err := getTaskLease(ctx, tx, input.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("task %s not found", input.ID)
+		}
+		if lease.status == models.TaskStatusInProcess {
+			return lease.error(input.ID)
+		}
+		return &TransitionError{ID: input.ID, From: lease.status, To: models.TaskStatusInProcess}
+	}
+	return tx.Commit(ctx)
+}
+
+// ExtendLease extends the lease of the task held by the worker.
+// Returns false if the task isn't leased by the worker anymore.
+func (q taskDB) ExtendLease(ctx context.Context, input *ClaimTaskInput) (extended bool, _ error) {
+	if input == nil || input.ID == uuid.Nil || input.Owner == "" {
+		return false, fmt.Errorf("input is nil, id or owner is empty")
+	}
+
+	query := sq.Update("tasks").
+		Set("lease_expires_at", sq.Expr("now() + ? * interval '1 second'", input.TTL.Seconds())).
+		Where(sq.Eq{"id": input.ID, "status": models.TaskStatusInProcess, "lease_owner": input.Owner})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	tag, err := q.db.Exec(ctx, sqlQuery, args...)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// taskLease is the status and lease of a task.
+type taskLease struct {
+	status    models.TaskStatus
+	owner     *string
+	expiresAt *time.Time
+}
+
+// error returns *LeaseError of the lease.
+func (l *taskLease) error(taskID uuid.UUID) *LeaseError {
+	leaseErr := &LeaseError{ID: taskID}
+	if l.owner != nil && l.expiresAt != nil {
+		leaseErr.Owner = *l.owner
+		leaseErr.ExpiresAt = *l.expiresAt
+	}
+	return leaseErr
+}
+
+// getTaskLease gets the status and lease of the task.
+func getTaskLease(ctx context.Context, tx pgx.Tx, taskID uuid.UUID) (_ *taskLease, exists bool, _ error) {
+	lease := &taskLease{}
+	err := tx.QueryRow(
+		ctx,
+		"SELECT status, lease_owner, lease_expires_at FROM tasks WHERE id = $1",
+		taskID,
+	).Scan(&lease.status, &lease.owner, &lease.expiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return lease, true, nil
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"requester/internal/models"
+	"time"
+)
+
+// LimitRepository is a repository manager for client limits and rate counters.
+type LimitRepository interface {
+	// GetClientLimits gets limit overrides of the client.
+	GetClientLimits(ctx context.Context, clientID string) (_ *models.ClientLimits, exists bool, _ error)
+	// IncrementCounter increments the client counter within the current window by n.
+	IncrementCounter(ctx context.Context, clientID string, bucket models.RateBucket, n int) (*models.RateCounter, error)
+	// CountOutstandingTasks counts client tasks that are not finished yet.
+	CountOutstandingTasks(ctx context.Context, clientID string) (int, error)
+}
+
+// OutstandingLimitError is returned when the task would exceed the client outstanding tasks limit.
+type OutstandingLimitError struct {
+	Limit int
+}
+
+func (e *OutstandingLimitError) Error() string {
+	return fmt.Sprintf("outstanding tasks limit %d exceeded", e.Limit)
+}
+
+// CounterLimit is a limit of a client rate counter incremented on creation of tasks.
+type CounterLimit struct {
+	Bucket models.RateBucket
+	// Max count within the window
+	Limit int
+	// Increment of the counter
+	N int
+}
+
+// CounterLimitError is returned when tasks would exceed the limit of a client rate counter.
+type CounterLimitError struct {
+	Bucket models.RateBucket
+	Limit  int
+	// Time until the counter window ends
+	ResetsIn time.Duration
+}
+
+func (e *CounterLimitError) Error() string {
+	return fmt.Sprintf("%s counter limit %d exceeded", e.Bucket, e.Limit)
+}
+
+// limitDB is a repository manager for client limits and rate counters.
+type limitDB struct {
+	db DBTX
+}
+
+// NewLimitDB inits new instance of limitDB.
+func NewLimitDB(db DBTX) LimitRepository {
+	return limitDB{
+		db: db,
+	}
+}
+
+// GetClientLimits gets limit overrides of the client.
+func (q limitDB) GetClientLimits(ctx context.Context, clientID string) (_ *models.ClientLimits, exists bool, _ error) {
+	query := sq.Select(
+		"client_id",
+		"requests_per_second",
+		"max_outstanding_tasks",
+		"daily_task_quota",
+	).
+		From("client_limits").
+		Where(sq.Eq{"client_id": clientID})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	limits := &models.ClientLimits{}
+	err = q.db.QueryRow(ctx, sqlQuery, args...).Scan(
+		&limits.ClientID,
+		&limits.RequestsPerSecond,
+		&limits.MaxOutstandingTasks,
+		&limits.DailyTaskQuota,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return limits, true, nil
+}
+
+// IncrementCounter increments the client counter within the current window by n.
+// Windows are aligned on the database clock, so all replicas share them.
+// Counters of the previous windows are removed.
+func (q limitDB) IncrementCounter(
+	ctx context.Context,
+	clientID string,
+	bucket models.RateBucket,
+	n int,
+) (*models.RateCounter, error) {
+	query := sq.Insert("client_rate_counters").
+		Columns("client_id", "bucket", "window_start", "count").
+		Values(clientID, bucket, sq.Expr("date_trunc(?, now())", bucket), n).
+		Suffix("ON CONFLICT (client_id, bucket, window_start) " +
+			"DO UPDATE SET count = client_rate_counters.count + EXCLUDED.count " +
+			"RETURNING window_start, count")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	counter := &models.RateCounter{ClientID: clientID, Bucket: bucket}
+	err = q.db.QueryRow(ctx, sqlQuery, args...).Scan(&counter.WindowStart, &counter.Count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment %s counter: %w", bucket, err)
+	}
+
+	cleanup := sq.Delete("client_rate_counters").
+		Where(sq.Eq{"client_id": clientID, "bucket": bucket}).
+		Where(sq.Lt{"window_start": counter.WindowStart})
+
+	sqlQuery, args, err = cleanup.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = q.db.Exec(ctx, sqlQuery, args...); err != nil {
+		return nil, err
+	}
+	return counter, nil
+}
+
+// CountOutstandingTasks counts client tasks that are not finished yet.
+func (q limitDB) CountOutstandingTasks(ctx context.Context, clientID string) (int, error) {
+	query := sq.Select("count(*)").
+		From("tasks").
+		Where(sq.Eq{
+			"client_id": clientID,
+			"status": []models.TaskStatus{
+				models.TaskStatusNew,
+				models.TaskStatusWaiting,
+				models.TaskStatusInProcess,
+			},
+		})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	return count, q.db.QueryRow(ctx, sqlQuery, args...).Scan(&count)
+}
+
+// checkOutstandingTasks checks that the client may create one more task within the outstanding tasks limit.
+// Creations of the client tasks are serialized with an advisory lock held until the transaction ends,
+// so concurrent requests can't exceed the limit. Returns *OutstandingLimitError if the limit is reached.
+func checkOutstandingTasks(ctx context.Context, tx pgx.Tx, clientID string, limit int) error {
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "outstanding_tasks:"+clientID); err != nil {
+		return err
+	}
+	count, err := NewLimitDB(tx).CountOutstandingTasks(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	if count >= limit {
+		return &OutstandingLimitError{Limit: limit}
+	}
+	return nil
+}
+
+// incrementCounters increments the client rate counters within the transaction creating the tasks,
+// so counters of rejected or failed creations are rolled back with it.
+// Returns *CounterLimitError if a counter exceeds its limit.
+func incrementCounters(ctx context.Context, tx pgx.Tx, clientID string, limits []CounterLimit) error {
+	for _, limit := range limits {
+		counter, err := NewLimitDB(tx).IncrementCounter(ctx, clientID, limit.Bucket, limit.N)
+		if err != nil {
+			return err
+		}
+		if counter.Count > limit.Limit {
+			return &CounterLimitError{Bucket: limit.Bucket, Limit: limit.Limit, ResetsIn: counter.ResetsIn(time.Now())}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"requester/internal/models"
+	"sync"
+)
+
+// taskUpdatesChannel is the notification channel of task status updates, the payload is TaskUpdate.
+// Every status update is notified by the tasks_notify_update trigger once the transaction is committed,
+// including bulk updates of dependents.
+const taskUpdatesChannel = "task_updates"
+
+// subscriptionBuffer is the number of updates buffered for a subscriber.
+const subscriptionBuffer = 16
+
+// TaskUpdate is a notification of a task status update.
+type TaskUpdate struct {
+	ID       uuid.UUID         `json:"id"`
+	ClientID string            `json:"client_id"`
+	Status   models.TaskStatus `json:"status"`
+}
+
+// TaskFilter selects task updates of a subscription.
+type TaskFilter struct {
+	// IDs of the tasks, any task if empty
+	TaskIDs []uuid.UUID
+	// ID of the client owning the tasks, any client if empty
+	ClientID string
+}
+
+// Matches reports whether the update passes the filter.
+func (f TaskFilter) Matches(update *TaskUpdate) bool {
+	if f.ClientID != "" && f.ClientID != update.ClientID {
+		return false
+	}
+	if len(f.TaskIDs) == 0 {
+		return true
+	}
+	for _, id := range f.TaskIDs {
+		if id == update.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskListener listens for task status updates on a single connection and dispatches them to subscribers.
+type TaskListener struct {
+	pool        *pgxpool.Pool
+	mu          sync.Mutex
+	subscribers map[chan *TaskUpdate]TaskFilter
+}
+
+// NewTaskListener inits new instance of TaskListener.
+func NewTaskListener(pool *pgxpool.Pool) (*TaskListener, error) {
+	if pool == nil {
+		return nil, errors.New("must specify *pgxpool.Pool")
+	}
+	return &TaskListener{
+		pool:        pool,
+		subscribers: make(map[chan *TaskUpdate]TaskFilter),
+	}, nil
+}
+
+// Listen dispatches notifications until the context is done or the connection fails.
+// Subscriptions are closed once listening starts and on return, since they may have missed updates.
+func (l *TaskListener) Listen(ctx context.Context) error {
+	defer l.closeAll()
+
+	pooled, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection is taken out of the pool and closed, so it doesn't keep listening.
+	conn := pooled.Hijack()
+	defer func() {
+		_ = conn.Close(context.Background())
+	}()
+
+	if _, err = conn.Exec(ctx, "LISTEN "+taskUpdatesChannel); err != nil {
+		return err
+	}
+	l.closeAll()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		update := &TaskUpdate{}
+		if err = json.Unmarshal([]byte(notification.Payload), update); err != nil {
+			continue
+		}
+		l.dispatch(update)
+	}
+}
+
+// Subscribe returns a channel receiving updates of the tasks selected by the filter and a function to unsubscribe.
+// The channel is closed if updates may have been missed: while the listener reconnects
+// or the subscriber doesn't keep up with the updates.
+func (l *TaskListener) Subscribe(filter TaskFilter) (updates <-chan *TaskUpdate, unsubscribe func()) {
+	ch := make(chan *TaskUpdate, subscriptionBuffer)
+
+	l.mu.Lock()
+	l.subscribers[ch] = filter
+	l.mu.Unlock()
+
+	return ch, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, ok := l.subscribers[ch]; ok {
+			delete(l.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// dispatch sends the update to matching subscribers, closing the ones which are full.
+func (l *TaskListener) dispatch(update *TaskUpdate) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for ch, filter := range l.subscribers {
+		if !filter.Matches(update) {
+			continue
+		}
+		select {
+		case ch <- update:
+		default:
+			delete(l.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// closeAll closes all subscriptions.
+func (l *TaskListener) closeAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for ch := range l.subscribers {
+		delete(l.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"requester/internal/models"
+)
+
+// OAuth2ProfileRepository is a repository manager for client OAuth2 profiles.
+type OAuth2ProfileRepository interface {
+	// PutProfile creates or updates a profile.
+	PutProfile(ctx context.Context, input *PutOAuth2ProfileInput) (*models.OAuth2Profile, error)
+	// GetProfile gets a profile.
+	GetProfile(ctx context.Context, clientID, name string) (_ *models.OAuth2Profile, exists bool, _ error)
+	// ListProfiles lists client profiles.
+	ListProfiles(ctx context.Context, clientID string) ([]*models.OAuth2Profile, error)
+	// DeleteProfile deletes a profile.
+	DeleteProfile(ctx context.Context, clientID, name string) (deleted bool, _ error)
+}
+
+// oauth2ProfileDB is a repository manager for client OAuth2 profiles.
+type oauth2ProfileDB struct {
+	db DBTX
+}
+
+// NewOAuth2ProfileDB inits new instance of oauth2ProfileDB.
+func NewOAuth2ProfileDB(db DBTX) OAuth2ProfileRepository {
+	return oauth2ProfileDB{
+		db: db,
+	}
+}
+
+// PutOAuth2ProfileInput is input for PutProfile.
+type PutOAuth2ProfileInput struct {
+	ClientID         string
+	Name             string
+	TokenURL         string
+	OAuth2ClientID   string
+	ClientSecretName string
+	Scopes           []string
+}
+
+// PutProfile creates or updates a profile.
+func (q oauth2ProfileDB) PutProfile(ctx context.Context, input *PutOAuth2ProfileInput) (*models.OAuth2Profile, error) {
+	if input == nil {
+		return nil, fmt.Errorf("input is nil")
+	}
+
+	scopes := input.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	query := sq.Insert("oauth2_profiles").
+		Columns("client_id", "name", "token_url", "oauth2_client_id", "client_secret_name", "scopes").
+		Values(input.ClientID, input.Name, input.TokenURL, input.OAuth2ClientID, input.ClientSecretName, scopes).
+		Suffix("ON CONFLICT (client_id, name) DO UPDATE SET " +
+			"token_url = EXCLUDED.token_url, " +
+			"oauth2_client_id = EXCLUDED.oauth2_client_id, " +
+			"client_secret_name = EXCLUDED.client_secret_name, " +
+			"scopes = EXCLUDED.scopes, " +
+			"updated_at = now() " +
+			"RETURNING created_at, updated_at")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &models.OAuth2Profile{
+		ClientID:         input.ClientID,
+		Name:             input.Name,
+		TokenURL:         input.TokenURL,
+		OAuth2ClientID:   input.OAuth2ClientID,
+		ClientSecretName: input.ClientSecretName,
+		Scopes:           scopes,
+	}
+	return profile, q.db.QueryRow(ctx, sqlQuery, args...).Scan(&profile.CreatedAt, &profile.UpdatedAt)
+}
+
+// selectProfiles returns the select query of profiles.
+func selectProfiles() sq.SelectBuilder {
+	return sq.Select(
+		"client_id",
+		"name",
+		"token_url",
+		"oauth2_client_id",
+		"client_secret_name",
+		"scopes",
+		"created_at",
+		"updated_at",
+	).
+		From("oauth2_profiles")
+}
+
+// scanProfile scans a row of selectProfiles.
+func scanProfile(row pgx.Row) (*models.OAuth2Profile, error) {
+	profile := &models.OAuth2Profile{}
+	return profile, row.Scan(
+		&profile.ClientID,
+		&profile.Name,
+		&profile.TokenURL,
+		&profile.OAuth2ClientID,
+		&profile.ClientSecretName,
+		&profile.Scopes,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+}
+
+// GetProfile gets a profile.
+func (q oauth2ProfileDB) GetProfile(
+	ctx context.Context,
+	clientID, name string,
+) (_ *models.OAuth2Profile, exists bool, _ error) {
+	query := selectProfiles().Where(sq.Eq{"client_id": clientID, "name": name})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	profile, err := scanProfile(q.db.QueryRow(ctx, sqlQuery, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return profile, true, nil
+}
+
+// ListProfiles lists client profiles.
+func (q oauth2ProfileDB) ListProfiles(ctx context.Context, clientID string) ([]*models.OAuth2Profile, error) {
+	query := selectProfiles().Where(sq.Eq{"client_id": clientID}).OrderBy("name")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.db.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []*models.OAuth2Profile
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, rows.Err()
+}
+
+// DeleteProfile deletes a profile.
+func (q oauth2ProfileDB) DeleteProfile(ctx context.Context, clientID, name string) (deleted bool, _ error) {
+	query := sq.Delete("oauth2_profiles").
+		Where(sq.Eq{"client_id": clientID, "name": name})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	tag, err := q.db.Exec(ctx, sqlQuery, args...)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
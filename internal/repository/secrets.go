@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"requester/internal/encryption"
+	"requester/internal/models"
+)
+
+// SecretRepository is a repository manager for client secrets.
+// Values are encrypted at rest.
+type SecretRepository interface {
+	// PutSecret creates or updates a secret.
+	PutSecret(ctx context.Context, input *PutSecretInput) (*models.Secret, error)
+	// GetSecret gets a secret with decrypted value.
+	GetSecret(ctx context.Context, clientID, name string) (_ *models.Secret, exists bool, _ error)
+	// ListSecrets lists client secrets without values.
+	ListSecrets(ctx context.Context, clientID string) ([]*models.Secret, error)
+	// DeleteSecret deletes a secret.
+	DeleteSecret(ctx context.Context, clientID, name string) (deleted bool, _ error)
+}
+
+// secretDB is a repository manager for client secrets.
+type secretDB struct {
+	db     DBTX
+	cipher *encryption.Cipher
+}
+
+// NewSecretDB inits new instance of secretDB.
+func NewSecretDB(db DBTX, cipher *encryption.Cipher) SecretRepository {
+	return secretDB{
+		db:     db,
+		cipher: cipher,
+	}
+}
+
+// secretAdditionalData binds the encrypted value to the secret owner and name,
+// so the value can't be moved to another row.
+func secretAdditionalData(clientID, name string) []byte {
+	return []byte(fmt.Sprintf("secrets/%s/%s", clientID, name))
+}
+
+// PutSecretInput is input for PutSecret.
+type PutSecretInput struct {
+	ClientID string
+	Name     string
+	Value    string
+}
+
+// PutSecret creates or updates a secret.
+func (q secretDB) PutSecret(ctx context.Context, input *PutSecretInput) (*models.Secret, error) {
+	if input == nil {
+		return nil, fmt.Errorf("input is nil")
+	}
+
+	value, err := q.cipher.Encrypt([]byte(input.Value), secretAdditionalData(input.ClientID, input.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	query := sq.Insert("secrets").
+		Columns("client_id", "name", "value").
+		Values(input.ClientID, input.Name, value).
+		Suffix("ON CONFLICT (client_id, name) DO UPDATE SET value = EXCLUDED.value, updated_at = now() " +
+			"RETURNING created_at, updated_at")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &models.Secret{
+		ClientID: input.ClientID,
+		Name:     input.Name,
+		Value:    input.Value,
+	}
+	return secret, q.db.QueryRow(ctx, sqlQuery, args...).Scan(&secret.CreatedAt, &secret.UpdatedAt)
+}
+
+// GetSecret gets a secret with decrypted value.
+func (q secretDB) GetSecret(ctx context.Context, clientID, name string) (_ *models.Secret, exists bool, _ error) {
+	query := sq.Select(
+		"client_id",
+		"name",
+		"value",
+		"created_at",
+		"updated_at",
+	).
+		From("secrets").
+		Where(sq.Eq{"client_id": clientID, "name": name})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	secret := &models.Secret{}
+	var value []byte
+	err = q.db.QueryRow(ctx, sqlQuery, args...).Scan(
+		&secret.ClientID,
+		&secret.Name,
+		&value,
+		&secret.CreatedAt,
+		&secret.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	plaintext, err := q.cipher.Decrypt(value, secretAdditionalData(clientID, name))
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to decrypt secret %s: %w", name, err)
+	}
+	secret.Value = string(plaintext)
+	return secret, true, nil
+}
+
+// ListSecrets lists client secrets without values.
+func (q secretDB) ListSecrets(ctx context.Context, clientID string) ([]*models.Secret, error) {
+	query := sq.Select(
+		"client_id",
+		"name",
+		"created_at",
+		"updated_at",
+	).
+		From("secrets").
+		Where(sq.Eq{"client_id": clientID}).
+		OrderBy("name")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.db.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var secrets []*models.Secret
+	for rows.Next() {
+		secret := &models.Secret{}
+		if err = rows.Scan(&secret.ClientID, &secret.Name, &secret.CreatedAt, &secret.UpdatedAt); err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, rows.Err()
+}
+
+// DeleteSecret deletes a secret.
+func (q secretDB) DeleteSecret(ctx context.Context, clientID, name string) (deleted bool, _ error) {
+	query := sq.Delete("secrets").
+		Where(sq.Eq{"client_id": clientID, "name": name})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	tag, err := q.db.Exec(ctx, sqlQuery, args...)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
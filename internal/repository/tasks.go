@@ -8,6 +8,7 @@ import (
 	"github.com/go-faster/jx"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
+	"requester/internal/encryption"
 	"requester/internal/models"
 )
 
@@ -18,18 +19,32 @@ type TaskRepository interface {
 	// GetTask gets task by id.
 	GetTask(ctx context.Context, id uuid.UUID) (_ *models.TaskWithResponseData, exists bool, _ error)
 	// UpdateTask updates task.
+	// Returns *TransitionError if the task status can't change to the given one
+	// and *LeaseError if the task is leased by another worker.
 	UpdateTask(ctx context.Context, input *UpdateTaskInput) error
+	// ClaimTask moves the task to in_process leased by the worker.
+	// Returns *LeaseError if the task is leased by another worker and *TransitionError if it can't be processed.
+	ClaimTask(ctx context.Context, input *ClaimTaskInput) error
+	// ExtendLease extends the lease of the task held by the worker.
+	ExtendLease(ctx context.Context, input *ClaimTaskInput) (extended bool, _ error)
+	// ReleaseDependents sets waiting dependents of the done task with all parents done to new.
+	ReleaseDependents(ctx context.Context, parentID uuid.UUID) ([]QueuedTask, error)
+	// SkipDependents sets waiting dependents of the failed task and their dependents to skipped.
+	SkipDependents(ctx context.Context, parentID uuid.UUID) (int64, error)
 }
 
 // taskDB is a repository manager for tasks.
+// Request headers, body and response headers are encrypted at rest.
 type taskDB struct {
-	db DBTX
+	db      DBTX
+	keyring *encryption.Keyring
 }
 
 // NewTaskDB inits new instance of taskDB.
-func NewTaskDB(db DBTX) TaskRepository {
+func NewTaskDB(db DBTX, keyring *encryption.Keyring) TaskRepository {
 	return taskDB{
-		db: db,
+		db:      db,
+		keyring: keyring,
 	}
 }
 
@@ -41,41 +56,189 @@ func (q taskDB) BeginTx(ctx context.Context) (pgx.Tx, error) {
 // WithTx sets transaction.
 func (q taskDB) WithTx(tx pgx.Tx) taskDB {
 	return taskDB{
-		db: tx,
+		db:      tx,
+		keyring: q.keyring,
 	}
 }
 
 // CreateTaskInput is input for CreateTask.
 type CreateTaskInput struct {
-	Method  string
-	URL     string
-	Headers map[string]string
-	Body    map[string]jx.Raw
+	// Task ID, generated if not set
+	ID       uuid.UUID
+	ClientID string
+	Method   string
+	URL      string
+	Headers  map[string]string
+	Body     map[string]jx.Raw
+	Signing  *models.Signing
+	// Name of the client OAuth2 profile, empty if not used
+	OAuth2Profile string
+	// Name of the client TLS profile, empty if not used
+	TLSProfile string
+	Proxy      *models.Proxy
+	// Success criteria of the response, nil if any response is accepted
+	Success *models.Success
+	// Values to extract from the response, nil if nothing is extracted
+	Extract map[string]string
+	// IDs of the client tasks which must be done before the task is sent
+	DependsOn []uuid.UUID
+	// Condition on a parent value which must hold for the task to be sent, nil if not used
+	Condition *models.Condition
+	// ID of the fan-out group of the task, zero if not grouped
+	GroupID uuid.UUID
+	// Variables referenced in URL, headers and body, nil if not used
+	Variables map[string]string
+	// Priority of the task queue, normal if empty
+	Priority models.TaskPriority
+	// Name of the task queue, the default queue if empty
+	Queue string
+	// Key of the tasks delivered in order by FIFO queues, empty if not ordered
+	OrderingKey string
+	// Limit of the client outstanding tasks checked on creation, zero if unlimited
+	MaxOutstandingTasks int
+	// Limits of the client rate counters incremented on creation, nil if unlimited
+	CounterLimits []CounterLimit
+}
+
+// priority returns the priority of the task, normal if it isn't set.
+func (i *CreateTaskInput) priority() models.TaskPriority {
+	if i.Priority == "" {
+		return models.TaskPriorityNormal
+	}
+	return i.Priority
 }
 
 // setInsertValues sets values for insert query.
-func (i *CreateTaskInput) setInsertValues(query sq.InsertBuilder) sq.InsertBuilder {
-	columns := []string{"status", "method", "url"}
-	values := []interface{}{models.TaskStatusNew, i.Method, i.URL}
+// Headers, body, signing, proxy and variables are encrypted with the task data key.
+func (i *CreateTaskInput) setInsertValues(
+	query sq.InsertBuilder,
+	dataKey *taskDataKey,
+	status models.TaskStatus,
+) (sq.InsertBuilder, error) {
+	columns := []string{"id", "status", "client_id", "priority", "method", "url", "key_id", "data_key"}
+	values := []interface{}{
+		dataKey.taskID, status, i.ClientID, i.priority(), i.Method, i.URL, dataKey.keyID, dataKey.wrapped,
+	}
 	if i.Headers != nil {
-		columns = append(columns, "headers")
-		values = append(values, i.Headers)
+		headers, err := dataKey.encrypt(columnHeaders, i.Headers)
+		if err != nil {
+			return query, err
+		}
+		columns = append(columns, "headers_encrypted")
+		values = append(values, headers)
 	}
 	if i.Body != nil {
-		columns = append(columns, "body")
-		values = append(values, i.Body)
+		body, err := dataKey.encrypt(columnBody, i.Body)
+		if err != nil {
+			return query, err
+		}
+		columns = append(columns, "body_encrypted")
+		values = append(values, body)
+	}
+	if i.OAuth2Profile != "" {
+		columns = append(columns, "oauth2_profile")
+		values = append(values, i.OAuth2Profile)
+	}
+	if i.Queue != "" {
+		columns = append(columns, "queue")
+		values = append(values, i.Queue)
+	}
+	if i.OrderingKey != "" {
+		columns = append(columns, "ordering_key")
+		values = append(values, i.OrderingKey)
+	}
+	if i.TLSProfile != "" {
+		columns = append(columns, "tls_profile")
+		values = append(values, i.TLSProfile)
+	}
+	if i.Signing != nil {
+		signing, err := dataKey.encrypt(columnSigning, i.Signing)
+		if err != nil {
+			return query, err
+		}
+		columns = append(columns, "signing_encrypted")
+		values = append(values, signing)
 	}
-	return query.Columns(columns...).Values(values...)
+	if i.Proxy != nil {
+		proxy, err := dataKey.encrypt(columnProxy, i.Proxy)
+		if err != nil {
+			return query, err
+		}
+		columns = append(columns, "proxy_encrypted")
+		values = append(values, proxy)
+	}
+	if i.Success != nil {
+		columns = append(columns, "success")
+		values = append(values, i.Success)
+	}
+	if i.Extract != nil {
+		columns = append(columns, "extract")
+		values = append(values, i.Extract)
+	}
+	if i.Condition != nil {
+		columns = append(columns, "condition")
+		values = append(values, i.Condition)
+	}
+	if i.Variables != nil {
+		variables, err := dataKey.encrypt(columnVariables, i.Variables)
+		if err != nil {
+			return query, err
+		}
+		columns = append(columns, "variables_encrypted")
+		values = append(values, variables)
+	}
+	if i.GroupID != uuid.Nil {
+		columns = append(columns, "group_id")
+		values = append(values, i.GroupID)
+	}
+	return query.Columns(columns...).Values(values...), nil
 }
 
 // CreateTask creates a new task.
+// Tasks with parents are created waiting until all parents are done,
+// or skipped if any of them has failed. Only new tasks must be sent to the queue.
 func (q taskDB) CreateTask(ctx context.Context, input *CreateTaskInput) (*models.Task, error) {
 	if input == nil {
 		return nil, fmt.Errorf("input is nil")
 	}
 
-	query := sq.Insert("tasks").Suffix("RETURNING id")
-	query = input.setInsertValues(query)
+	id := input.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+	dataKey, err := newTaskDataKey(q.keyring, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := q.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err = incrementCounters(ctx, tx, input.ClientID, input.CounterLimits); err != nil {
+		return nil, err
+	}
+	if input.MaxOutstandingTasks > 0 {
+		if err = checkOutstandingTasks(ctx, tx, input.ClientID, input.MaxOutstandingTasks); err != nil {
+			return nil, err
+		}
+	}
+
+	status := models.TaskStatusNew
+	if len(input.DependsOn) > 0 {
+		if status, err = lockParents(ctx, tx, input.ClientID, input.DependsOn); err != nil {
+			return nil, err
+		}
+	}
+
+	query, err := input.setInsertValues(sq.Insert("tasks"), dataKey, status)
+	if err != nil {
+		return nil, err
+	}
 
 	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
@@ -83,13 +246,33 @@ func (q taskDB) CreateTask(ctx context.Context, input *CreateTaskInput) (*models
 	}
 
 	task := &models.Task{
-		Status:  models.TaskStatusNew,
-		Method:  input.Method,
-		URL:     input.URL,
-		Headers: input.Headers,
-		Body:    input.Body,
+		ID:            dataKey.taskID,
+		Status:        status,
+		ClientID:      input.ClientID,
+		Priority:      input.priority(),
+		Queue:         input.Queue,
+		OrderingKey:   input.OrderingKey,
+		Method:        input.Method,
+		URL:           input.URL,
+		Headers:       input.Headers,
+		Body:          input.Body,
+		Signing:       input.Signing,
+		OAuth2Profile: input.OAuth2Profile,
+		TLSProfile:    input.TLSProfile,
+		Proxy:         input.Proxy,
+		Success:       input.Success,
+		Extract:       input.Extract,
+		DependsOn:     input.DependsOn,
+		Condition:     input.Condition,
+		Variables:     input.Variables,
 	}
-	return task, q.db.QueryRow(ctx, sqlQuery, args...).Scan(&task.ID)
+	if _, err = tx.Exec(ctx, sqlQuery, args...); err != nil {
+		return nil, err
+	}
+	if err = insertDependencies(ctx, tx, task.ID, input.DependsOn); err != nil {
+		return nil, err
+	}
+	return task, tx.Commit(ctx)
 }
 
 // GetTask gets task by id.
@@ -97,6 +280,10 @@ func (q taskDB) GetTask(ctx context.Context, id uuid.UUID) (_ *models.TaskWithRe
 	query := sq.Select(
 		"id",
 		"status",
+		"client_id",
+		"priority",
+		"COALESCE(queue, '')",
+		"COALESCE(ordering_key, '')",
 		"method",
 		"url",
 		"headers",
@@ -104,6 +291,26 @@ func (q taskDB) GetTask(ctx context.Context, id uuid.UUID) (_ *models.TaskWithRe
 		"response_status_code",
 		"response_headers",
 		"response_content_length",
+		"used_proxy",
+		"timings",
+		"failed_assertion",
+		"success",
+		"extract",
+		"condition",
+		"ARRAY(SELECT parent_id::text FROM task_dependencies WHERE task_id = tasks.id)",
+		"key_id",
+		"data_key",
+		"headers_encrypted",
+		"body_encrypted",
+		"response_headers_encrypted",
+		"signing_encrypted",
+		"proxy_encrypted",
+		"extracted_encrypted",
+		"variables_encrypted",
+		"oauth2_profile",
+		"tls_profile",
+		"lease_owner",
+		"lease_expires_at",
 	).
 		From("tasks").
 		Where(sq.Eq{"id": id})
@@ -114,9 +321,16 @@ func (q taskDB) GetTask(ctx context.Context, id uuid.UUID) (_ *models.TaskWithRe
 	}
 
 	task := &models.TaskWithResponseData{}
+	var keyID, oauth2Profile, tlsProfile *string
+	var dependsOn []string
+	var wrappedKey, headers, body, responseHeaders, signing, proxy, extracted, variables []byte
 	err = q.db.QueryRow(ctx, sqlQuery, args...).Scan(
 		&task.ID,
 		&task.Status,
+		&task.ClientID,
+		&task.Priority,
+		&task.Queue,
+		&task.OrderingKey,
 		&task.Method,
 		&task.URL,
 		&task.Headers,
@@ -124,6 +338,26 @@ func (q taskDB) GetTask(ctx context.Context, id uuid.UUID) (_ *models.TaskWithRe
 		&task.ResponseData.ResponseStatusCode,
 		&task.ResponseData.ResponseHeaders,
 		&task.ResponseData.ResponseContentLength,
+		&task.ResponseData.UsedProxy,
+		&task.ResponseData.Timings,
+		&task.ResponseData.FailedAssertion,
+		&task.Success,
+		&task.Extract,
+		&task.Condition,
+		&dependsOn,
+		&keyID,
+		&wrappedKey,
+		&headers,
+		&body,
+		&responseHeaders,
+		&signing,
+		&proxy,
+		&extracted,
+		&variables,
+		&oauth2Profile,
+		&tlsProfile,
+		&task.LeaseOwner,
+		&task.LeaseExpiresAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -131,52 +365,195 @@ func (q taskDB) GetTask(ctx context.Context, id uuid.UUID) (_ *models.TaskWithRe
 		}
 		return nil, false, err
 	}
+	if oauth2Profile != nil {
+		task.OAuth2Profile = *oauth2Profile
+	}
+	if tlsProfile != nil {
+		task.TLSProfile = *tlsProfile
+	}
+	for _, id := range dependsOn {
+		parentID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, false, err
+		}
+		task.DependsOn = append(task.DependsOn, parentID)
+	}
+
+	if keyID == nil {
+		return task, true, nil
+	}
+	dataKey, _, err := unwrapTaskDataKey(q.keyring, task.ID, *keyID, wrappedKey)
+	if err != nil {
+		return nil, false, err
+	}
+	for column, value := range map[string]struct {
+		data []byte
+		dst  interface{}
+	}{
+		columnHeaders:         {headers, &task.Headers},
+		columnBody:            {body, &task.Body},
+		columnResponseHeaders: {responseHeaders, &task.ResponseHeaders},
+		columnSigning:         {signing, &task.Signing},
+		columnProxy:           {proxy, &task.Proxy},
+		columnExtracted:       {extracted, &task.Extracted},
+		columnVariables:       {variables, &task.Variables},
+	} {
+		if value.data == nil {
+			continue
+		}
+		if err = dataKey.decrypt(column, value.data, value.dst); err != nil {
+			return nil, false, fmt.Errorf("unable to decrypt task %s: %w", column, err)
+		}
+	}
 	return task, true, nil
 }
 
+// TransitionError is returned when the task status can't change to the requested one,
+// e.g. the task has been claimed by another worker.
+type TransitionError struct {
+	ID   uuid.UUID
+	From models.TaskStatus
+	To   models.TaskStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("task %s status can't change from %s to %s", e.ID, e.From, e.To)
+}
+
 // UpdateTaskInput is input for UpdateTask.
 type UpdateTaskInput struct {
-	ID                    uuid.UUID
-	Status                *models.TaskStatus
+	ID     uuid.UUID
+	Status *models.TaskStatus
+	// Status the task is expected to have for the status update, any status which can change to the new one if nil.
+	FromStatus *models.TaskStatus
+	// ID of the worker which must hold the task lease for the update, if set
+	LeaseOwner            *string
 	ResponseStatusCode    *int
 	ResponseHeaders       map[string][]string
 	ResponseContentLength *int64
+	UsedProxy             *string
+	Timings               *models.Timings
+	FailedAssertion       *string
+	Extracted             map[string]jx.Raw
 }
 
 // setUpdateFields sets fields for update query.
-func (i *UpdateTaskInput) setUpdateFields(query sq.UpdateBuilder) sq.UpdateBuilder {
+// Response headers and extracted values are encrypted with the task data key.
+func (i *UpdateTaskInput) setUpdateFields(query sq.UpdateBuilder, dataKey *taskDataKey) (sq.UpdateBuilder, error) {
 	if i.Status != nil {
 		query = query.Set("status", *i.Status)
+		// The lease ends with the processing.
+		if *i.Status != models.TaskStatusInProcess {
+			query = query.Set("lease_owner", nil).Set("lease_expires_at", nil)
+		}
 	}
 	if i.ResponseStatusCode != nil {
 		query = query.Set("response_status_code", *i.ResponseStatusCode)
 	}
-	if i.ResponseHeaders != nil {
-		query = query.Set("response_headers", i.ResponseHeaders)
-	}
 	if i.ResponseContentLength != nil {
 		query = query.Set("response_content_length", *i.ResponseContentLength)
 	}
-	return query
+	if i.UsedProxy != nil {
+		query = query.Set("used_proxy", *i.UsedProxy)
+	}
+	if i.Timings != nil {
+		query = query.Set("timings", *i.Timings)
+	}
+	if i.FailedAssertion != nil {
+		query = query.Set("failed_assertion", *i.FailedAssertion)
+	}
+	if i.ResponseHeaders != nil {
+		headers, err := dataKey.encrypt(columnResponseHeaders, i.ResponseHeaders)
+		if err != nil {
+			return query, err
+		}
+		query = query.
+			Set("key_id", dataKey.keyID).
+			Set("data_key", dataKey.wrapped).
+			Set("response_headers_encrypted", headers).
+			Set("response_headers", nil)
+	}
+	if i.Extracted != nil {
+		extracted, err := dataKey.encrypt(columnExtracted, i.Extracted)
+		if err != nil {
+			return query, err
+		}
+		query = query.
+			Set("key_id", dataKey.keyID).
+			Set("data_key", dataKey.wrapped).
+			Set("extracted_encrypted", extracted)
+	}
+	return query, nil
 }
 
 // UpdateTask updates task.
+// Updates with response headers or extracted values lock the task row to get its data key.
+// Status updates only apply to tasks whose status can change to the new one, so concurrent updates
+// can't both claim the task. Returns *TransitionError otherwise. Updates of missing tasks are ignored.
 func (q taskDB) UpdateTask(ctx context.Context, input *UpdateTaskInput) error {
 	if input == nil || input.ID == uuid.Nil {
 		return fmt.Errorf("input is nil or id is empty")
 	}
 
+	tx, err := q.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var dataKey *taskDataKey
+	if input.ResponseHeaders != nil || input.Extracted != nil {
+		var exists bool
+		dataKey, exists, err = q.lockTaskDataKey(ctx, tx, input.ID)
+		if err != nil || !exists {
+			return err
+		}
+	}
+
 	query := sq.Update("tasks").Where(sq.Eq{"id": input.ID})
-	query = input.setUpdateFields(query)
+	if input.Status != nil {
+		if input.FromStatus == nil {
+			query = query.Where(sq.Eq{"status": input.Status.Predecessors()})
+		} else if input.FromStatus.CanTransitionTo(*input.Status) {
+			query = query.Where(sq.Eq{"status": *input.FromStatus})
+		} else {
+			return &TransitionError{ID: input.ID, From: *input.FromStatus, To: *input.Status}
+		}
+	}
+	if input.LeaseOwner != nil {
+		query = query.Where(sq.Eq{"lease_owner": *input.LeaseOwner})
+	}
+	query, err = input.setUpdateFields(query, dataKey)
+	if err != nil {
+		return err
+	}
 
 	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = q.db.Exec(ctx, sqlQuery, args...)
+	tag, err := tx.Exec(ctx, sqlQuery, args...)
 	if err != nil {
 		return err
 	}
-	return nil
+	if (input.Status != nil || input.LeaseOwner != nil) && tag.RowsAffected() == 0 {
+		return q.transitionError(ctx, tx, input)
+	}
+	return tx.Commit(ctx)
+}
+
+// transitionError returns *LeaseError or *TransitionError of the update which hasn't applied to the task,
+// nil if the task doesn't exist.
+func (q taskDB) transitionError(ctx context.Context, tx pgx.Tx, input *UpdateTaskInput) error {
+	lease, exists, err := getTaskLease(ctx, tx, input.ID)
+	if err != nil || !exists {
+		return err
+	}
+	if input.LeaseOwner != nil && (lease.owner == nil || *lease.owner != *input.LeaseOwner) {
+		return lease.error(input.ID)
+	}
+	return &TransitionError{ID: input.ID, From: lease.status, To: *input.Status}
 }
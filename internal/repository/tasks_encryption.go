@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"requester/internal/encryption"
+)
+
+// Encrypted task columns.
+// Each of them is stored encrypted in the "<column>_encrypted" column,
+// the plaintext column is kept only for rows written before encryption.
+// Signing, proxy, extracted values and variables have been encrypted from the start and have no plaintext columns.
+const (
+	columnHeaders         = "headers"
+	columnBody            = "body"
+	columnResponseHeaders = "response_headers"
+	columnSigning         = "signing"
+	columnProxy           = "proxy"
+	columnExtracted       = "extracted"
+	columnVariables       = "variables"
+)
+
+// taskDataKey is a per-task data key encrypting sensitive task columns.
+// The key itself is stored wrapped with a master key of the keyring.
+type taskDataKey struct {
+	taskID  uuid.UUID
+	keyID   string
+	wrapped []byte
+	cipher  *encryption.Cipher
+}
+
+// taskKeyAdditionalData binds the wrapped data key to the task.
+func taskKeyAdditionalData(taskID uuid.UUID) []byte {
+	return []byte("tasks/" + taskID.String())
+}
+
+// newTaskDataKey generates a new data key of the task wrapped with the primary key.
+func newTaskDataKey(keyring *encryption.Keyring, taskID uuid.UUID) (*taskDataKey, error) {
+	key, err := encryption.GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+	return wrapTaskDataKey(keyring, taskID, key)
+}
+
+// wrapTaskDataKey wraps the data key of the task with the primary key.
+func wrapTaskDataKey(keyring *encryption.Keyring, taskID uuid.UUID, key []byte) (*taskDataKey, error) {
+	keyID, wrapped, err := keyring.WrapKey(key, taskKeyAdditionalData(taskID))
+	if err != nil {
+		return nil, err
+	}
+	c, err := encryption.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return &taskDataKey{taskID: taskID, keyID: keyID, wrapped: wrapped, cipher: c}, nil
+}
+
+// unwrapTaskDataKey unwraps the stored data key of the task.
+func unwrapTaskDataKey(
+	keyring *encryption.Keyring,
+	taskID uuid.UUID,
+	keyID string,
+	wrapped []byte,
+) (*taskDataKey, []byte, error) {
+	key, err := keyring.UnwrapKey(keyID, wrapped, taskKeyAdditionalData(taskID))
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := encryption.NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &taskDataKey{taskID: taskID, keyID: keyID, wrapped: wrapped, cipher: c}, key, nil
+}
+
+// additionalData binds the encrypted value to the task column.
+func (k *taskDataKey) additionalData(column string) []byte {
+	return []byte("tasks/" + k.taskID.String() + "/" + column)
+}
+
+// encrypt encrypts JSON of the value for the task column.
+func (k *taskDataKey) encrypt(column string, v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return k.cipher.Encrypt(data, k.additionalData(column))
+}
+
+// decrypt decrypts the task column value into v.
+func (k *taskDataKey) decrypt(column string, data []byte, v interface{}) error {
+	plaintext, err := k.cipher.Decrypt(data, k.additionalData(column))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(plaintext, v)
+}
+
+// lockTaskDataKey locks the task row and returns its data key.
+// Rows written before encryption get a new data key.
+func (q taskDB) lockTaskDataKey(ctx context.Context, tx pgx.Tx, taskID uuid.UUID) (_ *taskDataKey, exists bool, _ error) {
+	query := sq.Select("key_id", "data_key").
+		From("tasks").
+		Where(sq.Eq{"id": taskID}).
+		Suffix("FOR UPDATE")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var keyID *string
+	var wrapped []byte
+	if err = tx.QueryRow(ctx, sqlQuery, args...).Scan(&keyID, &wrapped); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	if keyID == nil {
+		dataKey, err := newTaskDataKey(q.keyring, taskID)
+		return dataKey, true, err
+	}
+	dataKey, _, err := unwrapTaskDataKey(q.keyring, taskID, *keyID, wrapped)
+	return dataKey, true, err
+}
+
+// RotateTaskKeys re-encrypts a batch of tasks with the primary key of the keyring.
+// Data keys wrapped with other keys are re-wrapped,
+// plaintext columns of rows written before encryption are encrypted.
+// Returns the number of processed tasks, zero means there is nothing left to rotate.
+func RotateTaskKeys(ctx context.Context, db DBTX, keyring *encryption.Keyring, batchSize int) (int, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	query := sq.Select("id", "key_id", "data_key", "headers", "body", "response_headers").
+		From("tasks").
+		Where(sq.Or{
+			sq.Expr("key_id IS DISTINCT FROM ?", keyring.PrimaryID()),
+			sq.NotEq{"headers": nil},
+			sq.NotEq{"body": nil},
+			sq.NotEq{"response_headers": nil},
+		}).
+		OrderBy("id").
+		Limit(uint64(batchSize)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	type legacyTask struct {
+		id              uuid.UUID
+		keyID           *string
+		wrapped         []byte
+		headers         []byte
+		body            []byte
+		responseHeaders []byte
+	}
+	var tasks []legacyTask
+	rows, err := tx.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return 0, err
+	}
+	for rows.Next() {
+		var t legacyTask
+		if err = rows.Scan(&t.id, &t.keyID, &t.wrapped, &t.headers, &t.body, &t.responseHeaders); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		tasks = append(tasks, t)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, t := range tasks {
+		var dataKey *taskDataKey
+		if t.keyID == nil {
+			dataKey, err = newTaskDataKey(keyring, t.id)
+		} else {
+			var key []byte
+			if _, key, err = unwrapTaskDataKey(keyring, t.id, *t.keyID, t.wrapped); err == nil {
+				dataKey, err = wrapTaskDataKey(keyring, t.id, key)
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		update := sq.Update("tasks").
+			Set("key_id", dataKey.keyID).
+			Set("data_key", dataKey.wrapped).
+			Where(sq.Eq{"id": t.id})
+		for column, value := range map[string][]byte{
+			columnHeaders:         t.headers,
+			columnBody:            t.body,
+			columnResponseHeaders: t.responseHeaders,
+		} {
+			if value == nil {
+				continue
+			}
+			encrypted, err := dataKey.cipher.Encrypt(value, dataKey.additionalData(column))
+			if err != nil {
+				return 0, err
+			}
+			update = update.Set(column+"_encrypted", encrypted).Set(column, nil)
+		}
+
+		sqlQuery, args, err = update.PlaceholderFormat(sq.Dollar).ToSql()
+		if err != nil {
+			return 0, err
+		}
+		if _, err = tx.Exec(ctx, sqlQuery, args...); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(tasks), tx.Commit(ctx)
+}
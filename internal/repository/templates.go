@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/go-faster/jx"
+	"github.com/jackc/pgx/v4"
+	"requester/internal/models"
+)
+
+// TemplateRepository is a repository manager for client request templates.
+type TemplateRepository interface {
+	// CreateTemplate creates the next version of a template.
+	CreateTemplate(ctx context.Context, input *CreateTemplateInput) (*models.RequestTemplate, error)
+	// GetTemplate gets a version of a template, the latest one if the version is 0.
+	GetTemplate(ctx context.Context, clientID, name string, version int) (_ *models.RequestTemplate, exists bool, _ error)
+	// ListTemplates lists the latest versions of client templates.
+	ListTemplates(ctx context.Context, clientID string) ([]*models.RequestTemplate, error)
+	// DeleteTemplate deletes all versions of a template.
+	DeleteTemplate(ctx context.Context, clientID, name string) (deleted bool, _ error)
+}
+
+// templateDB is a repository manager for client request templates.
+type templateDB struct {
+	db DBTX
+}
+
+// NewTemplateDB inits new instance of templateDB.
+func NewTemplateDB(db DBTX) TemplateRepository {
+	return templateDB{
+		db: db,
+	}
+}
+
+// CreateTemplateInput is input for CreateTemplate.
+type CreateTemplateInput struct {
+	ClientID   string
+	Name       string
+	Method     string
+	URL        string
+	Headers    map[string]string
+	Body       map[string]jx.Raw
+	Parameters map[string]models.TemplateParameter
+}
+
+// CreateTemplate creates the next version of a template.
+// Concurrent creation of the same version fails on the primary key.
+func (q templateDB) CreateTemplate(ctx context.Context, input *CreateTemplateInput) (*models.RequestTemplate, error) {
+	if input == nil {
+		return nil, fmt.Errorf("input is nil")
+	}
+
+	parameters := input.Parameters
+	if parameters == nil {
+		parameters = map[string]models.TemplateParameter{}
+	}
+
+	query := sq.Insert("request_templates").
+		Columns("client_id", "name", "version", "method", "url", "headers", "body", "parameters").
+		Values(
+			input.ClientID,
+			input.Name,
+			sq.Expr(
+				"(SELECT COALESCE(MAX(version), 0) + 1 FROM request_templates WHERE client_id = ? AND name = ?)",
+				input.ClientID, input.Name,
+			),
+			input.Method,
+			input.URL,
+			input.Headers,
+			input.Body,
+			parameters,
+		).
+		Suffix("RETURNING version, created_at")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	template := &models.RequestTemplate{
+		ClientID:   input.ClientID,
+		Name:       input.Name,
+		Method:     input.Method,
+		URL:        input.URL,
+		Headers:    input.Headers,
+		Body:       input.Body,
+		Parameters: parameters,
+	}
+	return template, q.db.QueryRow(ctx, sqlQuery, args...).Scan(&template.Version, &template.CreatedAt)
+}
+
+// selectTemplates returns the select query of templates.
+func selectTemplates() sq.SelectBuilder {
+	return sq.Select(
+		"client_id",
+		"name",
+		"version",
+		"method",
+		"url",
+		"headers",
+		"body",
+		"parameters",
+		"created_at",
+	).
+		From("request_templates")
+}
+
+// scanTemplate scans a row of selectTemplates.
+func scanTemplate(row pgx.Row) (*models.RequestTemplate, error) {
+	template := &models.RequestTemplate{}
+	return template, row.Scan(
+		&template.ClientID,
+		&template.Name,
+		&template.Version,
+		&template.Method,
+		&template.URL,
+		&template.Headers,
+		&template.Body,
+		&template.Parameters,
+		&template.CreatedAt,
+	)
+}
+
+// GetTemplate gets a version of a template, the latest one if the version is 0.
+func (q templateDB) GetTemplate(
+	ctx context.Context,
+	clientID, name string,
+	version int,
+) (_ *models.RequestTemplate, exists bool, _ error) {
+	query := selectTemplates().Where(sq.Eq{"client_id": clientID, "name": name})
+	if version > 0 {
+		query = query.Where(sq.Eq{"version": version})
+	} else {
+		query = query.OrderBy("version DESC").Limit(1)
+	}
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	template, err := scanTemplate(q.db.QueryRow(ctx, sqlQuery, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return template, true, nil
+}
+
+// ListTemplates lists the latest versions of client templates.
+func (q templateDB) ListTemplates(ctx context.Context, clientID string) ([]*models.RequestTemplate, error) {
+	query := selectTemplates().
+		Options("DISTINCT ON (name)").
+		Where(sq.Eq{"client_id": clientID}).
+		OrderBy("name", "version DESC")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.db.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []*models.RequestTemplate
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+// DeleteTemplate deletes all versions of a template.
+func (q templateDB) DeleteTemplate(ctx context.Context, clientID, name string) (deleted bool, _ error) {
+	query := sq.Delete("request_templates").
+		Where(sq.Eq{"client_id": clientID, "name": name})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	tag, err := q.db.Exec(ctx, sqlQuery, args...)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
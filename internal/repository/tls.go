@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"requester/internal/encryption"
+	"requester/internal/models"
+)
+
+// TLSProfileRepository is a repository manager for client TLS profiles.
+// Private keys and proxy passwords are encrypted at rest.
+type TLSProfileRepository interface {
+	// PutProfile creates or updates a profile.
+	PutProfile(ctx context.Context, input *PutTLSProfileInput) (*models.TLSProfile, error)
+	// GetProfile gets a profile with decrypted private key and proxy password.
+	GetProfile(ctx context.Context, clientID, name string) (_ *models.TLSProfile, exists bool, _ error)
+	// ListProfiles lists client profiles without private keys and proxy passwords.
+	ListProfiles(ctx context.Context, clientID string) ([]*models.TLSProfile, error)
+	// DeleteProfile deletes a profile.
+	DeleteProfile(ctx context.Context, clientID, name string) (deleted bool, _ error)
+}
+
+// tlsProfileDB is a repository manager for client TLS profiles.
+type tlsProfileDB struct {
+	db     DBTX
+	cipher *encryption.Cipher
+}
+
+// NewTLSProfileDB inits new instance of tlsProfileDB.
+func NewTLSProfileDB(db DBTX, cipher *encryption.Cipher) TLSProfileRepository {
+	return tlsProfileDB{
+		db:     db,
+		cipher: cipher,
+	}
+}
+
+// tlsProfileAdditionalData binds the encrypted private key to the profile owner and name.
+func tlsProfileAdditionalData(clientID, name string) []byte {
+	return []byte(fmt.Sprintf("tls_profiles/%s/%s", clientID, name))
+}
+
+// tlsProfileProxyAdditionalData binds the encrypted proxy password to the profile owner and name.
+func tlsProfileProxyAdditionalData(clientID, name string) []byte {
+	return []byte(fmt.Sprintf("tls_profiles/%s/%s/proxy_password", clientID, name))
+}
+
+// PutTLSProfileInput is input for PutProfile.
+type PutTLSProfileInput struct {
+	ClientID   string
+	Name       string
+	ClientCert string
+	ClientKey  string
+	CABundle   string
+	MinVersion string
+	ServerName string
+	Proxy      *models.Proxy
+}
+
+// PutProfile creates or updates a profile.
+func (q tlsProfileDB) PutProfile(ctx context.Context, input *PutTLSProfileInput) (*models.TLSProfile, error) {
+	if input == nil {
+		return nil, fmt.Errorf("input is nil")
+	}
+
+	var proxyURL, proxyUsername string
+	var proxyPassword []byte
+	if input.Proxy != nil {
+		proxyURL, proxyUsername = input.Proxy.URL, input.Proxy.Username
+		if input.Proxy.Password != "" {
+			var err error
+			proxyPassword, err = q.cipher.Encrypt(
+				[]byte(input.Proxy.Password),
+				tlsProfileProxyAdditionalData(input.ClientID, input.Name),
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var clientKey []byte
+	if input.ClientKey != "" {
+		var err error
+		clientKey, err = q.cipher.Encrypt(
+			[]byte(input.ClientKey),
+			tlsProfileAdditionalData(input.ClientID, input.Name),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	query := sq.Insert("tls_profiles").
+		Columns(
+			"client_id",
+			"name",
+			"client_cert",
+			"client_key",
+			"ca_bundle",
+			"min_version",
+			"server_name",
+			"proxy_url",
+			"proxy_username",
+			"proxy_password",
+		).
+		Values(
+			input.ClientID,
+			input.Name,
+			input.ClientCert,
+			clientKey,
+			input.CABundle,
+			input.MinVersion,
+			input.ServerName,
+			proxyURL,
+			proxyUsername,
+			proxyPassword,
+		).
+		Suffix("ON CONFLICT (client_id, name) DO UPDATE SET " +
+			"client_cert = EXCLUDED.client_cert, " +
+			"client_key = EXCLUDED.client_key, " +
+			"ca_bundle = EXCLUDED.ca_bundle, " +
+			"min_version = EXCLUDED.min_version, " +
+			"server_name = EXCLUDED.server_name, " +
+			"proxy_url = EXCLUDED.proxy_url, " +
+			"proxy_username = EXCLUDED.proxy_username, " +
+			"proxy_password = EXCLUDED.proxy_password, " +
+			"updated_at = now() " +
+			"RETURNING created_at, updated_at")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &models.TLSProfile{
+		ClientID:   input.ClientID,
+		Name:       input.Name,
+		ClientCert: input.ClientCert,
+		ClientKey:  input.ClientKey,
+		CABundle:   input.CABundle,
+		MinVersion: input.MinVersion,
+		ServerName: input.ServerName,
+		Proxy:      input.Proxy,
+	}
+	return profile, q.db.QueryRow(ctx, sqlQuery, args...).Scan(&profile.CreatedAt, &profile.UpdatedAt)
+}
+
+// GetProfile gets a profile with decrypted private key and proxy password.
+func (q tlsProfileDB) GetProfile(ctx context.Context, clientID, name string) (_ *models.TLSProfile, exists bool, _ error) {
+	query := sq.Select(
+		"client_id",
+		"name",
+		"client_cert",
+		"client_key",
+		"ca_bundle",
+		"min_version",
+		"server_name",
+		"proxy_url",
+		"proxy_username",
+		"proxy_password",
+		"created_at",
+		"updated_at",
+	).
+		From("tls_profiles").
+		Where(sq.Eq{"client_id": clientID, "name": name})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	profile := &models.TLSProfile{}
+	var clientKey, proxyPassword []byte
+	proxy := &models.Proxy{}
+	err = q.db.QueryRow(ctx, sqlQuery, args...).Scan(
+		&profile.ClientID,
+		&profile.Name,
+		&profile.ClientCert,
+		&clientKey,
+		&profile.CABundle,
+		&profile.MinVersion,
+		&profile.ServerName,
+		&proxy.URL,
+		&proxy.Username,
+		&proxyPassword,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	if clientKey != nil {
+		plaintext, err := q.cipher.Decrypt(clientKey, tlsProfileAdditionalData(clientID, name))
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to decrypt tls profile %s key: %w", name, err)
+		}
+		profile.ClientKey = string(plaintext)
+	}
+	if proxy.URL != "" {
+		if proxyPassword != nil {
+			plaintext, err := q.cipher.Decrypt(proxyPassword, tlsProfileProxyAdditionalData(clientID, name))
+			if err != nil {
+				return nil, false, fmt.Errorf("unable to decrypt tls profile %s proxy password: %w", name, err)
+			}
+			proxy.Password = string(plaintext)
+		}
+		profile.Proxy = proxy
+	}
+	return profile, true, nil
+}
+
+// ListProfiles lists client profiles without private keys and proxy passwords.
+func (q tlsProfileDB) ListProfiles(ctx context.Context, clientID string) ([]*models.TLSProfile, error) {
+	query := sq.Select(
+		"client_id",
+		"name",
+		"client_cert",
+		"ca_bundle",
+		"min_version",
+		"server_name",
+		"proxy_url",
+		"proxy_username",
+		"created_at",
+		"updated_at",
+	).
+		From("tls_profiles").
+		Where(sq.Eq{"client_id": clientID}).
+		OrderBy("name")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.db.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []*models.TLSProfile
+	for rows.Next() {
+		profile := &models.TLSProfile{}
+		proxy := &models.Proxy{}
+		err = rows.Scan(
+			&profile.ClientID,
+			&profile.Name,
+			&profile.ClientCert,
+			&profile.CABundle,
+			&profile.MinVersion,
+			&profile.ServerName,
+			&proxy.URL,
+			&proxy.Username,
+			&profile.CreatedAt,
+			&profile.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if proxy.URL != "" {
+			profile.Proxy = proxy
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, rows.Err()
+}
+
+// DeleteProfile deletes a profile.
+func (q tlsProfileDB) DeleteProfile(ctx context.Context, clientID, name string) (deleted bool, _ error) {
+	query := sq.Delete("tls_profiles").
+		Where(sq.Eq{"client_id": clientID, "name": name})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	tag, err := q.db.Exec(ctx, sqlQuery, args...)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
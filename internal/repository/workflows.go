@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"requester/internal/encryption"
+	"requester/internal/models"
+)
+
+// WorkflowRepository is a repository manager for workflows.
+type WorkflowRepository interface {
+	// CreateWorkflow creates a workflow with tasks of its steps.
+	CreateWorkflow(ctx context.Context, input *CreateWorkflowInput) (*models.Workflow, error)
+	// GetWorkflow gets workflow by id with statuses of its steps.
+	GetWorkflow(ctx context.Context, id uuid.UUID) (_ *models.Workflow, exists bool, _ error)
+}
+
+// workflowDB is a repository manager for workflows.
+// Step tasks are created with the task repository, so they are encrypted as any other task.
+type workflowDB struct {
+	db      DBTX
+	keyring *encryption.Keyring
+}
+
+// NewWorkflowDB inits new instance of workflowDB.
+func NewWorkflowDB(db DBTX, keyring *encryption.Keyring) WorkflowRepository {
+	return workflowDB{
+		db:      db,
+		keyring: keyring,
+	}
+}
+
+// CreateWorkflowInput is input for CreateWorkflow.
+type CreateWorkflowInput struct {
+	ClientID string
+	// Steps ordered so that parents precede their dependents
+	Steps []CreateWorkflowStepInput
+	// Limits of the client rate counters incremented on creation, nil if unlimited
+	CounterLimits []CounterLimit
+}
+
+// CreateWorkflowStepInput is a step of CreateWorkflowInput.
+type CreateWorkflowStepInput struct {
+	Name string
+	// Task of the step, its parents must be tasks of the preceding steps
+	Task *CreateTaskInput
+}
+
+// CreateWorkflow creates a workflow with tasks of its steps in a single transaction.
+// Steps without parents are created new and must be sent to the queue, the others are waiting.
+func (q workflowDB) CreateWorkflow(ctx context.Context, input *CreateWorkflowInput) (*models.Workflow, error) {
+	if input == nil {
+		return nil, fmt.Errorf("input is nil")
+	}
+
+	tx, err := q.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err = incrementCounters(ctx, tx, input.ClientID, input.CounterLimits); err != nil {
+		return nil, err
+	}
+
+	workflow := &models.Workflow{ID: uuid.New(), ClientID: input.ClientID}
+	query := sq.Insert("workflows").
+		Columns("id", "client_id").
+		Values(workflow.ID, workflow.ClientID).
+		Suffix("RETURNING created_at")
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.QueryRow(ctx, sqlQuery, args...).Scan(&workflow.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	tasks := NewTaskDB(tx, q.keyring)
+	stepsQuery := sq.Insert("workflow_steps").Columns("workflow_id", "name", "position", "task_id")
+	for i, step := range input.Steps {
+		step.Task.ClientID = input.ClientID
+		task, err := tasks.CreateTask(ctx, step.Task)
+		if err != nil {
+			return nil, err
+		}
+		stepsQuery = stepsQuery.Values(workflow.ID, step.Name, i, task.ID)
+		workflow.Steps = append(workflow.Steps, models.WorkflowStep{
+			Name:   step.Name,
+			TaskID: task.ID,
+			Status: task.Status,
+		})
+	}
+
+	if len(input.Steps) > 0 {
+		sqlQuery, args, err = stepsQuery.PlaceholderFormat(sq.Dollar).ToSql()
+		if err != nil {
+			return nil, err
+		}
+		if _, err = tx.Exec(ctx, sqlQuery, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	workflow.Status = models.NewWorkflowStatus(workflow.Steps)
+	return workflow, tx.Commit(ctx)
+}
+
+// GetWorkflow gets workflow by id with statuses of its steps.
+func (q workflowDB) GetWorkflow(ctx context.Context, id uuid.UUID) (_ *models.Workflow, exists bool, _ error) {
+	query := sq.Select("id", "client_id", "created_at").
+		From("workflows").
+		Where(sq.Eq{"id": id})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	workflow := &models.Workflow{}
+	err = q.db.QueryRow(ctx, sqlQuery, args...).Scan(&workflow.ID, &workflow.ClientID, &workflow.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	stepsQuery := sq.Select("s.name", "s.task_id", "t.status").
+		From("workflow_steps s").
+		Join("tasks t ON t.id = s.task_id").
+		Where(sq.Eq{"s.workflow_id": id}).
+		OrderBy("s.position")
+
+	sqlQuery, args, err = stepsQuery.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	rows, err := q.db.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var step models.WorkflowStep
+		if err = rows.Scan(&step.Name, &step.TaskID, &step.Status); err != nil {
+			return nil, false, err
+		}
+		workflow.Steps = append(workflow.Steps, step)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	workflow.Status = models.NewWorkflowStatus(workflow.Steps)
+	return workflow, true, nil
+}
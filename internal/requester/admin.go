@@ -0,0 +1,24 @@
+package requester
+
+import (
+	"encoding/json"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// NewAdminHandler creates a new http.Handler exposing the worker state and metrics.
+func NewAdminHandler(breakers *Breakers, metrics *Metrics, logger *zap.Logger) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics)
+	mux.HandleFunc("/breakers", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(breakers.States()); err != nil {
+			logger.Error("Unable to encode breakers state", zap.Error(err))
+		}
+	})
+	return mux
+}
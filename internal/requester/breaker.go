@@ -0,0 +1,258 @@
+package requester
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"sort"
+	"sync"
+	"time"
+)
+
+// BreakerState is a state of a circuit breaker.
+type BreakerState string
+
+const (
+	BreakerStateClosed   BreakerState = "closed"
+	BreakerStateOpen     BreakerState = "open"
+	BreakerStateHalfOpen BreakerState = "half_open"
+)
+
+// metricValue returns the value of the state reported in metrics.
+func (s BreakerState) metricValue() int64 {
+	switch s {
+	case BreakerStateOpen:
+		return 2
+	case BreakerStateHalfOpen:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// BreakerMode defines what happens to tasks while the breaker is open.
+type BreakerMode string
+
+const (
+	// BreakerModeDefer returns tasks to the queue until the breaker half-opens.
+	BreakerModeDefer BreakerMode = "defer"
+	// BreakerModeFail fails tasks without making requests.
+	BreakerModeFail BreakerMode = "fail"
+)
+
+// CircuitOpenError is returned when the task is short-circuited by an open breaker.
+type CircuitOpenError struct {
+	Host string
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker of host %s is open", e.Host)
+}
+
+// BreakerStatus is a snapshot of a host circuit breaker.
+type BreakerStatus struct {
+	Host     string       `json:"host"`
+	State    BreakerState `json:"state"`
+	Requests int          `json:"requests"`
+	Failures int          `json:"failures"`
+	OpenedAt *time.Time   `json:"opened_at,omitempty"`
+}
+
+// breaker is a circuit breaker of a single host.
+type breaker struct {
+	state       BreakerState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int
+}
+
+// Breakers is a set of circuit breakers keyed by target host.
+// The breakers are local to the worker process.
+type Breakers struct {
+	cfg         BreakerConfig
+	mu          sync.Mutex
+	hosts       map[string]*breaker
+	now         func() time.Time
+	transitions instrument.Int64Counter
+}
+
+// NewBreakers creates a new set of circuit breakers.
+// The breakers state is reported to the meter provider.
+func NewBreakers(cfg BreakerConfig, meterProvider metric.MeterProvider) (*Breakers, error) {
+	if meterProvider == nil {
+		return nil, errors.New("must specify metric.MeterProvider")
+	}
+	meter := meterProvider.Meter("requester")
+	transitions, err := meter.Int64Counter(
+		"requester.breaker.transitions",
+		instrument.WithDescription("Number of circuit breaker state transitions"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Breakers{
+		cfg:         cfg,
+		hosts:       make(map[string]*breaker),
+		now:         time.Now,
+		transitions: transitions,
+	}
+
+	_, err = meter.Int64ObservableGauge(
+		"requester.breaker.state",
+		instrument.WithDescription("Circuit breaker state: 0 - closed, 1 - half open, 2 - open"),
+		instrument.WithInt64Callback(func(_ context.Context, observer instrument.Int64Observer) error {
+			for _, status := range b.States() {
+				observer.Observe(status.State.metricValue(), attribute.String("host", status.Host))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// enabled reports whether the breakers are enabled.
+func (b *Breakers) enabled() bool {
+	return b.cfg.FailureRatio > 0
+}
+
+// get returns the breaker of the host, creating it if needed.
+// Must be called with the mutex held.
+func (b *Breakers) get(host string) *breaker {
+	br, ok := b.hosts[host]
+	if !ok {
+		br = &breaker{state: BreakerStateClosed, windowStart: b.now()}
+		b.hosts[host] = br
+	}
+	return br
+}
+
+// setState moves the breaker to the state.
+// Must be called with the mutex held.
+func (b *Breakers) setState(host string, br *breaker, state BreakerState) {
+	now := b.now()
+	br.state = state
+	br.windowStart = now
+	br.requests = 0
+	br.failures = 0
+	br.probes = 0
+	if state == BreakerStateOpen {
+		br.openedAt = now
+	}
+	b.transitions.Add(
+		context.Background(), 1,
+		attribute.String("host", host),
+		attribute.String("state", string(state)),
+	)
+}
+
+// allow reports whether a request to the host is allowed.
+// If not, returns the time left until the breaker half-opens.
+// Every allowed request must be followed by record or cancel.
+func (b *Breakers) allow(host string) (wait time.Duration, allowed bool) {
+	if !b.enabled() {
+		return 0, true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br := b.get(host)
+	now := b.now()
+	switch br.state {
+	case BreakerStateOpen:
+		if left := br.openedAt.Add(b.cfg.OpenTimeout).Sub(now); left > 0 {
+			return left, false
+		}
+		b.setState(host, br, BreakerStateHalfOpen)
+		fallthrough
+	case BreakerStateHalfOpen:
+		if br.probes >= b.cfg.HalfOpenProbes {
+			return b.cfg.OpenTimeout, false
+		}
+		br.probes++
+	default:
+		if now.Sub(br.windowStart) >= b.cfg.Window {
+			br.windowStart = now
+			br.requests = 0
+			br.failures = 0
+		}
+	}
+	return 0, true
+}
+
+// record records the result of an allowed request to the host.
+func (b *Breakers) record(host string, failed bool) {
+	if !b.enabled() {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br := b.get(host)
+	switch br.state {
+	case BreakerStateHalfOpen:
+		if failed {
+			b.setState(host, br, BreakerStateOpen)
+		} else {
+			b.setState(host, br, BreakerStateClosed)
+		}
+	case BreakerStateClosed:
+		br.requests++
+		if failed {
+			br.failures++
+		}
+		if br.requests >= b.cfg.MinRequests &&
+			float64(br.failures)/float64(br.requests) >= b.cfg.FailureRatio {
+			b.setState(host, br, BreakerStateOpen)
+		}
+	}
+}
+
+// cancel releases an allowed request to the host that hasn't been made.
+func (b *Breakers) cancel(host string) {
+	if !b.enabled() {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if br := b.get(host); br.state == BreakerStateHalfOpen && br.probes > 0 {
+		br.probes--
+	}
+}
+
+// States returns snapshots of all breakers sorted by host.
+func (b *Breakers) States() []BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states := make([]BreakerStatus, 0, len(b.hosts))
+	for host, br := range b.hosts {
+		status := BreakerStatus{
+			Host:     host,
+			State:    br.state,
+			Requests: br.requests,
+			Failures: br.failures,
+		}
+		if br.state != BreakerStateClosed {
+			openedAt := br.openedAt
+			status.OpenedAt = &openedAt
+		}
+		states = append(states, status)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Host < states[j].Host
+	})
+	return states
+}
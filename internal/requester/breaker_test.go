@@ -0,0 +1,98 @@
+package requester
+
+import (
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Breakers(t *testing.T) {
+	breakers, err := NewBreakers(BreakerConfig{
+		Mode:           BreakerModeDefer,
+		FailureRatio:   0.5,
+		MinRequests:    4,
+		Window:         time.Minute,
+		OpenTimeout:    30 * time.Second,
+		HalfOpenProbes: 1,
+	}, metric.NewNoopMeterProvider())
+	require.NoError(t, err)
+
+	now := time.Now()
+	breakers.now = func() time.Time { return now }
+	host := "example.com"
+
+	for _, failed := range []bool{false, true, false, true} {
+		_, allowed := breakers.allow(host)
+		require.True(t, allowed)
+		breakers.record(host, failed)
+	}
+	require.Equal(t, BreakerStateOpen, breakers.States()[0].State)
+
+	now = now.Add(10 * time.Second)
+	wait, allowed := breakers.allow(host)
+	require.False(t, allowed)
+	require.Equal(t, 20*time.Second, wait)
+
+	now = now.Add(20 * time.Second)
+	_, allowed = breakers.allow(host)
+	require.True(t, allowed)
+	require.Equal(t, BreakerStateHalfOpen, breakers.States()[0].State)
+	_, allowed = breakers.allow(host)
+	require.False(t, allowed, "only one probe is allowed")
+
+	breakers.record(host, true)
+	require.Equal(t, BreakerStateOpen, breakers.States()[0].State)
+
+	now = now.Add(30 * time.Second)
+	_, allowed = breakers.allow(host)
+	require.True(t, allowed)
+	breakers.record(host, false)
+	require.Equal(t, BreakerStateClosed, breakers.States()[0].State)
+}
+
+func Test_Breakers_disabled(t *testing.T) {
+	breakers, err := NewBreakers(BreakerConfig{MinRequests: 1}, metric.NewNoopMeterProvider())
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, allowed := breakers.allow("example.com")
+		require.True(t, allowed)
+		breakers.record("example.com", true)
+	}
+	require.Empty(t, breakers.States())
+}
+
+func Test_NewAdminHandler_metrics(t *testing.T) {
+	metrics, err := NewMetrics()
+	require.NoError(t, err)
+	breakers, err := NewBreakers(BreakerConfig{FailureRatio: 0.5, MinRequests: 1, Window: time.Minute}, metrics.MeterProvider())
+	require.NoError(t, err)
+	_, allowed := breakers.allow("example.com")
+	require.True(t, allowed)
+	breakers.record("example.com", true)
+
+	handler := NewAdminHandler(breakers, metrics, zaptest.NewLogger(t, zaptest.Level(zap.PanicLevel)))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+
+	var state, transitions bool
+	for _, line := range strings.Split(string(body), "\n") {
+		if !strings.Contains(line, `host="example.com"`) {
+			continue
+		}
+		state = state || strings.HasPrefix(line, "requester_breaker_state{") && strings.HasSuffix(line, " 2")
+		transitions = transitions || strings.HasPrefix(line, "requester_breaker_transitions")
+	}
+	require.True(t, state, string(body))
+	require.True(t, transitions, string(body))
+}
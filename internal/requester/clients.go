@@ -0,0 +1,219 @@
+package requester
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"requester/internal/destination"
+	"requester/internal/models"
+	"requester/internal/tlsprofile"
+	"sync"
+	"time"
+)
+
+// maxRedirects is the redirect limit of clients with a dedicated CheckRedirect, as in net/http.
+const maxRedirects = 10
+
+// transportOptions are the settings of a dedicated task transport.
+type transportOptions struct {
+	tlsProfile *models.TLSProfile
+	proxy      *url.URL
+}
+
+// key identifies the transport in the cache.
+// Proxy credentials are part of the key as a hash, so clients with different credentials of the same proxy
+// have their own transports and passwords aren't kept in memory.
+func (o transportOptions) key() string {
+	key := ""
+	if o.tlsProfile != nil {
+		key = o.tlsProfile.ClientID + "/" + o.tlsProfile.Name
+	}
+	if o.proxy != nil {
+		proxy := *o.proxy
+		proxy.User = nil
+		key += " " + proxy.String()
+		if o.proxy.User != nil {
+			sum := sha256.Sum256([]byte(o.proxy.User.String()))
+			key += " " + hex.EncodeToString(sum[:])
+		}
+	}
+	return key
+}
+
+// version changes whenever the transport has to be rebuilt.
+func (o transportOptions) version() string {
+	if o.tlsProfile == nil {
+		return ""
+	}
+	return o.tlsProfile.UpdatedAt.Format(time.RFC3339Nano)
+}
+
+// cachedClient is a cached HTTP client with a dedicated transport.
+type cachedClient struct {
+	client *http.Client
+	// version is the version of the options the client was built with.
+	version string
+}
+
+// clientCache is a cache of HTTP clients with dedicated transports keyed by TLS profile and proxy.
+// The cache is local to the worker process.
+type clientCache struct {
+	mu      sync.Mutex
+	clients map[string]*cachedClient
+	// policy is the default policy for redirects of proxied requests.
+	policy *destination.Policy
+}
+
+// newClientCache creates a new client cache.
+func newClientCache(policy *destination.Policy) *clientCache {
+	return &clientCache{clients: make(map[string]*cachedClient), policy: policy}
+}
+
+// get returns the client of the options, building it from the base client if the options have changed.
+// The transport is cloned from the base one, so it keeps the destination checks of its dialer.
+func (c *clientCache) get(base *http.Client, opts transportOptions) (*http.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key, version := opts.key(), opts.version()
+	cached, ok := c.clients[key]
+	if ok && cached.version == version {
+		return cached.client, nil
+	}
+
+	roundTripper := base.Transport
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
+	}
+	transport, isTransport := roundTripper.(*http.Transport)
+	if !isTransport {
+		return nil, errors.New("dedicated transports require *http.Transport")
+	}
+	transport = transport.Clone()
+
+	if opts.tlsProfile != nil {
+		cfg, err := tlsprofile.ClientConfig(opts.tlsProfile)
+		if err != nil {
+			return nil, fmt.Errorf("tls profile %s: %w", opts.tlsProfile.Name, err)
+		}
+		transport.TLSClientConfig = cfg
+	}
+
+	checkRedirect := base.CheckRedirect
+	if opts.proxy != nil {
+		transport.Proxy = http.ProxyURL(opts.proxy)
+		checkRedirect = c.checkProxiedRedirect(base.CheckRedirect)
+	}
+
+	if ok {
+		cached.client.CloseIdleConnections()
+	}
+	client := &http.Client{
+		Transport:     transport,
+		CheckRedirect: checkRedirect,
+		Jar:           base.Jar,
+		Timeout:       base.Timeout,
+	}
+	c.clients[key] = &cachedClient{client: client, version: version}
+	return client, nil
+}
+
+// checkProxiedRedirect checks redirect targets of proxied requests against the policy,
+// since the proxy resolves them instead of the dialer.
+func (c *clientCache) checkProxiedRedirect(next func(*http.Request, []*http.Request) error) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		ctx := req.Context()
+		if err := destination.FromContext(ctx, c.policy).CheckResolved(ctx, req.URL.Hostname()); err != nil {
+			return err
+		}
+		if next != nil {
+			return next(req, via)
+		}
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+}
+
+// proxyURL builds the URL of the proxy with its credentials.
+func proxyURL(proxy *models.Proxy) (*url.URL, error) {
+	u, err := destination.ParseProxyURL(proxy.URL)
+	if err != nil {
+		return nil, err
+	}
+	if proxy.Username != "" || proxy.Password != "" {
+		u.User = url.UserPassword(proxy.Username, proxy.Password)
+	}
+	return u, nil
+}
+
+// taskClient returns the HTTP client and the context to send the task request to the target with.
+// Tasks with a TLS profile or a proxy are sent over a dedicated transport,
+// so connections with different client certificates or proxies are never shared.
+// The task proxy takes precedence over the TLS profile proxy, which takes precedence over the operator one.
+// Proxies resolve the target themselves, so it's checked against the policy beforehand.
+// The operator proxy is trusted, client proxies are checked by the dialer as any destination.
+func (r processor) taskClient(
+	ctx context.Context,
+	task *models.Task,
+	target *url.URL,
+	attempt *attempt,
+) (context.Context, *http.Client, error) {
+	opts := transportOptions{}
+	proxy := task.Proxy
+	if task.TLSProfile != "" {
+		profile, exists, err := r.tlsProfileRepository.GetProfile(ctx, task.ClientID, task.TLSProfile)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exists {
+			return nil, nil, fmt.Errorf("tls profile %s not found", task.TLSProfile)
+		}
+		opts.tlsProfile = profile
+		if proxy == nil {
+			proxy = profile.Proxy
+		}
+	}
+
+	var err error
+	trusted := false
+	if proxy != nil {
+		if opts.proxy, err = proxyURL(proxy); err != nil {
+			return nil, nil, fmt.Errorf("proxy: %w", err)
+		}
+	} else {
+		if opts.proxy, err = r.proxyFunc(target); err != nil {
+			return nil, nil, fmt.Errorf("operator proxy: %w", err)
+		}
+		trusted = true
+	}
+
+	if opts.tlsProfile == nil && opts.proxy == nil {
+		return ctx, r.client, nil
+	}
+
+	if opts.proxy != nil {
+		policy := destination.FromContext(ctx, r.policy)
+		if err = policy.CheckResolved(ctx, target.Hostname()); err != nil {
+			return nil, nil, err
+		}
+		if trusted {
+			ctx = destination.WithPolicy(ctx, policy.Trust(opts.proxy.Hostname()))
+		}
+
+		used := *opts.proxy
+		used.User = nil
+		attempt.proxy = used.String()
+	}
+
+	client, err := r.clients.get(r.client, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ctx, client, nil
+}
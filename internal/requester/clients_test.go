@@ -0,0 +1,163 @@
+package requester
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/url"
+	"requester/internal/destination"
+	"requester/internal/models"
+	"testing"
+	"time"
+)
+
+func Test_clientCache_get(t *testing.T) {
+	dialer := &net.Dialer{}
+	base := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+	}
+	profile := &models.TLSProfile{
+		ClientID:   "client",
+		Name:       "partner",
+		MinVersion: "1.3",
+		ServerName: "partner.internal",
+		UpdatedAt:  time.Now(),
+	}
+	policy, err := destination.NewPolicy(destination.Config{})
+	require.NoError(t, err)
+	clients := newClientCache(policy)
+
+	client, err := clients.get(base, transportOptions{tlsProfile: profile})
+	require.NoError(t, err)
+	require.NotSame(t, base.Transport, client.Transport, "transport must be dedicated")
+	require.Equal(t, base.Timeout, client.Timeout)
+	transport := client.Transport.(*http.Transport)
+	require.NotNil(t, transport.DialContext, "dialer must be kept")
+	require.Nil(t, transport.Proxy)
+	require.Equal(t, "partner.internal", transport.TLSClientConfig.ServerName)
+
+	cached, err := clients.get(base, transportOptions{tlsProfile: profile})
+	require.NoError(t, err)
+	require.Same(t, client, cached)
+
+	updated := *profile
+	updated.UpdatedAt = profile.UpdatedAt.Add(time.Second)
+	updated.ServerName = "partner2.internal"
+	rebuilt, err := clients.get(base, transportOptions{tlsProfile: &updated})
+	require.NoError(t, err)
+	require.NotSame(t, client, rebuilt)
+	require.Equal(t, "partner2.internal", rebuilt.Transport.(*http.Transport).TLSClientConfig.ServerName)
+
+	proxy := &url.URL{Scheme: "socks5", Host: "proxy.example.com:1080", User: url.UserPassword("user", "pass")}
+	proxied, err := clients.get(base, transportOptions{tlsProfile: &updated, proxy: proxy})
+	require.NoError(t, err)
+	require.NotSame(t, rebuilt, proxied, "proxied transport must be dedicated")
+	require.NotNil(t, proxied.CheckRedirect, "redirects must be checked")
+	proxyFunc := proxied.Transport.(*http.Transport).Proxy
+	require.NotNil(t, proxyFunc)
+	got, err := proxyFunc(&http.Request{URL: &url.URL{Scheme: "https", Host: "example.com"}})
+	require.NoError(t, err)
+	require.Equal(t, proxy.String(), got.String())
+
+	rotated := *proxy
+	rotated.User = url.UserPassword("user", "rotated")
+	reproxied, err := clients.get(base, transportOptions{tlsProfile: &updated, proxy: &rotated})
+	require.NoError(t, err)
+	require.NotSame(t, proxied, reproxied, "transport must be dedicated to new credentials")
+	cachedProxied, err := clients.get(base, transportOptions{tlsProfile: &updated, proxy: proxy})
+	require.NoError(t, err)
+	require.Same(t, proxied, cachedProxied, "transports of other credentials must be kept")
+	require.NotContains(t, transportOptions{proxy: proxy}.key(), "pass")
+
+	_, err = newClientCache(policy).get(
+		&http.Client{Transport: http.NewFileTransport(http.Dir("."))},
+		transportOptions{tlsProfile: &updated},
+	)
+	require.Error(t, err)
+}
+
+func Test_processor_taskClient(t *testing.T) {
+	policy, err := destination.NewPolicy(destination.Config{})
+	require.NoError(t, err)
+	base := &http.Client{Transport: &http.Transport{}}
+	operatorProxy := &url.URL{Scheme: "http", Host: "10.0.0.1:3128"}
+	r := processor{
+		clients: newClientCache(policy),
+		proxyFunc: func(target *url.URL) (*url.URL, error) {
+			if target.Hostname() == "direct.example.com" {
+				return nil, nil
+			}
+			return operatorProxy, nil
+		},
+		policy: policy,
+		client: base,
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		task      *models.Task
+		target    string
+		wantProxy string
+		wantBase  bool
+		wantErr   bool
+	}{
+		{
+			name:     "direct",
+			task:     &models.Task{},
+			target:   "http://direct.example.com",
+			wantBase: true,
+		},
+		{
+			name:      "operator_proxy",
+			task:      &models.Task{},
+			target:    "http://1.1.1.1",
+			wantProxy: "http://10.0.0.1:3128",
+		},
+		{
+			name: "task_proxy",
+			task: &models.Task{
+				Proxy: &models.Proxy{URL: "socks5://proxy.example.com:1080", Username: "user", Password: "pass"},
+			},
+			target:    "http://1.1.1.1",
+			wantProxy: "socks5://proxy.example.com:1080",
+		},
+		{
+			name:    "task_proxy_to_private_target",
+			task:    &models.Task{Proxy: &models.Proxy{URL: "http://proxy.example.com:3128"}},
+			target:  "http://127.0.0.1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid_task_proxy",
+			task:    &models.Task{Proxy: &models.Proxy{URL: "ftp://proxy.example.com"}},
+			target:  "http://direct.example.com",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := url.Parse(tt.target)
+			require.NoError(t, err)
+			requestAttempt := &attempt{}
+
+			gotCtx, client, err := r.taskClient(ctx, tt.task, target, requestAttempt)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantProxy, requestAttempt.proxy)
+			if tt.wantBase {
+				require.Same(t, base, client)
+				return
+			}
+			require.NotSame(t, base, client)
+
+			trusted := destination.FromContext(gotCtx, policy) != policy
+			require.Equal(t, tt.task.Proxy == nil, trusted, "only the operator proxy must be trusted")
+		})
+	}
+}
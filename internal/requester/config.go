@@ -1,19 +1,104 @@
 package requester
 
 import (
+	"fmt"
 	"github.com/kelseyhightower/envconfig"
+	"requester/internal/models"
+	"requester/internal/routing"
+	"time"
 )
 
 // Config for Requester.
 type Config struct {
 	Workers   int    `envconfig:"WORKERS" default:"3"`
 	TaskQueue string `envconfig:"TASK_QUEUE" default:"task-queue"`
+	// Names of the task queues polled by the worker, TASK_QUEUE if empty.
+	// Separate deployments serve the queues of separate traffic classes.
+	Queues []string `envconfig:"WORKER_QUEUES"`
+	// Shares of receives from the queue of each priority while the others have messages too.
+	// Queues of priorities with zero weight aren't polled.
+	PriorityWeights    map[models.TaskPriority]int `envconfig:"PRIORITY_WEIGHTS" default:"high:6,normal:3,low:1"`
+	AdminListenAddress string                      `envconfig:"ADMIN_LISTEN_ADDR" default:":3001"`
+	LeaseConfig
+	HostLimitsConfig
+	BreakerConfig
+	OAuth2Config
+	ProxyConfig
+	ResponseConfig
+}
+
+// LeaseConfig is the config of task processing leases.
+type LeaseConfig struct {
+	// ID of the worker holding leases, the hostname with a random suffix if empty.
+	WorkerID string `envconfig:"WORKER_ID"`
+	// Leases are extended while the request is in progress, a third of the TTL before they expire.
+	LeaseTTL time.Duration `envconfig:"TASK_LEASE_TTL" default:"1m"`
+}
+
+// HostLimitsConfig is the default outbound limits per target host.
+// Zero value disables the limit.
+type HostLimitsConfig struct {
+	MaxConcurrent     int           `envconfig:"HOST_MAX_CONCURRENT" default:"0"`
+	RequestsPerSecond float64       `envconfig:"HOST_REQUESTS_PER_SECOND" default:"0"`
+	Burst             int           `envconfig:"HOST_BURST" default:"1"`
+	SlotTTL           time.Duration `envconfig:"HOST_SLOT_TTL" default:"1m"`
+	DeferDelay        time.Duration `envconfig:"HOST_DEFER_DELAY" default:"5s"`
+}
+
+// BreakerConfig is the config of circuit breakers per target host.
+// Zero failure ratio disables the breakers.
+type BreakerConfig struct {
+	Mode           BreakerMode   `envconfig:"BREAKER_MODE" default:"defer"`
+	FailureRatio   float64       `envconfig:"BREAKER_FAILURE_RATIO" default:"0"`
+	MinRequests    int           `envconfig:"BREAKER_MIN_REQUESTS" default:"10"`
+	Window         time.Duration `envconfig:"BREAKER_WINDOW" default:"1m"`
+	OpenTimeout    time.Duration `envconfig:"BREAKER_OPEN_TIMEOUT" default:"30s"`
+	HalfOpenProbes int           `envconfig:"BREAKER_HALF_OPEN_PROBES" default:"1"`
+}
+
+// OAuth2Config is the config of OAuth2 access tokens cached by the worker.
+type OAuth2Config struct {
+	// Tokens are refreshed this long before they expire, at most half of their lifetime.
+	TokenExpiryMargin time.Duration `envconfig:"OAUTH2_TOKEN_EXPIRY_MARGIN" default:"30s"`
+	// Lifetime of tokens issued without expires_in.
+	DefaultTokenTTL time.Duration `envconfig:"OAUTH2_DEFAULT_TOKEN_TTL" default:"5m"`
+}
+
+// ProxyConfig is the operator proxy of outbound requests.
+// Tasks and TLS profiles with their own proxy don't use it.
+type ProxyConfig struct {
+	HTTPProxy  string `envconfig:"OUTBOUND_HTTP_PROXY"`
+	HTTPSProxy string `envconfig:"OUTBOUND_HTTPS_PROXY"`
+	// Comma-separated hosts, domains and CIDRs requested directly, as in NO_PROXY.
+	NoProxy string `envconfig:"OUTBOUND_NO_PROXY"`
+}
+
+// ResponseConfig is the config of task response evaluation.
+type ResponseConfig struct {
+	// Max size of the response body read for success criteria and extraction, larger bodies fail the task.
+	MaxBodySize int64 `envconfig:"RESPONSE_MAX_BODY_SIZE" default:"1048576"`
+}
+
+// WorkerQueues returns the names of the task queues polled by the worker.
+func (c Config) WorkerQueues() []string {
+	if len(c.Queues) == 0 {
+		return []string{c.TaskQueue}
+	}
+	return c.Queues
 }
 
 // LoadConfig loads envs.
 func LoadConfig() (Config, error) {
 	c := Config{}
-	return c, envconfig.Process("", &c)
+	if err := envconfig.Process("", &c); err != nil {
+		return c, err
+	}
+	for _, name := range c.Queues {
+		if err := routing.ValidateQueueName(name); err != nil {
+			return c, fmt.Errorf("invalid WORKER_QUEUES: %w", err)
+		}
+	}
+	return c, nil
 }
 
 // MustConfig loads envs.
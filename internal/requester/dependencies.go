@@ -0,0 +1,136 @@
+package requester
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"requester/internal/dependency"
+	"requester/internal/models"
+	"requester/internal/repository"
+	"time"
+)
+
+// taskSender is an interface for sending messages to the task queue.
+type taskSender interface {
+	SendMessage(ctx context.Context, url *string, data interface{}, orderingKey string) error
+}
+
+// resolveParents returns a copy of the task with references to values extracted from its parents resolved.
+func (r processor) resolveParents(ctx context.Context, task *models.Task) (*models.Task, error) {
+	if len(task.DependsOn) == 0 {
+		return task, nil
+	}
+	return dependency.ResolveTask(task, r.parentLookup(ctx, task))
+}
+
+// conditionHolds reports whether the task condition on a parent value holds, if the task has one.
+func (r processor) conditionHolds(ctx context.Context, task *models.Task) (bool, error) {
+	if task.Condition == nil {
+		return true, nil
+	}
+	return dependency.Holds(task.Condition, r.parentLookup(ctx, task))
+}
+
+// parentLookup returns the lookup of values extracted from the task parents.
+// Each parent is fetched once per lookup.
+func (r processor) parentLookup(ctx context.Context, task *models.Task) dependency.Lookup {
+	parents := make(map[uuid.UUID]*models.TaskWithResponseData)
+	return func(ref dependency.Reference) (jx.Raw, error) {
+		parent, ok := parents[ref.ParentID]
+		if !ok {
+			if !containsTaskID(task.DependsOn, ref.ParentID) {
+				return nil, fmt.Errorf("task %s is not a parent", ref.ParentID)
+			}
+			var exists bool
+			var err error
+			parent, exists, err = r.taskRepository.GetTask(ctx, ref.ParentID)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, fmt.Errorf("parent task %s not found", ref.ParentID)
+			}
+			parents[ref.ParentID] = parent
+		}
+
+		value, ok := parent.Extracted[ref.Name]
+		if !ok {
+			return nil, fmt.Errorf("value %s isn't extracted from parent task %s", ref.Name, ref.ParentID)
+		}
+		return value, nil
+	}
+}
+
+// releaseDependents sends waiting dependents of the done task with all parents done to their queues of their priority.
+// Safe to call repeatedly, released dependents aren't waiting anymore.
+// Dependents which can't be sent are errored, as tasks which can't be sent on creation.
+func (r processor) releaseDependents(ctx context.Context, taskID uuid.UUID) error {
+	released, err := r.taskRepository.ReleaseDependents(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range released {
+		queueURL, err := r.taskQueues.URL(ctx, task.Queue, task.Priority)
+		if err == nil {
+			err = r.taskSender.SendMessage(ctx, queueURL, task.ID, task.OrderingKey)
+		}
+		if err != nil {
+			r.logger.Error("failed to send released task", zap.String("dependent_id", task.ID.String()), zap.Error(err))
+			updErr := r.taskRepository.UpdateTask(
+				ctx,
+				&repository.UpdateTaskInput{ID: task.ID, Status: models.TaskStatusError.Pointer()},
+			)
+			if updErr != nil {
+				return fmt.Errorf("failed to update released task status: %w", updErr)
+			}
+		}
+	}
+	return nil
+}
+
+// skipDependents skips waiting dependents of the task which has failed for good.
+func (r processor) skipDependents(ctx context.Context, taskID uuid.UUID) error {
+	skipped, err := r.taskRepository.SkipDependents(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if skipped > 0 {
+		r.logger.Info("dependent tasks skipped", zap.String("task_id", taskID.String()), zap.Int64("skipped", skipped))
+	}
+	return nil
+}
+
+// AbandonTask finishes the task which won't be received anymore: the task is errored unless it's finished
+// and its waiting dependents are skipped. Tasks leased by another worker are left to it.
+func (r processor) AbandonTask(ctx context.Context, taskID uuid.UUID) error {
+	task, exists, err := r.taskRepository.GetTask(ctx, taskID)
+	if err != nil || !exists {
+		return err
+	}
+	if task.Status == models.TaskStatusInProcess && task.LeaseOwner != nil && *task.LeaseOwner != r.workerID &&
+		task.LeaseExpiresAt != nil && task.LeaseExpiresAt.After(time.Now()) {
+		return nil
+	}
+	if task.Status == models.TaskStatusNew || task.Status == models.TaskStatusInProcess {
+		err = r.updateTask(ctx, task, &repository.UpdateTaskInput{Status: models.TaskStatusError.Pointer()})
+		var transitionErr *repository.TransitionError
+		if err != nil && !errors.As(err, &transitionErr) {
+			return err
+		}
+	}
+	return r.skipDependents(ctx, taskID)
+}
+
+// containsTaskID reports whether the IDs contain the ID.
+func containsTaskID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
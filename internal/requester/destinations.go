@@ -0,0 +1,32 @@
+package requester
+
+import (
+	"context"
+	"requester/internal/destination"
+	"requester/internal/models"
+)
+
+// checkDestination checks the task URL against the client destination policy.
+// Returns the policy to be enforced by the dialer on resolved addresses.
+func (r processor) checkDestination(ctx context.Context, task *models.Task) (*destination.Policy, error) {
+	u, err := destination.ParseURL(task.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := r.policy
+	rules, exists, err := r.destinationRepository.GetDestinationRules(ctx, task.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		if policy, err = policy.WithRules(rules); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = policy.CheckURL(u); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,161 @@
+package requester
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/url"
+	"requester/internal/models"
+	"requester/internal/repository"
+	"strings"
+	"time"
+)
+
+// DeferError is returned when the task can't be processed now and should be retried after the delay.
+type DeferError struct {
+	Delay  time.Duration
+	Reason string
+}
+
+func (e *DeferError) Error() string {
+	return fmt.Sprintf("task deferred for %s: %s", e.Delay, e.Reason)
+}
+
+// taskHost returns the lowercased target host of the task.
+func taskHost(task *models.Task) (string, error) {
+	u, err := url.Parse(task.URL)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(u.Hostname()), nil
+}
+
+// getHostLimits returns the default limits merged with the host overrides.
+func (r processor) getHostLimits(ctx context.Context, host string) (HostLimitsConfig, error) {
+	limits := r.cfg.HostLimitsConfig
+	overrides, exists, err := r.hostRepository.GetHostLimits(ctx, host)
+	if err != nil || !exists {
+		return limits, err
+	}
+	if overrides.MaxConcurrent != nil {
+		limits.MaxConcurrent = *overrides.MaxConcurrent
+	}
+	if overrides.RequestsPerSecond != nil {
+		limits.RequestsPerSecond = *overrides.RequestsPerSecond
+	}
+	if overrides.Burst != nil {
+		limits.Burst = *overrides.Burst
+	}
+	return limits, nil
+}
+
+// acquireHostSlot acquires a request slot of the task target host.
+// Returns *DeferError if the host limits are exhausted.
+// The returned function releases the slot.
+func (r processor) acquireHostSlot(ctx context.Context, host string, task *models.Task) (release func(), _ error) {
+	limits, err := r.getHostLimits(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if limits.MaxConcurrent <= 0 && limits.RequestsPerSecond <= 0 {
+		return func() {}, nil
+	}
+
+	acquired, err := r.hostRepository.AcquireHostSlot(ctx, &repository.AcquireHostSlotInput{
+		Host:              host,
+		TaskID:            task.ID,
+		MaxConcurrent:     limits.MaxConcurrent,
+		RequestsPerSecond: limits.RequestsPerSecond,
+		Burst:             limits.Burst,
+		TTL:               limits.SlotTTL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, &DeferError{Delay: limits.DeferDelay, Reason: "limits of host " + host + " exhausted"}
+	}
+
+	if limits.MaxConcurrent <= 0 {
+		return func() {}, nil
+	}
+	stopSlot := r.keepHostSlot(host, task.ID, limits.SlotTTL)
+	return func() {
+		stopSlot()
+		// The slot is released even if the task context is cancelled.
+		err := r.hostRepository.ReleaseHostSlot(context.Background(), host, task.ID)
+		if err != nil {
+			r.logger.Error("failed to release host slot", zap.Error(err))
+		}
+	}, nil
+}
+
+// returnRateToken returns the rate token of the host taken by acquireHostSlot for a request which wasn't sent.
+func (r processor) returnRateToken(ctx context.Context, host string) error {
+	limits, err := r.getHostLimits(ctx, host)
+	if err != nil || limits.RequestsPerSecond <= 0 {
+		return err
+	}
+	return r.hostRepository.ReturnRateToken(ctx, host, limits.Burst)
+}
+
+// waitRateToken takes a rate token of the host for a repeated request of the task holding a host slot,
+// waiting until a token is available.
+func (r processor) waitRateToken(ctx context.Context, host string) error {
+	limits, err := r.getHostLimits(ctx, host)
+	if err != nil || limits.RequestsPerSecond <= 0 {
+		return err
+	}
+	for {
+		// Without the concurrency limit only a rate token is taken.
+		taken, err := r.hostRepository.AcquireHostSlot(ctx, &repository.AcquireHostSlotInput{
+			Host:              host,
+			RequestsPerSecond: limits.RequestsPerSecond,
+			Burst:             limits.Burst,
+		})
+		if err != nil || taken {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(float64(time.Second) / limits.RequestsPerSecond)):
+		}
+	}
+}
+
+// keepHostSlot extends the host slot held by the task every third of its TTL,
+// so that requests taking longer than the TTL keep it.
+// The returned function stops extending the slot.
+func (r processor) keepHostSlot(host string, taskID uuid.UUID, ttl time.Duration) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			extended, err := r.hostRepository.ExtendHostSlot(ctx, host, taskID, ttl)
+			if err != nil {
+				if ctx.Err() == nil {
+					r.logger.Error("failed to extend host slot", zap.Error(err))
+				}
+				continue
+			}
+			if !extended {
+				r.logger.Warn("host slot expired", zap.String("host", host))
+				return
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
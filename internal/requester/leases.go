@@ -0,0 +1,94 @@
+package requester
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"os"
+	"requester/internal/models"
+	"requester/internal/repository"
+	"time"
+)
+
+// newWorkerID returns the configured worker ID or the hostname with a random suffix,
+// so workers of the same host don't share leases.
+func newWorkerID(cfg *Config) string {
+	if cfg.WorkerID != "" {
+		return cfg.WorkerID
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "worker"
+	}
+	return hostname + "-" + uuid.NewString()[:8]
+}
+
+// leaseDeferError returns *DeferError retrying the task once the lease of another worker expires.
+// The message isn't dropped, so the task is reclaimed if the worker is gone.
+func (r processor) leaseDeferError(owner string, expiresAt time.Time) *DeferError {
+	delay := time.Until(expiresAt)
+	if delay < time.Second {
+		delay = time.Second
+	}
+	return &DeferError{Delay: delay, Reason: "task is leased by " + owner}
+}
+
+// claimTask moves the task to in_process leased by the worker.
+// Returns *DeferError if the task is leased by another worker.
+func (r processor) claimTask(ctx context.Context, task *models.TaskWithResponseData) error {
+	err := r.taskRepository.ClaimTask(ctx, &repository.ClaimTaskInput{
+		ID:    task.ID,
+		Owner: r.workerID,
+		TTL:   r.cfg.LeaseTTL,
+	})
+	var leaseErr *repository.LeaseError
+	if errors.As(err, &leaseErr) && leaseErr.Owner != "" {
+		return r.leaseDeferError(leaseErr.Owner, leaseErr.ExpiresAt)
+	}
+	if err != nil {
+		return err
+	}
+	task.Status = models.TaskStatusInProcess
+	task.LeaseOwner = &r.workerID
+	return nil
+}
+
+// keepLease extends the lease of the claimed task until the returned function is called.
+// The returned context is cancelled if the lease is lost, so the request isn't completed by two workers.
+func (r processor) keepLease(ctx context.Context, task *models.Task) (context.Context, func()) {
+	leaseCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(r.cfg.LeaseTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-leaseCtx.Done():
+				return
+			case <-ticker.C:
+			}
+			extended, err := r.taskRepository.ExtendLease(leaseCtx, &repository.ClaimTaskInput{
+				ID:    task.ID,
+				Owner: r.workerID,
+				TTL:   r.cfg.LeaseTTL,
+			})
+			if err != nil {
+				if leaseCtx.Err() == nil {
+					r.logger.Error("failed to extend task lease", zap.Error(err))
+				}
+				continue
+			}
+			if !extended {
+				r.logger.Warn("task lease lost, cancelling the request")
+				cancel()
+				return
+			}
+		}
+	}()
+	return leaseCtx, func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,45 @@
+package requester
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"net/http"
+)
+
+// Metrics is the meter provider of the worker metrics, exported in the Prometheus format.
+type Metrics struct {
+	provider *sdkmetric.MeterProvider
+	handler  http.Handler
+}
+
+// NewMetrics creates a new meter provider with a Prometheus exporter on its own registry.
+func NewMetrics() (*Metrics, error) {
+	registry := prometheus.NewRegistry()
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, err
+	}
+	return &Metrics{
+		provider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter)),
+		handler:  promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+	}, nil
+}
+
+// MeterProvider returns the provider of the meters exported by the metrics.
+func (m *Metrics) MeterProvider() metric.MeterProvider {
+	return m.provider
+}
+
+// ServeHTTP serves the metrics for scraping.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.handler.ServeHTTP(w, r)
+}
+
+// Shutdown stops the meter provider.
+func (m *Metrics) Shutdown(ctx context.Context) error {
+	return m.provider.Shutdown(ctx)
+}
@@ -0,0 +1,148 @@
+package requester
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"requester/internal/models"
+	"strings"
+	"sync"
+	"time"
+)
+
+// oauth2Token is a cached access token of a client OAuth2 profile.
+type oauth2Token struct {
+	// mu serializes fetches of the token.
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+	// profileVersion is the update time of the profile the token was fetched with.
+	profileVersion time.Time
+}
+
+// oauth2Tokens is a cache of access tokens keyed by client and profile name.
+// The cache is local to the worker process.
+type oauth2Tokens struct {
+	mu     sync.Mutex
+	tokens map[string]*oauth2Token
+}
+
+// newOAuth2Tokens creates a new token cache.
+func newOAuth2Tokens() *oauth2Tokens {
+	return &oauth2Tokens{tokens: make(map[string]*oauth2Token)}
+}
+
+// get returns the cached token of the client profile, creating an empty one if needed.
+func (c *oauth2Tokens) get(clientID, profile string) *oauth2Token {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := clientID + "/" + profile
+	token, ok := c.tokens[key]
+	if !ok {
+		token = &oauth2Token{}
+		c.tokens[key] = token
+	}
+	return token
+}
+
+// oauth2TokenResponse is a successful response of the token endpoint.
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// oauth2Token returns the access token of the task OAuth2 profile.
+// The token is fetched if it's missing, expires soon, was fetched with an older profile
+// or equals the rejected token.
+func (r processor) oauth2Token(ctx context.Context, task *models.Task, rejected string) (string, error) {
+	profile, exists, err := r.oauth2ProfileRepository.GetProfile(ctx, task.ClientID, task.OAuth2Profile)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("oauth2 profile %s not found", task.OAuth2Profile)
+	}
+
+	token := r.oauth2Tokens.get(task.ClientID, profile.Name)
+	token.mu.Lock()
+	defer token.mu.Unlock()
+
+	if token.accessToken != "" &&
+		token.accessToken != rejected &&
+		token.profileVersion.Equal(profile.UpdatedAt) &&
+		time.Now().Before(token.expiresAt) {
+		return token.accessToken, nil
+	}
+
+	fetched, err := r.fetchOAuth2Token(ctx, profile)
+	if err != nil {
+		return "", err
+	}
+
+	ttl := r.cfg.OAuth2Config.DefaultTokenTTL
+	if fetched.ExpiresIn > 0 {
+		ttl = time.Duration(fetched.ExpiresIn) * time.Second
+	}
+	token.accessToken = fetched.AccessToken
+	token.expiresAt = tokenExpiresAt(time.Now(), ttl, r.cfg.OAuth2Config.TokenExpiryMargin)
+	token.profileVersion = profile.UpdatedAt
+	return token.accessToken, nil
+}
+
+// tokenExpiresAt returns the time the token fetched now with the TTL is refreshed at.
+// The margin is at most half of the TTL, so short-lived tokens are still cached.
+func tokenExpiresAt(now time.Time, ttl, margin time.Duration) time.Time {
+	if margin > ttl/2 {
+		margin = ttl / 2
+	}
+	return now.Add(ttl - margin)
+}
+
+// fetchOAuth2Token requests a new access token with the client credentials grant.
+// The client authenticates with HTTP Basic auth.
+func (r processor) fetchOAuth2Token(ctx context.Context, profile *models.OAuth2Profile) (*oauth2TokenResponse, error) {
+	secret, exists, err := r.secretRepository.GetSecret(ctx, profile.ClientID, profile.ClientSecretName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("secret %s not found", profile.ClientSecretName)
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(profile.Scopes) > 0 {
+		form.Set("scope", strings.Join(profile.Scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, profile.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(profile.OAuth2ClientID), url.QueryEscape(secret.Value))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("oauth2 profile %s token endpoint responded with %d", profile.Name, resp.StatusCode)
+	}
+
+	token := &oauth2TokenResponse{}
+	if err = json.NewDecoder(resp.Body).Decode(token); err != nil {
+		return nil, fmt.Errorf("oauth2 profile %s token response: %w", profile.Name, err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("oauth2 profile %s token response has no access_token", profile.Name)
+	}
+	return token, nil
+}
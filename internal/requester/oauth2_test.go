@@ -0,0 +1,26 @@
+package requester
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func Test_tokenExpiresAt(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		ttl    time.Duration
+		margin time.Duration
+		want   time.Time
+	}{
+		{"margin", time.Hour, 30 * time.Second, now.Add(time.Hour - 30*time.Second)},
+		{"short_ttl", 40 * time.Second, 30 * time.Second, now.Add(20 * time.Second)},
+		{"ttl_within_margin", 10 * time.Second, 30 * time.Second, now.Add(5 * time.Second)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tokenExpiresAt(now, tt.ttl, tt.margin))
+		})
+	}
+}
@@ -5,92 +5,305 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"golang.org/x/net/http/httpproxy"
 	"io"
 	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"requester/internal/destination"
+	"requester/internal/extract"
 	"requester/internal/models"
+	"requester/internal/queue"
 	"requester/internal/repository"
+	"requester/internal/signing"
+	"requester/internal/success"
+	"requester/internal/variables"
+	"time"
 )
 
 // Processor is a handler for processing tasks.
 type Processor interface {
 	ProcessTask(ctx context.Context, taskID uuid.UUID) error
+	AbandonTask(ctx context.Context, taskID uuid.UUID) error
 	WithLogger(logger *zap.Logger) Processor
 }
 
 // processor is a handler for processing tasks.
 type processor struct {
-	taskRepository repository.TaskRepository
-	client         *http.Client
-	logger         *zap.Logger
+	taskRepository          repository.TaskRepository
+	hostRepository          repository.HostRepository
+	destinationRepository   repository.DestinationRepository
+	secretRepository        repository.SecretRepository
+	oauth2ProfileRepository repository.OAuth2ProfileRepository
+	tlsProfileRepository    repository.TLSProfileRepository
+	taskSender              taskSender
+	taskQueues              *queue.TaskQueues
+	breakers                *Breakers
+	oauth2Tokens            *oauth2Tokens
+	clients                 *clientCache
+	proxyFunc               func(*url.URL) (*url.URL, error)
+	policy                  *destination.Policy
+	client                  *http.Client
+	cfg                     *Config
+	workerID                string
+	logger                  *zap.Logger
 }
 
 // New creates a new processor.
-func New(taskRepository repository.TaskRepository, client *http.Client, logger *zap.Logger) (Processor, error) {
+func New(
+	taskRepository repository.TaskRepository,
+	hostRepository repository.HostRepository,
+	destinationRepository repository.DestinationRepository,
+	secretRepository repository.SecretRepository,
+	oauth2ProfileRepository repository.OAuth2ProfileRepository,
+	tlsProfileRepository repository.TLSProfileRepository,
+	taskSender taskSender,
+	taskQueues *queue.TaskQueues,
+	breakers *Breakers,
+	policy *destination.Policy,
+	client *http.Client,
+	cfg *Config,
+	logger *zap.Logger,
+) (Processor, error) {
 	if taskRepository == nil {
 		return nil, errors.New("must specify repository.TaskRepository")
 	}
+	if hostRepository == nil {
+		return nil, errors.New("must specify repository.HostRepository")
+	}
+	if destinationRepository == nil {
+		return nil, errors.New("must specify repository.DestinationRepository")
+	}
+	if secretRepository == nil {
+		return nil, errors.New("must specify repository.SecretRepository")
+	}
+	if oauth2ProfileRepository == nil {
+		return nil, errors.New("must specify repository.OAuth2ProfileRepository")
+	}
+	if tlsProfileRepository == nil {
+		return nil, errors.New("must specify repository.TLSProfileRepository")
+	}
+	if taskSender == nil {
+		return nil, errors.New("must specify taskSender")
+	}
+	if taskQueues == nil {
+		return nil, errors.New("must specify *queue.TaskQueues")
+	}
+	if breakers == nil {
+		return nil, errors.New("must specify *Breakers")
+	}
+	if policy == nil {
+		return nil, errors.New("must specify *destination.Policy")
+	}
 	if client == nil {
 		return nil, errors.New("must specify *http.Client")
 	}
+	if cfg == nil {
+		return nil, errors.New("must specify *Config")
+	}
+	if cfg.LeaseTTL <= 0 {
+		return nil, errors.New("task lease TTL must be positive")
+	}
 	if logger == nil {
 		return nil, errors.New("must specify *zap.Logger")
 	}
+	// The operator proxy is configured as HTTP_PROXY, HTTPS_PROXY and NO_PROXY envs are.
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  cfg.HTTPProxy,
+		HTTPSProxy: cfg.HTTPSProxy,
+		NoProxy:    cfg.NoProxy,
+	}).ProxyFunc()
 	return processor{
-		taskRepository: taskRepository,
-		client:         client,
-		logger:         logger,
+		taskRepository:          taskRepository,
+		hostRepository:          hostRepository,
+		destinationRepository:   destinationRepository,
+		secretRepository:        secretRepository,
+		oauth2ProfileRepository: oauth2ProfileRepository,
+		tlsProfileRepository:    tlsProfileRepository,
+		taskSender:              taskSender,
+		taskQueues:              taskQueues,
+		breakers:                breakers,
+		oauth2Tokens:            newOAuth2Tokens(),
+		clients:                 newClientCache(policy),
+		proxyFunc:               proxyFunc,
+		policy:                  policy,
+		client:                  client,
+		cfg:                     cfg,
+		workerID:                newWorkerID(cfg),
+		logger:                  logger,
 	}, nil
 }
 
-// updateTask updates task.
-// Safe to call after task is done.
+// updateTask updates task if its status hasn't changed since it was read.
+// Returns *repository.TransitionError otherwise, the task is left unchanged then.
+// Updates of the claimed task return *repository.LeaseError if the lease has been lost.
+// Safe to call after task is done. Failed tasks can be retried, but don't become errored.
 func (r processor) updateTask(ctx context.Context, task *models.TaskWithResponseData, input *repository.UpdateTaskInput) error {
 	if task.Status == models.TaskStatusDone {
 		return nil
 	}
+	if task.Status == models.TaskStatusFailed && *input.Status == models.TaskStatusError {
+		return nil
+	}
 	input.ID = task.ID
+	input.FromStatus = task.Status.Pointer()
+	input.LeaseOwner = task.LeaseOwner
+	if err := r.taskRepository.UpdateTask(ctx, input); err != nil {
+		return err
+	}
 	task.Status = *input.Status
+	if task.Status != models.TaskStatusInProcess {
+		task.LeaseOwner = nil
+		task.LeaseExpiresAt = nil
+	}
 	task.ResponseHeaders = input.ResponseHeaders
 	task.ResponseStatusCode = input.ResponseStatusCode
 	task.ResponseContentLength = input.ResponseContentLength
-	return r.taskRepository.UpdateTask(ctx, input)
+	task.UsedProxy = input.UsedProxy
+	task.Timings = input.Timings
+	task.FailedAssertion = input.FailedAssertion
+	task.Extracted = input.Extracted
+	return nil
+}
+
+// attempt is the data of a request attempt recorded with the task response.
+type attempt struct {
+	// proxy is the URL of the proxy the request was sent through, without credentials.
+	proxy string
+	// timings is the timing breakdown of the last request sent.
+	timings models.Timings
+	// sentAt is the time the last request was sent, the start of its total duration.
+	sentAt time.Time
 }
 
 // makeRequest makes request to a service.
-func (r processor) makeRequest(ctx context.Context, task *models.Task) (*http.Response, error) {
+// Templates are rendered, secret and parent value references are resolved and the request is signed
+// right before sending, so each attempt gets fresh built-in values and resolved values never leave this function.
+// Requests with an OAuth2 profile are retried once with a refreshed token on 401.
+func (r processor) makeRequest(ctx context.Context, task *models.Task, attempt *attempt) (*http.Response, error) {
+	// Templates are rendered first, so that variables can reference secrets and parent values.
+	task, err := variables.Render(task, task.Variables, variables.NewBuiltins(task.ID))
+	if err != nil {
+		return nil, err
+	}
+	if task, err = r.resolveSecrets(ctx, task); err != nil {
+		return nil, err
+	}
+	// Parents are resolved after secrets, so that extracted values can't reference secrets.
+	if task, err = r.resolveParents(ctx, task); err != nil {
+		return nil, err
+	}
+
+	if task.OAuth2Profile == "" {
+		return r.sendRequest(ctx, task, "", attempt)
+	}
+
+	token, err := r.oauth2Token(ctx, task, "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.sendRequest(ctx, task, token, attempt)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if token, err = r.oauth2Token(ctx, task, token); err != nil {
+		return nil, err
+	}
+	// The retry is one more request to the host, so it takes a rate token too.
+	host, err := taskHost(task)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.waitRateToken(ctx, host); err != nil {
+		return nil, err
+	}
+	return r.sendRequest(ctx, task, token, attempt)
+}
+
+// sendRequest sends the resolved task request with the bearer token, if set.
+// The request is sent with the client of the task TLS profile and proxy, if set.
+// Its timings are recorded in the attempt.
+func (r processor) sendRequest(ctx context.Context, task *models.Task, token string, attempt *attempt) (*http.Response, error) {
+	var err error
+	var data []byte
 	var body io.Reader
 	if task.Body != nil {
-		data, err := json.Marshal(task.Body)
+		data, err = json.Marshal(task.Body)
 		if err != nil {
-			return nil, nil
+			return nil, err
 		}
 		body = bytes.NewReader(data)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, task.Method, task.URL, body)
 	if err != nil {
-		return nil, nil
+		return nil, err
+	}
+
+	ctx, client, err := r.taskClient(ctx, task, req.URL, attempt)
+	if err != nil {
+		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	for k, v := range task.Headers {
 		req.Header.Set(k, v)
 	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if task.Signing != nil {
+		if err = signing.Sign(req, data, task.Signing, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	trace := newRequestTrace()
+	attempt.sentAt = trace.start
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace.clientTrace()))
+	resp, err := client.Do(req)
+	attempt.timings = trace.result()
+	if err != nil {
+		return nil, &transportError{err: err}
+	}
+	return resp, nil
+}
+
+// transportError is an error of sending the request to the task target.
+type transportError struct {
+	err error
+}
 
-	return r.client.Do(req)
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
 }
 
 // WithLogger returns a new processor with a new logger.
 func (r processor) WithLogger(logger *zap.Logger) Processor {
-	return processor{
-		taskRepository: r.taskRepository,
-		client:         r.client,
-		logger:         logger,
-	}
+	r.logger = logger
+	return r
 }
 
 // ProcessTask processes task.
+// Returns *DeferError if the task target host limits are exhausted
+// or its circuit breaker is open, the task stays unchanged then.
+// Tasks failing their success criteria are failed, *success.AssertionError is returned
+// to retry them if the criteria allow it.
+// Waiting dependents are released once the task is done and skipped once it fails for good.
+// Tasks whose condition on a parent value doesn't hold are skipped along with their dependents.
+// Only a single worker claims the task by moving it to in_process with a lease extended during the request.
+// Returns *DeferError for deliveries of a task leased by another worker, so it's reclaimed once the lease expires.
 func (r processor) ProcessTask(ctx context.Context, taskID uuid.UUID) error {
 	logg := r.logger.With(zap.String("task_id", taskID.String()))
 
@@ -103,34 +316,202 @@ func (r processor) ProcessTask(ctx context.Context, taskID uuid.UUID) error {
 		return nil
 	}
 
-	if task.Status == models.TaskStatusDone {
+	switch {
+	case task.Status == models.TaskStatusDone:
 		logg.Info("task already done")
+		return r.releaseDependents(ctx, task.ID)
+	case task.Status == models.TaskStatusFailed && (task.Success == nil || !task.Success.Retry):
+		logg.Info("task already failed")
+		return r.skipDependents(ctx, task.ID)
+	case task.Status == models.TaskStatusWaiting:
+		logg.Info("task is waiting for parents")
 		return nil
+	case task.Status == models.TaskStatusSkipped:
+		logg.Info("task skipped")
+		return r.skipDependents(ctx, task.ID)
+	case task.Status == models.TaskStatusInProcess && task.LeaseOwner != nil && task.LeaseExpiresAt != nil &&
+		task.LeaseExpiresAt.After(time.Now()):
+		logg.Info("task is already being processed")
+		return r.leaseDeferError(*task.LeaseOwner, *task.LeaseExpiresAt)
+	case task.Status == models.TaskStatusInProcess:
+		logg.Info("reclaiming task with expired lease")
 	}
+
+	holds, err := r.conditionHolds(ctx, &task.Task)
+	if err != nil {
+		return err
+	}
+	if !holds {
+		logg.Info("task condition doesn't hold")
+		err = r.updateTask(ctx, task, &repository.UpdateTaskInput{Status: models.TaskStatusSkipped.Pointer()})
+		if err != nil {
+			return err
+		}
+		return r.skipDependents(ctx, task.ID)
+	}
+
+	policy, err := r.checkDestination(ctx, &task.Task)
+	if err != nil {
+		var forbiddenErr *destination.ForbiddenError
+		if errors.As(err, &forbiddenErr) {
+			updErr := r.updateTask(ctx, task, &repository.UpdateTaskInput{Status: models.TaskStatusError.Pointer()})
+			if updErr != nil {
+				logg.Error("failed to update task status", zap.Error(updErr))
+			}
+		}
+		return err
+	}
+	ctx = destination.WithPolicy(ctx, policy)
+
+	host, err := taskHost(&task.Task)
+	if err != nil {
+		return err
+	}
+	// The breaker is checked first, so that tasks of an open breaker don't hold host slots.
+	wait, allowed := r.breakers.allow(host)
+	if !allowed && r.cfg.BreakerConfig.Mode != BreakerModeFail {
+		return &DeferError{Delay: wait, Reason: "circuit breaker of host " + host + " is open"}
+	}
+	if allowed {
+		release, err := r.acquireHostSlot(ctx, host, &task.Task)
+		if err != nil {
+			r.breakers.cancel(host)
+			return err
+		}
+		defer release()
+	}
+
+	// The task of another worker isn't errored.
+	claimLost := false
 	defer func() {
+		if claimLost {
+			return
+		}
 		err := r.updateTask(ctx, task, &repository.UpdateTaskInput{Status: models.TaskStatusError.Pointer()})
 		if err != nil {
 			logg.Error("failed to update task status", zap.Error(err))
 		}
 	}()
 
-	err = r.updateTask(ctx, task, &repository.UpdateTaskInput{
-		Status: models.TaskStatusInProcess.Pointer(),
-	})
-	if err != nil {
+	if !allowed {
+		return &CircuitOpenError{Host: host}
+	}
+
+	if err = r.claimTask(ctx, task); err != nil {
+		r.breakers.cancel(host)
+		if tokenErr := r.returnRateToken(ctx, host); tokenErr != nil {
+			logg.Error("failed to return host rate token", zap.Error(tokenErr))
+		}
+		var deferErr *DeferError
+		if errors.As(err, &deferErr) {
+			logg.Info("task claimed by another worker")
+			claimLost = true
+			return err
+		}
+		var transitionErr *repository.TransitionError
+		if errors.As(err, &transitionErr) {
+			logg.Info("task status changed", zap.String("status", string(transitionErr.From)))
+			claimLost = true
+			return nil
+		}
 		return err
 	}
+	reqCtx, stopLease := r.keepLease(ctx, &task.Task)
+	defer stopLease()
 
-	resp, err := r.makeRequest(ctx, &task.Task)
+	// Only requests which went out are recorded by the breaker, errors of templates, secrets
+	// or OAuth2 tokens say nothing about the host.
+	sent, failed := false, false
+	defer func() {
+		if sent {
+			r.breakers.record(host, failed)
+		} else {
+			r.breakers.cancel(host)
+		}
+	}()
+
+	requestAttempt := &attempt{}
+	resp, err := r.makeRequest(reqCtx, &task.Task, requestAttempt)
 	if err != nil {
+		var transportErr *transportError
+		if errors.As(err, &transportErr) {
+			sent, failed = true, true
+		}
 		return err
 	}
 	defer resp.Body.Close()
+	sent, failed = true, resp.StatusCode >= http.StatusInternalServerError
 
-	return r.updateTask(ctx, task, &repository.UpdateTaskInput{
+	input := &repository.UpdateTaskInput{
 		Status:                models.TaskStatusDone.Pointer(),
 		ResponseStatusCode:    &resp.StatusCode,
 		ResponseHeaders:       resp.Header,
 		ResponseContentLength: &resp.ContentLength,
-	})
+		Timings:               &requestAttempt.timings,
+	}
+	if requestAttempt.proxy != "" {
+		input.UsedProxy = &requestAttempt.proxy
+	}
+
+	// Bodies over the limit fail the task, since assertions and extraction can't see all of them.
+	var assertionErr *success.AssertionError
+	body, err := r.readBody(&task.Task, resp)
+	requestAttempt.timings.Total = time.Since(requestAttempt.sentAt)
+	if err != nil && !errors.As(err, &assertionErr) {
+		return err
+	}
+	if assertionErr == nil && task.Extract != nil {
+		if input.Extracted, err = extract.Extract(task.Extract, resp.Header, body); err != nil {
+			return err
+		}
+	}
+	if assertionErr == nil && task.Success != nil {
+		if assertionErr, err = checkSuccess(task.Success, resp, body); err != nil {
+			return err
+		}
+	}
+	if assertionErr != nil {
+		input.Status = models.TaskStatusFailed.Pointer()
+		input.FailedAssertion = &assertionErr.Assertion
+	}
+	stopLease()
+	if err = r.updateTask(ctx, task, input); err != nil {
+		return err
+	}
+	if assertionErr == nil {
+		return r.releaseDependents(ctx, task.ID)
+	}
+	if task.Success != nil && task.Success.Retry {
+		return assertionErr
+	}
+	return r.skipDependents(ctx, task.ID)
+}
+
+// readBody reads the response body if the success criteria or extraction need it.
+// Returns nil otherwise, the body isn't stored.
+// Returns *success.AssertionError if the body exceeds the max body size.
+func (r processor) readBody(task *models.Task, resp *http.Response) ([]byte, error) {
+	if (task.Success == nil || !success.ReadsBody(task.Success)) && !extract.ReadsBody(task.Extract) {
+		return nil, nil
+	}
+	limit := r.cfg.ResponseConfig.MaxBodySize
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, &success.AssertionError{Assertion: fmt.Sprintf("body exceeds %d bytes", limit)}
+	}
+	return body, nil
+}
+
+// checkSuccess checks the response against the task success criteria.
+// Returns the failed assertion, if any.
+func checkSuccess(criteria *models.Success, resp *http.Response, body []byte) (*success.AssertionError, error) {
+	err := success.Check(criteria, resp.StatusCode, resp.Header, body)
+	var assertionErr *success.AssertionError
+	if errors.As(err, &assertionErr) {
+		return assertionErr, nil
+	}
+	return nil, err
 }
@@ -2,20 +2,32 @@ package requester
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 	"io"
 	"net/http"
+	"requester/internal/destination"
+	"requester/internal/encryption"
 	"requester/internal/models"
+	"requester/internal/queue"
 	"requester/internal/repository"
+	"requester/internal/success"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestProcessorTestSuite(t *testing.T) {
@@ -26,6 +38,25 @@ type ProcessorTestSuite struct {
 	suite.Suite
 	dbPool    *pgxpool.Pool
 	processor processor
+	tx        pgx.Tx
+	cipher    *encryption.Cipher
+	keyring   *encryption.Keyring
+}
+
+type testTaskSender struct {
+	sent []interface{}
+}
+
+func (s *testTaskSender) SendMessage(_ context.Context, _ *string, data interface{}, _ string) error {
+	s.sent = append(s.sent, data)
+	return nil
+}
+
+// testQueueURLGetter returns URLs of queues by their names.
+type testQueueURLGetter struct{}
+
+func (testQueueURLGetter) GetQueueURL(_ context.Context, queue string) (*string, error) {
+	return aws.String("sqs://" + queue), nil
 }
 
 func (suite *ProcessorTestSuite) SetupSuite() {
@@ -36,7 +67,31 @@ func (suite *ProcessorTestSuite) SetupSuite() {
 	suite.dbPool = repository.MustPool(repository.SetupPool(ctx, dbConfig))
 	logger := zaptest.NewLogger(suite.T(), zaptest.Level(zap.PanicLevel))
 
-	proc, err := New(repository.NewTaskDB(suite.dbPool), http.DefaultClient, logger)
+	cfg := MustConfig(LoadConfig())
+	encryptionCfg := encryption.MustConfig(encryption.LoadConfig())
+	suite.cipher = encryption.MustCipher(encryptionCfg)
+	suite.keyring = encryption.MustKeyring(encryptionCfg)
+	breakers, err := NewBreakers(cfg.BreakerConfig, metric.NewNoopMeterProvider())
+	suite.Require().NoError(err)
+	policy, err := destination.NewPolicy(destination.Config{})
+	suite.Require().NoError(err)
+	taskQueues, err := queue.NewTaskQueues(testQueueURLGetter{}, "task-queue")
+	suite.Require().NoError(err)
+	proc, err := New(
+		repository.NewTaskDB(suite.dbPool, suite.keyring),
+		repository.NewHostDB(suite.dbPool),
+		repository.NewDestinationDB(suite.dbPool),
+		repository.NewSecretDB(suite.dbPool, suite.cipher),
+		repository.NewOAuth2ProfileDB(suite.dbPool),
+		repository.NewTLSProfileDB(suite.dbPool, suite.cipher),
+		&testTaskSender{},
+		taskQueues,
+		breakers,
+		policy,
+		http.DefaultClient,
+		&cfg,
+		logger,
+	)
 	suite.Require().NoError(err)
 	suite.processor = proc.(processor)
 }
@@ -50,7 +105,16 @@ func (suite *ProcessorTestSuite) SetupTest() {
 	ctx := context.Background()
 	tx, err := suite.dbPool.Begin(ctx)
 	suite.Require().NoError(err)
-	suite.processor.taskRepository = repository.NewTaskDB(tx)
+	suite.tx = tx
+	suite.processor.taskRepository = repository.NewTaskDB(tx, suite.keyring)
+	suite.processor.hostRepository = repository.NewHostDB(tx)
+	suite.processor.destinationRepository = repository.NewDestinationDB(tx)
+	suite.processor.secretRepository = repository.NewSecretDB(tx, suite.cipher)
+	suite.processor.oauth2ProfileRepository = repository.NewOAuth2ProfileDB(tx)
+	suite.processor.oauth2Tokens = newOAuth2Tokens()
+	suite.processor.tlsProfileRepository = repository.NewTLSProfileDB(tx, suite.cipher)
+	suite.processor.clients = newClientCache(suite.processor.policy)
+	suite.processor.taskSender = &testTaskSender{}
 	suite.T().Cleanup(func() {
 		suite.Require().NoError(tx.Rollback(ctx))
 	})
@@ -141,12 +205,35 @@ func (suite *ProcessorTestSuite) Test_updateTask() {
 	}
 }
 
+func (suite *ProcessorTestSuite) Test_updateTask_claimed() {
+	ctx := context.Background()
+	task := suite.prepareTask(ctx)
+	first := &models.TaskWithResponseData{Task: *task}
+	second := &models.TaskWithResponseData{Task: *task}
+
+	claim := &repository.UpdateTaskInput{Status: models.TaskStatusInProcess.Pointer()}
+	suite.Require().NoError(suite.processor.updateTask(ctx, first, claim))
+
+	claim = &repository.UpdateTaskInput{Status: models.TaskStatusInProcess.Pointer()}
+	err := suite.processor.updateTask(ctx, second, claim)
+	var transitionErr *repository.TransitionError
+	suite.Require().ErrorAs(err, &transitionErr)
+	suite.Equal(models.TaskStatusInProcess, transitionErr.From)
+	suite.Equal(models.TaskStatusNew, second.Status)
+
+	err = suite.processor.updateTask(ctx, second, &repository.UpdateTaskInput{Status: models.TaskStatusError.Pointer()})
+	suite.Require().ErrorAs(err, &transitionErr)
+	stored, _, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+	suite.Require().NoError(err)
+	suite.Equal(models.TaskStatusInProcess, stored.Status)
+}
+
 func (suite *ProcessorTestSuite) Test_processTask_makeRequest() {
 	ctx := context.Background()
 	task := suite.prepareTask(ctx)
 	wantStatusCode, wantContentLength, wantHeaders := suite.prepareHttpMock(task, nil)
 
-	response, err := suite.processor.makeRequest(ctx, task)
+	response, err := suite.processor.makeRequest(ctx, task, &attempt{})
 	suite.Require().NoError(err)
 
 	suite.Equal(wantStatusCode, response.StatusCode)
@@ -159,7 +246,9 @@ func (suite *ProcessorTestSuite) Test_processTask_ProcessTask_error() {
 	task := suite.prepareTask(ctx)
 	suite.prepareHttpMock(task, errors.New("request failed"))
 
-	suite.Require().Error(suite.processor.ProcessTask(ctx, task.ID))
+	err := suite.processor.ProcessTask(ctx, task.ID)
+	var transportErr *transportError
+	suite.Require().ErrorAs(err, &transportErr)
 
 	taskWithResponse, exists, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
 	suite.Require().NoError(err)
@@ -182,4 +271,634 @@ func (suite *ProcessorTestSuite) Test_processTask_ProcessTask_ok() {
 	suite.Equal(wantStatusCode, *taskWithResponse.ResponseStatusCode)
 	suite.Equal(wantContentLength, *taskWithResponse.ResponseContentLength)
 	suite.Equal(wantHeaders, taskWithResponse.ResponseHeaders)
+	suite.Require().NotNil(taskWithResponse.Timings)
+	suite.Positive(taskWithResponse.Timings.Total)
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_ProcessTask_success() {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		success    *models.Success
+		wantStatus models.TaskStatus
+		wantRetry  bool
+	}{
+		{
+			"passed",
+			&models.Success{
+				StatusCodes:  []models.StatusCodeRange{{From: 200, To: 299}},
+				Headers:      []models.HeaderAssertion{{Name: "Header1", Value: "value1"}},
+				BodyContains: "body",
+			},
+			models.TaskStatusDone,
+			false,
+		},
+		{
+			"failed",
+			&models.Success{StatusCodes: []models.StatusCodeRange{{From: 200, To: 200}}},
+			models.TaskStatusFailed,
+			false,
+		},
+		{
+			"failed_with_retry",
+			&models.Success{BodyRegex: "^ok$", Retry: true},
+			models.TaskStatusFailed,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			task, err := suite.processor.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+				Method:  http.MethodPost,
+				URL:     "https://example.com",
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    map[string]jx.Raw{"foo": jx.Raw(`"bar"`)},
+				Success: tt.success,
+			})
+			suite.Require().NoError(err)
+			suite.prepareHttpMock(task, nil)
+
+			err = suite.processor.ProcessTask(ctx, task.ID)
+			if tt.wantRetry {
+				var assertionErr *success.AssertionError
+				suite.Require().ErrorAs(err, &assertionErr)
+			} else {
+				suite.Require().NoError(err)
+			}
+
+			stored, exists, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+			suite.Require().NoError(err)
+			suite.Require().True(exists)
+			suite.Equal(tt.wantStatus, stored.Status)
+			suite.Equal(tt.success, stored.Success)
+			if tt.wantStatus == models.TaskStatusFailed {
+				suite.Require().NotNil(stored.FailedAssertion)
+				suite.NotEmpty(*stored.FailedAssertion)
+			} else {
+				suite.Nil(stored.FailedAssertion)
+			}
+		})
+	}
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_ProcessTask_bodyTooLarge() {
+	ctx := context.Background()
+	maxBodySize := suite.processor.cfg.ResponseConfig.MaxBodySize
+	suite.processor.cfg.ResponseConfig.MaxBodySize = 3
+	suite.T().Cleanup(func() {
+		suite.processor.cfg.ResponseConfig.MaxBodySize = maxBodySize
+	})
+
+	task, err := suite.processor.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		Method:  http.MethodPost,
+		URL:     "https://example.com",
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    map[string]jx.Raw{"foo": jx.Raw(`"bar"`)},
+		Success: &models.Success{BodyContains: "bod"},
+	})
+	suite.Require().NoError(err)
+	suite.prepareHttpMock(task, nil)
+
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, task.ID))
+
+	stored, exists, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal(models.TaskStatusFailed, stored.Status)
+	suite.Require().NotNil(stored.FailedAssertion)
+	suite.Equal("body exceeds 3 bytes", *stored.FailedAssertion)
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_ProcessTask_failedWithoutSuccess() {
+	ctx := context.Background()
+	maxBodySize := suite.processor.cfg.ResponseConfig.MaxBodySize
+	suite.processor.cfg.ResponseConfig.MaxBodySize = 3
+	suite.T().Cleanup(func() {
+		suite.processor.cfg.ResponseConfig.MaxBodySize = maxBodySize
+	})
+
+	task, err := suite.processor.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		Method:  http.MethodPost,
+		URL:     "https://example.com",
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    map[string]jx.Raw{"foo": jx.Raw(`"bar"`)},
+		Extract: map[string]string{"id": "$.id"},
+	})
+	suite.Require().NoError(err)
+	suite.prepareHttpMock(task, nil)
+
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, task.ID))
+	// Redeliveries of the failed task without success criteria finish it as failed for good.
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, task.ID))
+	suite.Equal(1, httpmock.GetTotalCallCount())
+
+	stored, exists, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal(models.TaskStatusFailed, stored.Status)
+	suite.Nil(stored.Success)
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_ProcessTask_extract() {
+	ctx := context.Background()
+	task, err := suite.processor.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		Method:  http.MethodGet,
+		URL:     "https://example.com/orders/1",
+		Extract: map[string]string{"id": "$.order.id", "state": "$.order.state", "request_id": "X-Request-Id"},
+	})
+	suite.Require().NoError(err)
+	httpmock.RegisterResponder(
+		task.Method, task.URL,
+		func(req *http.Request) (*http.Response, error) {
+			response := httpmock.NewStringResponse(http.StatusOK, `{"order": {"id": 1, "lines": []}}`)
+			response.Header.Set("X-Request-Id", "req-1")
+			return response, nil
+		},
+	)
+	suite.T().Cleanup(httpmock.Reset)
+
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, task.ID))
+
+	stored, exists, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal(models.TaskStatusDone, stored.Status)
+	suite.Equal(task.Extract, stored.Extract)
+	suite.Equal(map[string]jx.Raw{
+		"id":         jx.Raw(`1`),
+		"state":      jx.Raw(`null`),
+		"request_id": jx.Raw(`"req-1"`),
+	}, stored.Extracted)
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_ProcessTask_dependencies() {
+	ctx := context.Background()
+	sender := &testTaskSender{}
+	suite.processor.taskSender = sender
+
+	parent, err := suite.processor.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		Method:  http.MethodPost,
+		URL:     "https://example.com/sessions",
+		Extract: map[string]string{"token": "$.token"},
+	})
+	suite.Require().NoError(err)
+	child, err := suite.processor.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		Method:    http.MethodGet,
+		URL:       "https://example.com/orders?token={{parent:" + parent.ID.String() + ".token}}",
+		DependsOn: []uuid.UUID{parent.ID},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(models.TaskStatusWaiting, child.Status)
+	failing, err := suite.processor.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		Method:  http.MethodPost,
+		URL:     "https://example.com/payments",
+		Success: &models.Success{StatusCodes: []models.StatusCodeRange{{From: 200, To: 200}}},
+	})
+	suite.Require().NoError(err)
+	skipped, err := suite.processor.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		Method:    http.MethodGet,
+		URL:       "https://example.com/receipts",
+		DependsOn: []uuid.UUID{parent.ID, failing.ID},
+	})
+	suite.Require().NoError(err)
+
+	httpmock.RegisterResponder(http.MethodPost, parent.URL, httpmock.NewStringResponder(http.StatusOK, `{"token": "a b"}`))
+	httpmock.RegisterResponder(http.MethodPost, failing.URL, httpmock.NewStringResponder(http.StatusAccepted, ""))
+	httpmock.RegisterResponder(
+		http.MethodGet, "https://example.com/orders",
+		func(req *http.Request) (*http.Response, error) {
+			suite.Equal("a b", req.URL.Query().Get("token"))
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		},
+	)
+	suite.T().Cleanup(httpmock.Reset)
+
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, child.ID), "waiting tasks aren't processed")
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, parent.ID))
+	suite.Equal([]interface{}{child.ID}, sender.sent, "only dependents with all parents done are released")
+
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, child.ID))
+	stored, _, err := suite.processor.taskRepository.GetTask(ctx, child.ID)
+	suite.Require().NoError(err)
+	suite.Equal(models.TaskStatusDone, stored.Status)
+
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, failing.ID))
+	stored, _, err = suite.processor.taskRepository.GetTask(ctx, skipped.ID)
+	suite.Require().NoError(err)
+	suite.Equal(models.TaskStatusSkipped, stored.Status)
+	suite.ElementsMatch([]uuid.UUID{parent.ID, failing.ID}, stored.DependsOn)
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_AbandonTask() {
+	ctx := context.Background()
+	parent := suite.prepareTask(ctx)
+	child, err := suite.processor.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		Method:    http.MethodGet,
+		URL:       "https://example.com/orders",
+		DependsOn: []uuid.UUID{parent.ID},
+	})
+	suite.Require().NoError(err)
+
+	// The deferred task is still new when its message runs out of receives.
+	suite.Require().NoError(suite.processor.AbandonTask(ctx, parent.ID))
+
+	stored, _, err := suite.processor.taskRepository.GetTask(ctx, parent.ID)
+	suite.Require().NoError(err)
+	suite.Equal(models.TaskStatusError, stored.Status)
+	stored, _, err = suite.processor.taskRepository.GetTask(ctx, child.ID)
+	suite.Require().NoError(err)
+	suite.Equal(models.TaskStatusSkipped, stored.Status)
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_ProcessTask_condition() {
+	ctx := context.Background()
+	parent, err := suite.processor.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		Method:  http.MethodPost,
+		URL:     "https://example.com/orders",
+		Extract: map[string]string{"state": "$.state"},
+	})
+	suite.Require().NoError(err)
+	child, err := suite.processor.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		Method:    http.MethodPost,
+		URL:       "https://example.com/charges",
+		DependsOn: []uuid.UUID{parent.ID},
+		Condition: &models.Condition{ParentID: parent.ID, Value: "state", Equals: jx.Raw(`"new"`)},
+	})
+	suite.Require().NoError(err)
+	grandchild, err := suite.processor.taskRepository.CreateTask(ctx, &repository.CreateTaskInput{
+		Method:    http.MethodPost,
+		URL:       "https://example.com/receipts",
+		DependsOn: []uuid.UUID{child.ID},
+	})
+	suite.Require().NoError(err)
+
+	httpmock.RegisterResponder(http.MethodPost, parent.URL, httpmock.NewStringResponder(http.StatusOK, `{"state": "paid"}`))
+	suite.T().Cleanup(httpmock.Reset)
+
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, parent.ID))
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, child.ID))
+	for _, id := range []uuid.UUID{child.ID, grandchild.ID} {
+		stored, _, err := suite.processor.taskRepository.GetTask(ctx, id)
+		suite.Require().NoError(err)
+		suite.Equal(models.TaskStatusSkipped, stored.Status)
+	}
+	suite.Equal(1, httpmock.GetTotalCallCount(), "skipped tasks aren't sent")
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_ProcessTask_deferred() {
+	ctx := context.Background()
+	task := suite.prepareTask(ctx)
+	suite.prepareHttpMock(task, nil)
+
+	_, err := suite.tx.Exec(
+		ctx,
+		"INSERT INTO host_limits (host, max_concurrent) VALUES ('example.com', 1);"+
+			"INSERT INTO host_slots (host, task_id, expires_at) "+
+			"VALUES ('example.com', gen_random_uuid(), now() + interval '1 minute')",
+	)
+	suite.Require().NoError(err)
+
+	err = suite.processor.ProcessTask(ctx, task.ID)
+	var deferErr *DeferError
+	suite.Require().ErrorAs(err, &deferErr)
+	suite.Equal(suite.processor.cfg.DeferDelay, deferErr.Delay)
+	suite.Zero(httpmock.GetTotalCallCount())
+
+	taskWithResponse, exists, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal(models.TaskStatusNew, taskWithResponse.Status)
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_returnRateToken() {
+	ctx := context.Background()
+	task := suite.prepareTask(ctx)
+	// Tokens aren't refilled, since now() doesn't advance within the test transaction.
+	_, err := suite.tx.Exec(
+		ctx, "INSERT INTO host_limits (host, requests_per_second, burst) VALUES ('example.com', 1, 1)",
+	)
+	suite.Require().NoError(err)
+
+	release, err := suite.processor.acquireHostSlot(ctx, "example.com", task)
+	suite.Require().NoError(err)
+	release()
+	_, err = suite.processor.acquireHostSlot(ctx, "example.com", task)
+	var deferErr *DeferError
+	suite.Require().ErrorAs(err, &deferErr)
+
+	suite.Require().NoError(suite.processor.returnRateToken(ctx, "example.com"))
+	release, err = suite.processor.acquireHostSlot(ctx, "example.com", task)
+	suite.Require().NoError(err, "returned token is taken again")
+	release()
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_keepHostSlot() {
+	ctx := context.Background()
+	// Slots are extended relative to now(), which doesn't advance within the test transaction.
+	suite.processor.hostRepository = repository.NewHostDB(suite.dbPool)
+	host, taskID, ttl := "slot.example.com", uuid.New(), 300*time.Millisecond
+	_, err := suite.dbPool.Exec(
+		ctx,
+		"INSERT INTO host_slots (host, task_id, expires_at) VALUES ($1, $2, now() + $3 * interval '1 second')",
+		host, taskID, ttl.Seconds(),
+	)
+	suite.Require().NoError(err)
+	suite.T().Cleanup(func() {
+		_, err := suite.dbPool.Exec(ctx, "DELETE FROM host_slots WHERE host = $1", host)
+		suite.Require().NoError(err)
+	})
+
+	stop := suite.processor.keepHostSlot(host, taskID, ttl)
+	time.Sleep(3 * ttl)
+	stop()
+
+	var held bool
+	err = suite.dbPool.QueryRow(
+		ctx, "SELECT expires_at > now() FROM host_slots WHERE host = $1 AND task_id = $2", host, taskID,
+	).Scan(&held)
+	suite.Require().NoError(err)
+	suite.True(held, "slot is extended past its TTL")
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_ProcessTask_leased() {
+	ctx := context.Background()
+	task := suite.prepareTask(ctx)
+	suite.prepareHttpMock(task, nil)
+	err := suite.processor.taskRepository.ClaimTask(ctx, &repository.ClaimTaskInput{
+		ID:    task.ID,
+		Owner: "other-worker",
+		TTL:   time.Minute,
+	})
+	suite.Require().NoError(err)
+
+	err = suite.processor.ProcessTask(ctx, task.ID)
+	var deferErr *DeferError
+	suite.Require().ErrorAs(err, &deferErr)
+	suite.InDelta(time.Minute, deferErr.Delay, float64(5*time.Second))
+	suite.Zero(httpmock.GetTotalCallCount())
+
+	stored, exists, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal(models.TaskStatusInProcess, stored.Status)
+	suite.Equal("other-worker", *stored.LeaseOwner)
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_ProcessTask_expiredLease() {
+	ctx := context.Background()
+	task := suite.prepareTask(ctx)
+	suite.prepareHttpMock(task, nil)
+	err := suite.processor.taskRepository.ClaimTask(ctx, &repository.ClaimTaskInput{
+		ID:    task.ID,
+		Owner: "gone-worker",
+		TTL:   -time.Second,
+	})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, task.ID))
+	suite.Equal(1, httpmock.GetTotalCallCount())
+
+	stored, exists, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal(models.TaskStatusDone, stored.Status)
+	suite.Nil(stored.LeaseOwner)
+
+	// The gone worker can't complete the reclaimed task.
+	err = suite.processor.taskRepository.UpdateTask(ctx, &repository.UpdateTaskInput{
+		ID:         task.ID,
+		Status:     models.TaskStatusError.Pointer(),
+		FromStatus: models.TaskStatusInProcess.Pointer(),
+		LeaseOwner: aws.String("gone-worker"),
+	})
+	var leaseErr *repository.LeaseError
+	suite.ErrorAs(err, &leaseErr)
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_ProcessTask_forbidden() {
+	ctx := context.Background()
+	task, err := suite.processor.taskRepository.CreateTask(
+		ctx, &repository.CreateTaskInput{
+			Method: http.MethodGet,
+			URL:    "http://169.254.169.254/latest/meta-data",
+		},
+	)
+	suite.Require().NoError(err)
+
+	err = suite.processor.ProcessTask(ctx, task.ID)
+	var forbiddenErr *destination.ForbiddenError
+	suite.Require().ErrorAs(err, &forbiddenErr)
+	suite.Zero(httpmock.GetTotalCallCount())
+
+	taskWithResponse, exists, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal(models.TaskStatusError, taskWithResponse.Status)
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_makeRequest_secrets() {
+	ctx := context.Background()
+	task, err := suite.processor.taskRepository.CreateTask(
+		ctx, &repository.CreateTaskInput{
+			ClientID: "secrets-client",
+			Method:   http.MethodPost,
+			URL:      "https://example.com",
+			Headers:  map[string]string{"Authorization": "Bearer {{secret:token}}"},
+			Body:     map[string]jx.Raw{"password": jx.Raw(`"{{secret:password}}"`)},
+		},
+	)
+	suite.Require().NoError(err)
+	for name, value := range map[string]string{"token": "t0ken", "password": `pa"ss`} {
+		_, err = suite.processor.secretRepository.PutSecret(ctx, &repository.PutSecretInput{
+			ClientID: task.ClientID,
+			Name:     name,
+			Value:    value,
+		})
+		suite.Require().NoError(err)
+	}
+
+	httpmock.RegisterResponder(
+		task.Method, task.URL,
+		func(req *http.Request) (*http.Response, error) {
+			suite.Equal("Bearer t0ken", req.Header.Get("Authorization"))
+			reqBody, _ := io.ReadAll(req.Body)
+			suite.JSONEq(`{"password": "pa\"ss"}`, string(reqBody))
+			return httpmock.NewStringResponse(http.StatusOK, "body"), nil
+		},
+	)
+	suite.T().Cleanup(httpmock.Reset)
+
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, task.ID))
+
+	stored, exists, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal(models.TaskStatusDone, stored.Status)
+	suite.Equal("Bearer {{secret:token}}", stored.Headers["Authorization"])
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_makeRequest_variables() {
+	ctx := context.Background()
+	task, err := suite.processor.taskRepository.CreateTask(
+		ctx, &repository.CreateTaskInput{
+			Method:    http.MethodPost,
+			URL:       "https://example.com/items/{{var:id}}",
+			Headers:   map[string]string{"Idempotency-Key": "{{uuid}}"},
+			Body:      map[string]jx.Raw{"task": jx.Raw(`"{{task_id}}"`), "name": jx.Raw(`"{{var:name}}"`)},
+			Variables: map[string]string{"id": "7", "name": `"Jo"`},
+		},
+	)
+	suite.Require().NoError(err)
+
+	var keys []string
+	httpmock.RegisterResponder(
+		task.Method, "https://example.com/items/7",
+		func(req *http.Request) (*http.Response, error) {
+			keys = append(keys, req.Header.Get("Idempotency-Key"))
+			reqBody, _ := io.ReadAll(req.Body)
+			suite.JSONEq(`{"task": "`+task.ID.String()+`", "name": "\"Jo\""}`, string(reqBody))
+			return httpmock.NewStringResponse(http.StatusOK, "body"), nil
+		},
+	)
+	suite.T().Cleanup(httpmock.Reset)
+
+	for i := 0; i < 2; i++ {
+		resp, err := suite.processor.makeRequest(ctx, task, &attempt{})
+		suite.Require().NoError(err)
+		resp.Body.Close()
+	}
+	suite.Require().Len(keys, 2)
+	suite.NotEqual(keys[0], keys[1], "built-ins are rendered on each attempt")
+
+	stored, _, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+	suite.Require().NoError(err)
+	suite.Equal(task.Variables, stored.Variables)
+	suite.Equal("{{uuid}}", stored.Headers["Idempotency-Key"])
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_makeRequest_signing() {
+	ctx := context.Background()
+	task, err := suite.processor.taskRepository.CreateTask(
+		ctx, &repository.CreateTaskInput{
+			ClientID: "signing-client",
+			Method:   http.MethodPost,
+			URL:      "https://example.com/orders",
+			Body:     map[string]jx.Raw{"id": jx.Raw(`1`)},
+			Signing: &models.Signing{
+				Type: models.SigningTypeHMACSHA256,
+				HMAC: &models.HMACSigning{Key: "{{secret:hmac_key}}"},
+			},
+		},
+	)
+	suite.Require().NoError(err)
+	_, err = suite.processor.secretRepository.PutSecret(ctx, &repository.PutSecretInput{
+		ClientID: task.ClientID,
+		Name:     "hmac_key",
+		Value:    "key",
+	})
+	suite.Require().NoError(err)
+
+	httpmock.RegisterResponder(
+		task.Method, task.URL,
+		func(req *http.Request) (*http.Response, error) {
+			reqBody, _ := io.ReadAll(req.Body)
+			mac := hmac.New(sha256.New, []byte("key"))
+			mac.Write([]byte("POST\n/orders\n" + req.Header.Get("X-Timestamp") + "\n" + string(reqBody)))
+			suite.Equal(hex.EncodeToString(mac.Sum(nil)), req.Header.Get("X-Signature"))
+			return httpmock.NewStringResponse(http.StatusOK, "body"), nil
+		},
+	)
+	suite.T().Cleanup(httpmock.Reset)
+
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, task.ID))
+
+	stored, exists, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal(models.TaskStatusDone, stored.Status)
+	suite.Equal("{{secret:hmac_key}}", stored.Signing.HMAC.Key)
+}
+
+func (suite *ProcessorTestSuite) Test_processTask_makeRequest_oauth2() {
+	ctx := context.Background()
+	clientID := "oauth2-client"
+	_, err := suite.processor.secretRepository.PutSecret(ctx, &repository.PutSecretInput{
+		ClientID: clientID,
+		Name:     "partner_secret",
+		Value:    "s3cr3t",
+	})
+	suite.Require().NoError(err)
+	_, err = suite.processor.oauth2ProfileRepository.PutProfile(ctx, &repository.PutOAuth2ProfileInput{
+		ClientID:         clientID,
+		Name:             "partner",
+		TokenURL:         "https://auth.example.com/token",
+		OAuth2ClientID:   "requester",
+		ClientSecretName: "partner_secret",
+		Scopes:           []string{"orders.read"},
+	})
+	suite.Require().NoError(err)
+	task, err := suite.processor.taskRepository.CreateTask(
+		ctx, &repository.CreateTaskInput{
+			ClientID:      clientID,
+			Method:        http.MethodGet,
+			URL:           "https://example.com/orders",
+			OAuth2Profile: "partner",
+		},
+	)
+	suite.Require().NoError(err)
+
+	tokens := []string{"revoked", "fresh"}
+	httpmock.RegisterResponder(
+		http.MethodPost, "https://auth.example.com/token",
+		func(req *http.Request) (*http.Response, error) {
+			clientID, secret, _ := req.BasicAuth()
+			suite.Equal("requester", clientID)
+			suite.Equal("s3cr3t", secret)
+			suite.Require().NoError(req.ParseForm())
+			suite.Equal("client_credentials", req.PostForm.Get("grant_type"))
+			suite.Equal("orders.read", req.PostForm.Get("scope"))
+
+			token := tokens[0]
+			tokens = tokens[1:]
+			return httpmock.NewJsonResponse(http.StatusOK, map[string]interface{}{
+				"access_token": token,
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			})
+		},
+	)
+	httpmock.RegisterResponder(
+		task.Method, task.URL,
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Authorization") != "Bearer fresh" {
+				return httpmock.NewStringResponse(http.StatusUnauthorized, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, "body"), nil
+		},
+	)
+	suite.T().Cleanup(httpmock.Reset)
+
+	// Tokens aren't refilled, since now() doesn't advance within the test transaction.
+	_, err = suite.tx.Exec(
+		ctx, "INSERT INTO host_limits (host, requests_per_second, burst) VALUES ('example.com', 1, 2)",
+	)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.processor.ProcessTask(ctx, task.ID))
+	suite.Empty(tokens, "token must be refreshed once")
+	var hostTokens float64
+	err = suite.tx.QueryRow(ctx, "SELECT tokens FROM host_rate_buckets WHERE host = 'example.com'").Scan(&hostTokens)
+	suite.Require().NoError(err)
+	suite.Zero(hostTokens, "the retry takes a rate token")
+
+	stored, exists, err := suite.processor.taskRepository.GetTask(ctx, task.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+	suite.Equal(models.TaskStatusDone, stored.Status)
+	suite.Equal(http.StatusOK, *stored.ResponseStatusCode)
+
+	token, err := suite.processor.oauth2Token(ctx, task, "")
+	suite.Require().NoError(err)
+	suite.Equal("fresh", token, "token must be cached")
 }
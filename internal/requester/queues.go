@@ -0,0 +1,104 @@
+package requester
+
+import (
+	"context"
+	"requester/internal/models"
+	"requester/internal/queue"
+	"time"
+)
+
+// maxWaitTime is the longest time a receive waits for messages, as long polling allows.
+const maxWaitTime = 10 * time.Second
+
+// Queue is a task queue polled by the worker.
+type Queue struct {
+	URL *string
+	// Share of receives from the queue while the other queues have messages too
+	Weight int
+}
+
+// PriorityQueues returns the task queues of the names and the priorities with positive weights,
+// from the highest priority. Queues of the same priority get the same weight.
+func PriorityQueues(
+	ctx context.Context,
+	taskQueues *queue.TaskQueues,
+	names []string,
+	weights map[models.TaskPriority]int,
+) ([]Queue, error) {
+	var queues []Queue
+	for _, priority := range models.TaskPriorities {
+		if weights[priority] <= 0 {
+			continue
+		}
+		for _, name := range names {
+			url, err := taskQueues.URL(ctx, name, priority)
+			if err != nil {
+				return nil, err
+			}
+			queues = append(queues, Queue{URL: url, Weight: weights[priority]})
+		}
+	}
+	return queues, nil
+}
+
+// queueScheduler picks the queue to receive messages from by smooth weighted round-robin,
+// so queues with messages get receives by their weights and low weight queues aren't starved.
+// It isn't safe for concurrent use.
+type queueScheduler struct {
+	queues  []Queue
+	current []int
+	total   int
+	last    int
+	// Number of receives in a row which got no messages
+	empty int
+}
+
+// newQueueScheduler creates a new scheduler of the queues, considered empty until messages are received.
+func newQueueScheduler(queues []Queue) *queueScheduler {
+	s := &queueScheduler{
+		queues:  queues,
+		current: make([]int, len(queues)),
+		empty:   len(queues),
+	}
+	for _, queue := range queues {
+		s.total += queue.Weight
+	}
+	return s
+}
+
+// next returns the queue to receive messages from next.
+func (s *queueScheduler) next() Queue {
+	s.last = 0
+	for i, queue := range s.queues {
+		s.current[i] += queue.Weight
+		if s.current[i] > s.current[s.last] {
+			s.last = i
+		}
+	}
+	s.current[s.last] -= s.total
+	return s.queues[s.last]
+}
+
+// received records the number of messages received from the last queue.
+func (s *queueScheduler) received(count int) {
+	if count > 0 {
+		s.empty = 0
+		return
+	}
+	s.empty++
+}
+
+// waitTime returns the time the next receive waits for messages.
+// While some queue has messages, receives don't wait, so empty queues don't delay the others.
+// Once all queues are empty, the long polling wait is split between them.
+func (s *queueScheduler) waitTime() time.Duration {
+	if s.empty < len(s.queues) {
+		return 0
+	}
+	// Long polling waits whole seconds.
+	wait := (maxWaitTime / time.Duration(len(s.queues))).Truncate(time.Second)
+	if wait < time.Second {
+		wait = time.Second
+	}
+	return wait
+}
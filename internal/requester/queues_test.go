@@ -0,0 +1,86 @@
+package requester
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+	"requester/internal/models"
+	"requester/internal/queue"
+	"testing"
+	"time"
+)
+
+func Test_queueScheduler(t *testing.T) {
+	high, normal, low := "sqs://high", "sqs://normal", "sqs://low"
+	scheduler := newQueueScheduler([]Queue{
+		{URL: &high, Weight: 6},
+		{URL: &normal, Weight: 3},
+		{URL: &low, Weight: 1},
+	})
+	require.Equal(t, 3*time.Second, scheduler.waitTime())
+
+	counts := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		queue := scheduler.next()
+		counts[*queue.URL]++
+		scheduler.received(1)
+		require.Zero(t, scheduler.waitTime())
+	}
+	require.Equal(t, map[string]int{high: 60, normal: 30, low: 10}, counts)
+
+	for i := 0; i < 2; i++ {
+		scheduler.next()
+		scheduler.received(0)
+		require.Zero(t, scheduler.waitTime())
+	}
+	scheduler.next()
+	scheduler.received(0)
+	require.Equal(t, 3*time.Second, scheduler.waitTime())
+}
+
+func Test_queueScheduler_single(t *testing.T) {
+	url := "sqs://task-queue"
+	scheduler := newQueueScheduler([]Queue{{URL: &url, Weight: 1}})
+	require.Equal(t, maxWaitTime, scheduler.waitTime())
+	require.Equal(t, &url, scheduler.next().URL)
+}
+
+func Test_PriorityQueues(t *testing.T) {
+	taskQueues, err := queue.NewTaskQueues(testQueueURLGetter{}, "task-queue")
+	require.NoError(t, err)
+	queues, err := PriorityQueues(
+		context.Background(),
+		taskQueues,
+		[]string{"fast", "ordered.fifo"},
+		map[models.TaskPriority]int{models.TaskPriorityHigh: 2, models.TaskPriorityLow: 1},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []Queue{
+		{URL: aws.String("sqs://fast-high"), Weight: 2},
+		{URL: aws.String("sqs://ordered-high.fifo"), Weight: 2},
+		{URL: aws.String("sqs://fast-low"), Weight: 1},
+		{URL: aws.String("sqs://ordered-low.fifo"), Weight: 1},
+	}, queues)
+}
+
+func Test_LoadConfig_priorityWeights(t *testing.T) {
+	t.Setenv("PRIORITY_WEIGHTS", "high:3,low:1")
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	require.Equal(t, map[models.TaskPriority]int{models.TaskPriorityHigh: 3, models.TaskPriorityLow: 1}, cfg.PriorityWeights)
+}
+
+func Test_LoadConfig_workerQueues(t *testing.T) {
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	require.Equal(t, []string{cfg.TaskQueue}, cfg.WorkerQueues())
+
+	t.Setenv("WORKER_QUEUES", "slow,bulk")
+	cfg, err = LoadConfig()
+	require.NoError(t, err)
+	require.Equal(t, []string{"slow", "bulk"}, cfg.WorkerQueues())
+
+	t.Setenv("WORKER_QUEUES", "slow.queue")
+	_, err = LoadConfig()
+	require.Error(t, err)
+}
@@ -0,0 +1,28 @@
+package requester
+
+import (
+	"context"
+	"fmt"
+	"requester/internal/models"
+	"requester/internal/secrets"
+)
+
+// resolveSecrets returns a copy of the task with secret references of its client resolved.
+// Each secret is fetched once per task.
+func (r processor) resolveSecrets(ctx context.Context, task *models.Task) (*models.Task, error) {
+	values := make(map[string]string)
+	return secrets.ResolveTask(task, func(name string) (string, error) {
+		if value, ok := values[name]; ok {
+			return value, nil
+		}
+		secret, exists, err := r.secretRepository.GetSecret(ctx, task.ClientID, name)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return "", fmt.Errorf("secret %s not found", name)
+		}
+		values[name] = secret.Value
+		return secret.Value, nil
+	})
+}
@@ -0,0 +1,87 @@
+package requester
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http/httptrace"
+	"requester/internal/models"
+	"sync"
+	"time"
+)
+
+// requestTrace collects the timings of a request.
+// Hooks may be called concurrently while dialing, so the state is guarded by the mutex.
+type requestTrace struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	timings      models.Timings
+}
+
+// newRequestTrace creates a new trace of a request starting now.
+func newRequestTrace() *requestTrace {
+	return &requestTrace{start: time.Now()}
+}
+
+// clientTrace returns the hooks collecting the timings.
+// Only the first successful connect is recorded if several addresses are dialed.
+func (t *requestTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.DNS = time.Since(t.dnsStart)
+		},
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if t.connectStart.IsZero() {
+				t.connectStart = time.Now()
+			}
+		},
+		ConnectDone: func(_, _ string, err error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if err == nil && t.timings.Connect == 0 {
+				t.timings.Connect = time.Since(t.connectStart)
+			}
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.TLSHandshake = time.Since(t.tlsStart)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.ConnectionReused = info.Reused
+			if addr, ok := info.Conn.RemoteAddr().(*net.TCPAddr); ok {
+				t.timings.RemoteIP = addr.IP.String()
+			}
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.TimeToFirstByte = time.Since(t.start)
+		},
+	}
+}
+
+// result returns the timings collected so far.
+func (t *requestTrace) result() models.Timings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.timings
+}
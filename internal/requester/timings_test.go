@@ -0,0 +1,42 @@
+package requester
+
+import (
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"testing"
+)
+
+func Test_requestTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: &http.Transport{}}
+
+	send := func() *requestTrace {
+		trace := newRequestTrace()
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace.clientTrace()))
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		require.NoError(t, resp.Body.Close())
+		return trace
+	}
+
+	timings := send().result()
+	require.False(t, timings.ConnectionReused)
+	require.Equal(t, "127.0.0.1", timings.RemoteIP)
+	require.Positive(t, timings.Connect)
+	require.Positive(t, timings.TimeToFirstByte)
+	require.Zero(t, timings.TLSHandshake)
+
+	timings = send().result()
+	require.True(t, timings.ConnectionReused)
+	require.Zero(t, timings.Connect, "reused connection must not be dialed")
+	require.Positive(t, timings.TimeToFirstByte)
+}
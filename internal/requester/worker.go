@@ -8,6 +8,7 @@ import (
 	"github.com/go-faster/errors"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"requester/internal/queue"
 	"runtime/debug"
 	"sync"
 	"time"
@@ -17,22 +18,52 @@ import (
 type messageReceiver interface {
 	VisibilityTimeout() time.Duration
 	DecodeMessage(ctx context.Context, queueURL *string, message *sqs.Message, output interface{}) error
+	IsLastAttempt(message *sqs.Message) bool
 	GetMessages(ctx context.Context, input *sqs.ReceiveMessageInput) ([]*sqs.Message, error)
 	DeleteMessage(ctx context.Context, queue *string, message *sqs.Message) error
+	DeferMessage(ctx context.Context, queue *string, message *sqs.Message, delay time.Duration) error
 }
 
 // Worker is an implementation of Worker.
 type Worker struct {
-	queueURL  *string
+	queues    []Queue
 	workers   int
 	receiver  messageReceiver
 	processor Processor
 	logger    *zap.Logger
 }
 
-// NewWorker creates a new worker.
+// queueMessage is a message received from the queue.
+type queueMessage struct {
+	queueURL *string
+	*sqs.Message
+}
+
+// groupMessages splits the received messages into groups handled one after another by a single goroutine.
+// Messages of a FIFO queue message group stay in order, messages of standard queues are handled independently.
+func groupMessages(queueURL *string, messages []*sqs.Message) [][]*queueMessage {
+	var groups [][]*queueMessage
+	groupIndex := make(map[string]int)
+	for _, message := range messages {
+		msg := &queueMessage{queueURL: queueURL, Message: message}
+		groupID, ok := message.Attributes[sqs.MessageSystemAttributeNameMessageGroupId]
+		if !ok || groupID == nil {
+			groups = append(groups, []*queueMessage{msg})
+			continue
+		}
+		if i, ok := groupIndex[*groupID]; ok {
+			groups[i] = append(groups[i], msg)
+			continue
+		}
+		groupIndex[*groupID] = len(groups)
+		groups = append(groups, []*queueMessage{msg})
+	}
+	return groups
+}
+
+// NewWorker creates a new worker polling the queues.
 func NewWorker(
-	queueURL *string,
+	queues []Queue,
 	workers int,
 	receiver messageReceiver,
 	processor Processor,
@@ -41,8 +72,13 @@ func NewWorker(
 	if workers > 10 {
 		return nil, errors.New("max workers count is 10")
 	}
-	if queueURL == nil {
-		return nil, errors.New("must specify queueURL")
+	if len(queues) == 0 {
+		return nil, errors.New("must specify queues")
+	}
+	for _, queue := range queues {
+		if queue.URL == nil || queue.Weight <= 0 {
+			return nil, errors.New("queues must have URL and positive weight")
+		}
 	}
 	if receiver == nil {
 		return nil, errors.New("must specify Receiver")
@@ -54,7 +90,7 @@ func NewWorker(
 		return nil, errors.New("must specify logger")
 	}
 	return &Worker{
-		queueURL:  queueURL,
+		queues:    queues,
 		workers:   workers,
 		receiver:  receiver,
 		processor: processor,
@@ -62,14 +98,15 @@ func NewWorker(
 	}, nil
 }
 
-// WatchMessages starts a polling loop for messages from the queue,
+// WatchMessages starts a polling loop for messages from the queues,
 // followed by their processing.
 func (w *Worker) WatchMessages(ctx context.Context) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	defer w.handlePanic()
 
-	messages := make(chan *sqs.Message)
+	messages := make(chan []*queueMessage)
+	scheduler := newQueueScheduler(w.queues)
 
 	for i := 0; i < w.workers; i++ {
 		wg.Add(1)
@@ -81,48 +118,68 @@ func (w *Worker) WatchMessages(ctx context.Context) {
 	}
 
 	for {
-		output, err := w.receiveMessages(ctx)
+		queue := scheduler.next()
+		output, err := w.receiveMessages(ctx, queue.URL, scheduler.waitTime())
 		if ctx.Err() != nil {
 			w.logger.Info("Termination of the worker due to context cancellation")
 			return
 		}
 		if err != nil {
-			w.logger.Error("Error reading messages from the queue", zap.Error(err))
+			w.logger.Error("Error reading messages from the queue", zap.String("QueueURL", *queue.URL), zap.Error(err))
 			continue
 		}
-		for _, message := range output {
+		scheduler.received(len(output))
+		for _, group := range groupMessages(queue.URL, output) {
 			select {
 			case <-ctx.Done():
 				return
-			case messages <- message:
+			case messages <- group:
 			}
 		}
 	}
 }
 
-// listenMessages listens for messages from the queue.
-func (w *Worker) listenMessages(ctx context.Context, messages chan *sqs.Message) {
+// listenMessages listens for message groups from the queues and handles messages of a group in order.
+// Once a message isn't done, the rest of its group is released, to be received again after it.
+func (w *Worker) listenMessages(ctx context.Context, messages chan []*queueMessage) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case msg := <-messages:
-			w.handleMessage(ctx, msg)
+		case group := <-messages:
+			for i, msg := range group {
+				if !w.handleMessage(ctx, msg.queueURL, msg.Message) {
+					w.releaseMessages(ctx, group[i+1:])
+					break
+				}
+			}
+		}
+	}
+}
+
+// releaseMessages makes the messages visible again right away.
+func (w *Worker) releaseMessages(ctx context.Context, messages []*queueMessage) {
+	for _, msg := range messages {
+		if err := w.receiver.DeferMessage(ctx, msg.queueURL, msg.Message, 0); err != nil {
+			w.logger.Error("Error releasing the message", zap.String("MessageId", *msg.MessageId), zap.Error(err))
 		}
 	}
 }
 
-// receiveMessages receives messages.
-func (w *Worker) receiveMessages(ctx context.Context) ([]*sqs.Message, error) {
+// receiveMessages receives messages from the queue, waiting for them up to the wait time.
+func (w *Worker) receiveMessages(ctx context.Context, queueURL *string, waitTime time.Duration) ([]*sqs.Message, error) {
 	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
 	input := &sqs.ReceiveMessageInput{
-		QueueUrl:            w.queueURL,
+		QueueUrl:            queueURL,
 		MaxNumberOfMessages: aws.Int64(int64(w.workers)),
-		WaitTimeSeconds:     aws.Int64(10),
+		WaitTimeSeconds:     aws.Int64(int64(waitTime / time.Second)),
 		VisibilityTimeout:   aws.Int64(int64(w.receiver.VisibilityTimeout() / time.Second)),
-		AttributeNames:      []*string{aws.String(sqs.MessageSystemAttributeNameApproximateReceiveCount)},
+		AttributeNames: []*string{
+			aws.String(sqs.MessageSystemAttributeNameApproximateReceiveCount),
+			aws.String(sqs.MessageSystemAttributeNameMessageGroupId),
+		},
 	}
 
 	return w.receiver.GetMessages(ctx, input)
@@ -130,29 +187,65 @@ func (w *Worker) receiveMessages(ctx context.Context) ([]*sqs.Message, error) {
 
 // handleMessage performs processing of a message from the queue.
 // The function is intended to be launched in a goroutine, within a pool of similar goroutine-workers.
-func (w *Worker) handleMessage(ctx context.Context, sqsMsg *sqs.Message) {
+// Reports whether the message is done and deleted from the queue.
+func (w *Worker) handleMessage(ctx context.Context, queueURL *string, sqsMsg *sqs.Message) bool {
 	start := time.Now()
 	logg := w.logger.With(zap.String("MessageId", *sqsMsg.MessageId))
 	logg.Info("Message received for processing")
 
+	processor := w.processor.WithLogger(logg)
 	var taskID uuid.UUID
-	if err := w.receiver.DecodeMessage(ctx, w.queueURL, sqsMsg, &taskID); err != nil {
+	if err := w.receiver.DecodeMessage(ctx, queueURL, sqsMsg, &taskID); err != nil {
 		logg.Error("Error decoding the message", zap.Error(err))
-		return
+		// The deleted message won't be received again, its task is finished with an error.
+		var exceededErr *queue.ReceivesExceededError
+		if errors.As(err, &exceededErr) {
+			if err = processor.AbandonTask(ctx, taskID); err != nil {
+				logg.Error("Error abandoning the task", zap.Error(err))
+			}
+		}
+		return false
 	}
 
-	if err := w.processor.WithLogger(logg).ProcessTask(ctx, taskID); err != nil {
+	if err := processor.ProcessTask(ctx, taskID); err != nil {
+		var deferErr *DeferError
+		if errors.As(err, &deferErr) {
+			w.deferMessage(ctx, logg, queueURL, sqsMsg, deferErr)
+			return false
+		}
 		logg.Error("Error processing the message", zap.Error(err))
-		return
+		if w.receiver.IsLastAttempt(sqsMsg) {
+			if err = processor.AbandonTask(ctx, taskID); err != nil {
+				logg.Error("Error abandoning the task", zap.Error(err))
+			}
+		}
+		return false
 	}
 
-	if err := w.receiver.DeleteMessage(ctx, w.queueURL, sqsMsg); err != nil {
+	if err := w.receiver.DeleteMessage(ctx, queueURL, sqsMsg); err != nil {
 		logg.Error("Error deleting the message", zap.Error(err))
-		return
+		return false
 	}
 
 	logg.With(zap.Duration("Duration", time.Since(start))).
 		Info("Successfully processed the message")
+	return true
+}
+
+// deferMessage returns the message to the queue to be processed after the delay.
+func (w *Worker) deferMessage(
+	ctx context.Context,
+	logg *zap.Logger,
+	queueURL *string,
+	sqsMsg *sqs.Message,
+	deferErr *DeferError,
+) {
+	logg = logg.With(zap.Duration("Delay", deferErr.Delay), zap.String("Reason", deferErr.Reason))
+	if err := w.receiver.DeferMessage(ctx, queueURL, sqsMsg, deferErr.Delay); err != nil {
+		logg.Error("Error deferring the message", zap.Error(err))
+		return
+	}
+	logg.Info("Message deferred")
 }
 
 // handlePanic catches panic and logs the error.
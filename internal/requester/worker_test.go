@@ -2,12 +2,15 @@ package requester
 
 import (
 	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+	"requester/internal/queue"
 	"testing"
 	"time"
 )
@@ -28,6 +31,16 @@ func (r *testMessageReceiver) DeleteMessage(context.Context, *string, *sqs.Messa
 	return nil
 }
 
+func (r *testMessageReceiver) DeferMessage(
+	ctx context.Context,
+	url *string,
+	message *sqs.Message,
+	delay time.Duration,
+) error {
+	args := r.Called(ctx, url, message, delay)
+	return args.Error(0)
+}
+
 func (r *testMessageReceiver) VisibilityTimeout() time.Duration {
 	return 20 * time.Minute
 }
@@ -42,6 +55,11 @@ func (r *testMessageReceiver) DecodeMessage(
 	return args.Error(0)
 }
 
+func (r *testMessageReceiver) IsLastAttempt(message *sqs.Message) bool {
+	args := r.Called(message)
+	return args.Bool(0)
+}
+
 type testProcessor struct {
 	mock.Mock
 }
@@ -51,6 +69,11 @@ func (p *testProcessor) ProcessTask(ctx context.Context, taskID uuid.UUID) error
 	return args.Error(0)
 }
 
+func (p *testProcessor) AbandonTask(ctx context.Context, taskID uuid.UUID) error {
+	args := p.Called(ctx, taskID)
+	return args.Error(0)
+}
+
 func (p *testProcessor) WithLogger(*zap.Logger) Processor {
 	return p
 }
@@ -60,7 +83,7 @@ func Test_WatchMessages(t *testing.T) {
 	logger := zaptest.NewLogger(t, zaptest.Level(zap.PanicLevel))
 	receiver := &testMessageReceiver{}
 	proc := &testProcessor{}
-	instance, err := NewWorker(&url, 1, receiver, proc, logger)
+	instance, err := NewWorker([]Queue{{URL: &url, Weight: 1}}, 1, receiver, proc, logger)
 	require.NoError(t, err)
 
 	taskID := uuid.New()
@@ -86,3 +109,126 @@ func Test_WatchMessages(t *testing.T) {
 	receiver.AssertExpectations(t)
 	proc.AssertExpectations(t)
 }
+
+func Test_WatchMessages_abandon(t *testing.T) {
+	url := "sqs://task-queue"
+	logger := zaptest.NewLogger(t, zaptest.Level(zap.PanicLevel))
+	receiver := &testMessageReceiver{}
+	proc := &testProcessor{}
+	instance, err := NewWorker([]Queue{{URL: &url, Weight: 1}}, 1, receiver, proc, logger)
+	require.NoError(t, err)
+
+	taskID := uuid.New()
+
+	msgId := "test"
+	message := &sqs.Message{MessageId: &msgId}
+	receiver.On("GetMessages", mock.Anything, mock.Anything).
+		Return([]*sqs.Message{message}, nil)
+
+	receiver.On(
+		"DecodeMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	).Return(nil).Run(func(args mock.Arguments) {
+		output := args.Get(3).(*uuid.UUID)
+		*output = taskID
+	})
+	receiver.On("IsLastAttempt", message).Return(true)
+
+	proc.On("ProcessTask", mock.Anything, taskID).Return(errors.New("connection refused"))
+	proc.On("AbandonTask", mock.Anything, taskID).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go instance.WatchMessages(ctx)
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+
+	receiver.AssertExpectations(t)
+	proc.AssertExpectations(t)
+}
+
+func Test_handleMessage_receivesExceeded(t *testing.T) {
+	url := "sqs://task-queue.fifo"
+	logger := zaptest.NewLogger(t, zaptest.Level(zap.PanicLevel))
+	receiver := &testMessageReceiver{}
+	proc := &testProcessor{}
+	instance, err := NewWorker([]Queue{{URL: &url, Weight: 1}}, 1, receiver, proc, logger)
+	require.NoError(t, err)
+
+	taskID := uuid.New()
+	message := &sqs.Message{MessageId: aws.String("deferred")}
+	receiver.On(
+		"DecodeMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	).Return(&queue.ReceivesExceededError{Count: 6}).Run(func(args mock.Arguments) {
+		output := args.Get(3).(*uuid.UUID)
+		*output = taskID
+	})
+	proc.On("AbandonTask", mock.Anything, taskID).Return(nil).Once()
+
+	// The message deleted after too many deferrals finishes its task instead of leaving it new.
+	require.False(t, instance.handleMessage(context.Background(), &url, message))
+	receiver.AssertExpectations(t)
+	proc.AssertExpectations(t)
+	proc.AssertNotCalled(t, "ProcessTask", mock.Anything, mock.Anything)
+}
+
+func Test_groupMessages(t *testing.T) {
+	url := "sqs://task-queue.fifo"
+	newMessage := func(id, groupID string) *sqs.Message {
+		message := &sqs.Message{MessageId: aws.String(id)}
+		if groupID != "" {
+			message.Attributes = map[string]*string{sqs.MessageSystemAttributeNameMessageGroupId: aws.String(groupID)}
+		}
+		return message
+	}
+	a1, b1, a2, s1 := newMessage("a1", "a"), newMessage("b1", "b"), newMessage("a2", "a"), newMessage("s1", "")
+
+	groups := groupMessages(&url, []*sqs.Message{a1, b1, a2, s1})
+	var ids [][]string
+	for _, group := range groups {
+		var groupIDs []string
+		for _, msg := range group {
+			require.Equal(t, &url, msg.queueURL)
+			groupIDs = append(groupIDs, *msg.MessageId)
+		}
+		ids = append(ids, groupIDs)
+	}
+	require.Equal(t, [][]string{{"a1", "a2"}, {"b1"}, {"s1"}}, ids)
+}
+
+func Test_listenMessages_group(t *testing.T) {
+	url := "sqs://task-queue.fifo"
+	logger := zaptest.NewLogger(t, zaptest.Level(zap.PanicLevel))
+	receiver := &testMessageReceiver{}
+	proc := &testProcessor{}
+	instance, err := NewWorker([]Queue{{URL: &url, Weight: 1}}, 1, receiver, proc, logger)
+	require.NoError(t, err)
+
+	groupID := map[string]*string{sqs.MessageSystemAttributeNameMessageGroupId: aws.String("account")}
+	taskIDs := map[string]uuid.UUID{"first": uuid.New(), "second": uuid.New(), "third": uuid.New()}
+	first := &sqs.Message{MessageId: aws.String("first"), Attributes: groupID}
+	second := &sqs.Message{MessageId: aws.String("second"), Attributes: groupID}
+	third := &sqs.Message{MessageId: aws.String("third"), Attributes: groupID}
+
+	receiver.On(
+		"DecodeMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	).Return(nil).Run(func(args mock.Arguments) {
+		output := args.Get(3).(*uuid.UUID)
+		*output = taskIDs[*args.Get(2).(*sqs.Message).MessageId]
+	})
+	receiver.On("IsLastAttempt", second).Return(false)
+	proc.On("ProcessTask", mock.Anything, taskIDs["first"]).Return(nil).Once()
+	proc.On("ProcessTask", mock.Anything, taskIDs["second"]).Return(errors.New("connection refused")).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// The third message is released once the second one fails, so it isn't handled out of order.
+	receiver.On("DeferMessage", mock.Anything, &url, third, time.Duration(0)).Return(nil).Once().
+		Run(func(mock.Arguments) { cancel() })
+
+	messages := make(chan []*queueMessage, 1)
+	messages <- groupMessages(&url, []*sqs.Message{first, second, third})[0]
+	instance.listenMessages(ctx, messages)
+
+	receiver.AssertExpectations(t)
+	proc.AssertExpectations(t)
+	proc.AssertNotCalled(t, "ProcessTask", mock.Anything, taskIDs["third"])
+}
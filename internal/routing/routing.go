@@ -0,0 +1,105 @@
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"requester/internal/destination"
+	"strings"
+)
+
+// maxQueueNameLength leaves room for the priority suffix within the 80 characters of SQS queue names.
+const maxQueueNameLength = 75
+
+// queueNameRe matches names allowed for SQS queues, FIFO ones have the .fifo suffix.
+var queueNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.fifo)?$`)
+
+// Rule routes matching tasks to the queue.
+// Empty conditions match any task.
+type Rule struct {
+	// Exact or wildcard pattern of the task URL host like "*.example.com"
+	Host string `json:"host,omitempty"`
+	// ID of the client that created the task
+	Client string `json:"client,omitempty"`
+	// Label of the task
+	Label string `json:"label,omitempty"`
+	// Name of the queue
+	Queue string `json:"queue"`
+}
+
+// matches reports whether the task matches the rule.
+func (r Rule) matches(host, clientID, label string) bool {
+	if r.Host != "" && !destination.MatchHost(r.Host, host) {
+		return false
+	}
+	if r.Client != "" && r.Client != clientID {
+		return false
+	}
+	return r.Label == "" || r.Label == label
+}
+
+// Rules are routing rules of tasks to queues, the first matching rule wins.
+type Rules []Rule
+
+// Decode decodes the rules from a JSON array like `[{"host": "*.example.com", "queue": "slow"}]`.
+// Host patterns are normalized.
+func (rs *Rules) Decode(value string) error {
+	var rules Rules
+	if err := json.Unmarshal([]byte(value), &rules); err != nil {
+		return fmt.Errorf("invalid routing rules: %w", err)
+	}
+	for i := range rules {
+		rules[i].Host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(rules[i].Host)), ".")
+	}
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+	*rs = rules
+	return nil
+}
+
+// Validate checks the queue names of the rules.
+func (rs Rules) Validate() error {
+	for i, rule := range rs {
+		if err := ValidateQueueName(rule.Queue); err != nil {
+			return fmt.Errorf("routing rule %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Queue returns the queue of the first rule the task matches, empty if none does.
+// The host is matched case-insensitively.
+func (rs Rules) Queue(host, clientID, label string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, rule := range rs {
+		if rule.matches(host, clientID, label) {
+			return rule.Queue
+		}
+	}
+	return ""
+}
+
+// Queues returns the distinct queue names of the rules.
+func (rs Rules) Queues() []string {
+	var queues []string
+	seen := make(map[string]bool, len(rs))
+	for _, rule := range rs {
+		if !seen[rule.Queue] {
+			seen[rule.Queue] = true
+			queues = append(queues, rule.Queue)
+		}
+	}
+	return queues
+}
+
+// ValidateQueueName checks that the name is a valid task queue name.
+func ValidateQueueName(name string) error {
+	if name == "" {
+		return fmt.Errorf("queue name is empty")
+	}
+	if len(name) > maxQueueNameLength || !queueNameRe.MatchString(name) {
+		return fmt.Errorf("invalid queue name %q", name)
+	}
+	return nil
+}
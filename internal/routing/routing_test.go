@@ -0,0 +1,62 @@
+package routing
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_Rules_Queue(t *testing.T) {
+	rules := Rules{
+		{Host: "*.slow.example.com", Queue: "slow"},
+		{Client: "bulk-client", Label: "report", Queue: "bulk-reports"},
+		{Client: "bulk-client", Queue: "bulk"},
+		{Label: "urgent", Queue: "urgent"},
+	}
+	tests := []struct {
+		name     string
+		host     string
+		clientID string
+		label    string
+		want     string
+	}{
+		{"host", "api.slow.example.com", "client", "", "slow"},
+		{"host_case", "API.Slow.Example.com.", "client", "urgent", "slow"},
+		{"host_parent", "slow.example.com", "client", "", ""},
+		{"client_and_label", "example.com", "bulk-client", "report", "bulk-reports"},
+		{"client", "example.com", "bulk-client", "urgent", "bulk"},
+		{"label", "example.com", "client", "urgent", "urgent"},
+		{"no_match", "example.com", "client", "report", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, rules.Queue(tt.host, tt.clientID, tt.label))
+		})
+	}
+}
+
+func Test_Rules_Decode(t *testing.T) {
+	var rules Rules
+	err := rules.Decode(`[{"host": " *.Example.COM. ", "queue": "slow"}, {"client": "c", "queue": "slow"}, ` +
+		`{"label": "ordered", "queue": "ordered.fifo"}]`)
+	require.NoError(t, err)
+	require.Equal(t, Rules{
+		{Host: "*.example.com", Queue: "slow"},
+		{Client: "c", Queue: "slow"},
+		{Label: "ordered", Queue: "ordered.fifo"},
+	}, rules)
+	require.Equal(t, []string{"slow", "ordered.fifo"}, rules.Queues())
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"invalid_json", `{"queue": "slow"}`},
+		{"no_queue", `[{"host": "example.com"}]`},
+		{"invalid_queue", `[{"host": "example.com", "queue": "slow.queue"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, rules.Decode(tt.value))
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package secrets
+
+import (
+	"encoding/json"
+	"github.com/go-faster/jx"
+	"regexp"
+	"requester/internal/models"
+	"requester/internal/variables"
+	"strings"
+)
+
+// referenceRe matches secret references like {{secret:partner_token}}.
+var referenceRe = regexp.MustCompile(`\{\{\s*secret:([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// Lookup returns the value of the secret by name.
+type Lookup func(name string) (string, error)
+
+// HasReferences reports whether the string contains secret references.
+func HasReferences(s string) bool {
+	return referenceRe.MatchString(s)
+}
+
+// Resolve replaces secret references in the string with values escaped by the escape function,
+// which gets the part of the string preceding the reference.
+func Resolve(s string, lookup Lookup, escape func(prefix, value string) string) (string, error) {
+	var b strings.Builder
+	last := 0
+	for _, loc := range referenceRe.FindAllStringSubmatchIndex(s, -1) {
+		value, err := lookup(s[loc[2]:loc[3]])
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(s[last:loc[0]])
+		b.WriteString(escape(s[:loc[0]], value))
+		last = loc[1]
+	}
+	b.WriteString(s[last:])
+	return b.String(), nil
+}
+
+// ResolveTask returns a copy of the task with secret references resolved in URL, headers, body,
+// signing keys and proxy credentials.
+// The task itself is left unchanged, so resolved values can't be stored by accident.
+func ResolveTask(task *models.Task, lookup Lookup) (*models.Task, error) {
+	resolved := *task
+
+	var err error
+	if resolved.URL, err = Resolve(task.URL, lookup, variables.EscapeURL); err != nil {
+		return nil, err
+	}
+
+	if task.Headers != nil {
+		resolved.Headers = make(map[string]string, len(task.Headers))
+		for k, v := range task.Headers {
+			if resolved.Headers[k], err = Resolve(v, lookup, noEscape); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if task.Body != nil {
+		resolved.Body = make(map[string]jx.Raw, len(task.Body))
+		for k, v := range task.Body {
+			value, err := Resolve(string(v), lookup, jsonEscape)
+			if err != nil {
+				return nil, err
+			}
+			resolved.Body[k] = jx.Raw(value)
+		}
+	}
+
+	if task.Signing != nil {
+		if resolved.Signing, err = resolveSigning(task.Signing, lookup); err != nil {
+			return nil, err
+		}
+	}
+
+	if task.Proxy != nil {
+		proxy := *task.Proxy
+		for _, value := range []*string{&proxy.Username, &proxy.Password} {
+			if *value, err = Resolve(*value, lookup, noEscape); err != nil {
+				return nil, err
+			}
+		}
+		resolved.Proxy = &proxy
+	}
+
+	return &resolved, nil
+}
+
+// resolveSigning returns a copy of the signing with secret references resolved in keys.
+func resolveSigning(signing *models.Signing, lookup Lookup) (*models.Signing, error) {
+	resolved := *signing
+
+	var err error
+	if signing.HMAC != nil {
+		hmac := *signing.HMAC
+		if hmac.Key, err = Resolve(hmac.Key, lookup, noEscape); err != nil {
+			return nil, err
+		}
+		resolved.HMAC = &hmac
+	}
+
+	if signing.AWSSigV4 != nil {
+		aws := *signing.AWSSigV4
+		for _, value := range []*string{&aws.AccessKeyID, &aws.SecretAccessKey, &aws.SessionToken} {
+			if *value, err = Resolve(*value, lookup, noEscape); err != nil {
+				return nil, err
+			}
+		}
+		resolved.AWSSigV4 = &aws
+	}
+
+	return &resolved, nil
+}
+
+// noEscape returns the value as is.
+func noEscape(_, value string) string {
+	return value
+}
+
+// jsonEscape escapes the value to be placed inside a JSON string.
+func jsonEscape(_, value string) string {
+	escaped, _ := json.Marshal(value)
+	return string(escaped[1 : len(escaped)-1])
+}
@@ -0,0 +1,62 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-faster/jx"
+	"github.com/stretchr/testify/require"
+	"requester/internal/models"
+	"testing"
+)
+
+func Test_ResolveTask(t *testing.T) {
+	values := map[string]string{
+		"token": `t"o&k en`,
+		"user":  "admin",
+	}
+	lookup := func(name string) (string, error) {
+		value, ok := values[name]
+		if !ok {
+			return "", errors.New("secret not found")
+		}
+		return value, nil
+	}
+
+	task := &models.Task{
+		URL:     "https://example.com/{{secret:user}}?token={{ secret:token }}",
+		Headers: map[string]string{"Authorization": "Bearer {{secret:token}}"},
+		Body:    map[string]jx.Raw{"auth": jx.Raw(`{"token": "{{secret:token}}"}`), "n": jx.Raw(`1`)},
+		Signing: &models.Signing{
+			Type: models.SigningTypeHMACSHA256,
+			HMAC: &models.HMACSigning{Key: "{{secret:token}}"},
+		},
+		Proxy: &models.Proxy{URL: "http://proxy:3128", Username: "{{secret:user}}", Password: "{{secret:token}}"},
+	}
+
+	resolved, err := ResolveTask(task, lookup)
+	require.NoError(t, err)
+
+	require.Equal(t, "https://example.com/admin?token=t%22o%26k+en", resolved.URL)
+	require.Equal(t, `Bearer t"o&k en`, resolved.Headers["Authorization"])
+	var auth struct {
+		Token string `json:"token"`
+	}
+	require.NoError(t, json.Unmarshal(resolved.Body["auth"], &auth))
+	require.Equal(t, `t"o&k en`, auth.Token)
+	require.Equal(t, jx.Raw(`1`), resolved.Body["n"])
+	require.Equal(t, `t"o&k en`, resolved.Signing.HMAC.Key)
+	require.Equal(t, "admin", resolved.Proxy.Username)
+	require.Equal(t, `t"o&k en`, resolved.Proxy.Password)
+
+	require.Equal(t, "Bearer {{secret:token}}", task.Headers["Authorization"], "task must not change")
+	require.Equal(t, "{{secret:token}}", task.Signing.HMAC.Key, "task must not change")
+	require.Equal(t, "{{secret:token}}", task.Proxy.Password, "task must not change")
+
+	resolved, err = ResolveTask(&models.Task{URL: "https://example.com/{{secret:token}}#{{secret:user}}"}, lookup)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/t%22o&k%20en#admin", resolved.URL, "path values must be path-escaped")
+
+	task.Headers["X-Missing"] = "{{secret:missing}}"
+	_, err = ResolveTask(task, lookup)
+	require.Error(t, err)
+}
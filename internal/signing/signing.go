@@ -0,0 +1,124 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"io"
+	"net/http"
+	"requester/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultHeader is the default header of the HMAC signature.
+	DefaultHeader = "X-Signature"
+	// DefaultTimestampHeader is the default header of the HMAC signature timestamp.
+	DefaultTimestampHeader = "X-Timestamp"
+)
+
+// DefaultParts is the default canonical parts of the request signed with HMAC.
+var DefaultParts = []models.HMACPart{
+	models.HMACPartMethod,
+	models.HMACPartPath,
+	models.HMACPartTimestamp,
+	models.HMACPartBody,
+}
+
+// Validate checks that the signing has options of its type.
+func Validate(signing *models.Signing) error {
+	switch signing.Type {
+	case models.SigningTypeHMACSHA256:
+		if signing.HMAC == nil {
+			return errors.New("hmac options are required for hmac_sha256 signing")
+		}
+		if signing.HMAC.Key == "" {
+			return errors.New("hmac key is required")
+		}
+	case models.SigningTypeAWSSigV4:
+		if signing.AWSSigV4 == nil {
+			return errors.New("aws_sigv4 options are required for aws_sigv4 signing")
+		}
+	default:
+		return fmt.Errorf("unknown signing type %q", signing.Type)
+	}
+	return nil
+}
+
+// Sign signs the request created with the body.
+// Must be called right before the request is sent, the signature includes the signing time.
+func Sign(req *http.Request, body []byte, signing *models.Signing, now time.Time) error {
+	if err := Validate(signing); err != nil {
+		return err
+	}
+	switch signing.Type {
+	case models.SigningTypeAWSSigV4:
+		return signAWSSigV4(req, body, signing.AWSSigV4, now)
+	default:
+		signHMAC(req, body, signing.HMAC, now)
+		return nil
+	}
+}
+
+// signHMAC sets the hex-encoded HMAC-SHA256 signature of the canonical parts joined by a newline.
+// The timestamp header is set only if the timestamp is signed.
+func signHMAC(req *http.Request, body []byte, opts *models.HMACSigning, now time.Time) {
+	parts := opts.Parts
+	if len(parts) == 0 {
+		parts = DefaultParts
+	}
+	header := opts.Header
+	if header == "" {
+		header = DefaultHeader
+	}
+	timestampHeader := opts.TimestampHeader
+	if timestampHeader == "" {
+		timestampHeader = DefaultTimestampHeader
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	canonical := make([]string, 0, len(parts))
+	for _, part := range parts {
+		switch part {
+		case models.HMACPartMethod:
+			canonical = append(canonical, req.Method)
+		case models.HMACPartPath:
+			canonical = append(canonical, req.URL.EscapedPath())
+		case models.HMACPartQuery:
+			canonical = append(canonical, req.URL.RawQuery)
+		case models.HMACPartTimestamp:
+			canonical = append(canonical, timestamp)
+			req.Header.Set(timestampHeader, timestamp)
+		case models.HMACPartBody:
+			canonical = append(canonical, string(body))
+		}
+	}
+
+	mac := hmac.New(sha256.New, []byte(opts.Key))
+	mac.Write([]byte(strings.Join(canonical, "\n")))
+	req.Header.Set(header, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// signAWSSigV4 signs the request with AWS Signature V4.
+// The request body is left as is.
+func signAWSSigV4(req *http.Request, body []byte, opts *models.AWSSigV4Signing, now time.Time) error {
+	signer := v4.NewSigner(
+		credentials.NewStaticCredentials(opts.AccessKeyID, opts.SecretAccessKey, opts.SessionToken),
+		func(s *v4.Signer) {
+			s.DisableRequestBodyOverwrite = true
+		},
+	)
+	var reader io.ReadSeeker
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	_, err := signer.Sign(req, reader, opts.Service, opts.Region, now)
+	return err
+}
@@ -0,0 +1,104 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"requester/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRequest(t *testing.T, body []byte) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/v1/orders?id=1", bytes.NewReader(body))
+	require.NoError(t, err)
+	return req
+}
+
+func Test_Sign_hmac(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	now := time.Unix(1700000000, 0)
+	mac := func(canonical string) string {
+		m := hmac.New(sha256.New, []byte("key"))
+		m.Write([]byte(canonical))
+		return hex.EncodeToString(m.Sum(nil))
+	}
+
+	tests := []struct {
+		name      string
+		opts      models.HMACSigning
+		header    string
+		signature string
+		timestamp string
+	}{
+		{
+			"default",
+			models.HMACSigning{Key: "key"},
+			DefaultHeader,
+			mac("POST\n/v1/orders\n1700000000\n" + string(body)),
+			"1700000000",
+		},
+		{
+			"custom",
+			models.HMACSigning{
+				Key:    "key",
+				Parts:  []models.HMACPart{models.HMACPartQuery, models.HMACPartBody},
+				Header: "X-Partner-Signature",
+			},
+			"X-Partner-Signature",
+			mac("id=1\n" + string(body)),
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newRequest(t, body)
+			err := Sign(req, body, &models.Signing{Type: models.SigningTypeHMACSHA256, HMAC: &tt.opts}, now)
+			require.NoError(t, err)
+			require.Equal(t, tt.signature, req.Header.Get(tt.header))
+			require.Equal(t, tt.timestamp, req.Header.Get(DefaultTimestampHeader))
+		})
+	}
+}
+
+func Test_Sign_awsSigV4(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	req := newRequest(t, body)
+	err := Sign(req, body, &models.Signing{
+		Type: models.SigningTypeAWSSigV4,
+		AWSSigV4: &models.AWSSigV4Signing{
+			Region:          "us-east-1",
+			Service:         "execute-api",
+			AccessKeyID:     "AKID",
+			SecretAccessKey: "SECRET",
+		},
+	}, time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+
+	require.True(t, strings.HasPrefix(
+		req.Header.Get("Authorization"),
+		"AWS4-HMAC-SHA256 Credential=AKID/20230102/us-east-1/execute-api/aws4_request",
+	))
+	require.Equal(t, "20230102T030405Z", req.Header.Get("X-Amz-Date"))
+
+	sent, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, body, sent, "body must be left as is")
+}
+
+func Test_Validate(t *testing.T) {
+	require.Error(t, Validate(&models.Signing{Type: models.SigningTypeHMACSHA256}))
+	require.Error(t, Validate(&models.Signing{Type: models.SigningTypeAWSSigV4}))
+	require.Error(t, Validate(&models.Signing{Type: "unknown"}))
+	require.NoError(t, Validate(&models.Signing{
+		Type: models.SigningTypeHMACSHA256,
+		HMAC: &models.HMACSigning{Key: "{{secret:key}}"},
+	}))
+}
@@ -0,0 +1,147 @@
+// Package success evaluates success criteria of task responses.
+package success
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"requester/internal/jsonpath"
+	"requester/internal/models"
+	"strings"
+)
+
+// AssertionError is returned when the response fails an assertion.
+type AssertionError struct {
+	// Assertion is the description of the failed assertion.
+	Assertion string
+}
+
+func (e *AssertionError) Error() string {
+	return "assertion failed: " + e.Assertion
+}
+
+// Validate checks that the status code ranges, the regular expression, JSONPaths and expected values are valid.
+func Validate(s *models.Success) error {
+	for _, r := range s.StatusCodes {
+		if r.From < 100 || r.To > 599 || r.From > r.To {
+			return fmt.Errorf("invalid status code range %d-%d", r.From, r.To)
+		}
+	}
+	for _, h := range s.Headers {
+		if h.Name == "" {
+			return errors.New("header name is required")
+		}
+	}
+	if s.BodyRegex != "" {
+		if _, err := regexp.Compile(s.BodyRegex); err != nil {
+			return fmt.Errorf("invalid body regex: %w", err)
+		}
+	}
+	for _, a := range s.JSON {
+		if _, err := jsonpath.Parse(a.Path); err != nil {
+			return err
+		}
+		if !json.Valid(a.Equals) {
+			return fmt.Errorf("expected value of %s is not valid JSON", a.Path)
+		}
+	}
+	return nil
+}
+
+// ReadsBody reports whether the assertions need the response body.
+func ReadsBody(s *models.Success) bool {
+	return s.BodyContains != "" || s.BodyRegex != "" || len(s.JSON) > 0
+}
+
+// Check checks the response against the success criteria.
+// Returns *AssertionError describing the first failed assertion.
+func Check(s *models.Success, statusCode int, header http.Header, body []byte) error {
+	if len(s.StatusCodes) > 0 && !acceptsStatusCode(s.StatusCodes, statusCode) {
+		return &AssertionError{Assertion: fmt.Sprintf("status code %d is not accepted", statusCode)}
+	}
+
+	for _, h := range s.Headers {
+		values, ok := header[http.CanonicalHeaderKey(h.Name)]
+		if !ok {
+			return &AssertionError{Assertion: fmt.Sprintf("header %s is missing", h.Name)}
+		}
+		if h.Value != "" && !contains(values, h.Value) {
+			return &AssertionError{Assertion: fmt.Sprintf("header %s is not %q", h.Name, h.Value)}
+		}
+	}
+
+	if s.BodyContains != "" && !bytes.Contains(body, []byte(s.BodyContains)) {
+		return &AssertionError{Assertion: fmt.Sprintf("body doesn't contain %q", s.BodyContains)}
+	}
+
+	if s.BodyRegex != "" {
+		re, err := regexp.Compile(s.BodyRegex)
+		if err != nil {
+			return err
+		}
+		if !re.Match(body) {
+			return &AssertionError{Assertion: fmt.Sprintf("body doesn't match %q", s.BodyRegex)}
+		}
+	}
+
+	if len(s.JSON) == 0 {
+		return nil
+	}
+	doc, err := jsonpath.Decode(body)
+	if err != nil {
+		return &AssertionError{Assertion: "body is not valid JSON"}
+	}
+	for _, a := range s.JSON {
+		if err = checkJSON(doc, a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkJSON checks that the value of the decoded document equals the expected one.
+func checkJSON(doc interface{}, a models.JSONAssertion) error {
+	path, err := jsonpath.Parse(a.Path)
+	if err != nil {
+		return err
+	}
+	got, err := path.Get(doc)
+	if err != nil {
+		if errors.Is(err, jsonpath.ErrNotFound) {
+			return &AssertionError{Assertion: fmt.Sprintf("%s is missing", a.Path)}
+		}
+		return err
+	}
+	want, err := jsonpath.Decode(a.Equals)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(got, want) {
+		return &AssertionError{Assertion: fmt.Sprintf("%s is not %s", a.Path, strings.TrimSpace(string(a.Equals)))}
+	}
+	return nil
+}
+
+// acceptsStatusCode reports whether any of the ranges contains the status code.
+func acceptsStatusCode(ranges []models.StatusCodeRange, statusCode int) bool {
+	for _, r := range ranges {
+		if statusCode >= r.From && statusCode <= r.To {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether the values contain the value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
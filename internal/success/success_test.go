@@ -0,0 +1,74 @@
+package success
+
+import (
+	"github.com/go-faster/jx"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"requester/internal/models"
+	"testing"
+)
+
+func Test_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		success *models.Success
+	}{
+		{"reversed_range", &models.Success{StatusCodes: []models.StatusCodeRange{{From: 299, To: 200}}}},
+		{"out_of_range", &models.Success{StatusCodes: []models.StatusCodeRange{{From: 200, To: 600}}}},
+		{"empty_header", &models.Success{Headers: []models.HeaderAssertion{{Value: "x"}}}},
+		{"invalid_regex", &models.Success{BodyRegex: "("}},
+		{"invalid_path", &models.Success{JSON: []models.JSONAssertion{{Path: "data", Equals: jx.Raw(`1`)}}}},
+		{"invalid_value", &models.Success{JSON: []models.JSONAssertion{{Path: "$.data", Equals: jx.Raw(`{`)}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, Validate(tt.success))
+		})
+	}
+}
+
+func Test_Check(t *testing.T) {
+	s := &models.Success{
+		StatusCodes:  []models.StatusCodeRange{{From: 200, To: 299}, {From: 304, To: 304}},
+		Headers:      []models.HeaderAssertion{{Name: "x-request-id"}, {Name: "Content-Type", Value: "application/json"}},
+		BodyContains: `"status"`,
+		BodyRegex:    `"id":\s*\d+`,
+		JSON: []models.JSONAssertion{
+			{Path: "$.status", Equals: jx.Raw(`"accepted"`)},
+			{Path: "$.items[0]", Equals: jx.Raw(`{"n": 1}`)},
+		},
+	}
+	require.NoError(t, Validate(s))
+	require.True(t, ReadsBody(s))
+
+	header := http.Header{"Content-Type": {"application/json"}, "X-Request-Id": {"1"}}
+	body := []byte(`{"id": 7, "status": "accepted", "items": [{"n": 1}]}`)
+	require.NoError(t, Check(s, http.StatusCreated, header, body))
+
+	tests := []struct {
+		name       string
+		statusCode int
+		header     http.Header
+		body       string
+		want       string
+	}{
+		{"status_code", http.StatusInternalServerError, header, string(body), "status code 500 is not accepted"},
+		{"missing_header", http.StatusOK, http.Header{"Content-Type": {"application/json"}}, string(body), "header x-request-id is missing"},
+		{"header_value", http.StatusOK, http.Header{"Content-Type": {"text/plain"}, "X-Request-Id": {"1"}}, string(body), `header Content-Type is not "application/json"`},
+		{"body_contains", http.StatusOK, header, `{"id": 7}`, `body doesn't contain "\"status\""`},
+		{"body_regex", http.StatusOK, header, `{"status": "accepted"}`, `body doesn't match "\"id\":\\s*\\d+"`},
+		{"json_value", http.StatusOK, header, `{"id": 7, "status": "rejected"}`, `$.status is not "accepted"`},
+		{"json_missing", http.StatusOK, header, `{"id": 7, "status": "accepted"}`, "$.items[0] is missing"},
+		{"not_json", http.StatusOK, header, `"id": 7, "status"`, "body is not valid JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(s, tt.statusCode, tt.header, []byte(tt.body))
+			var assertionErr *AssertionError
+			require.ErrorAs(t, err, &assertionErr)
+			require.Equal(t, tt.want, assertionErr.Assertion)
+		})
+	}
+
+	require.NoError(t, Check(&models.Success{}, http.StatusInternalServerError, nil, nil), "empty rules accept any response")
+}
@@ -0,0 +1,141 @@
+// Package templates validates request templates and binds task parameters to their parameter schemas.
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/go-faster/jx"
+	"regexp"
+	"requester/internal/models"
+	"requester/internal/variables"
+	"strconv"
+)
+
+// nameRe matches parameter names which can be referenced as variables.
+var nameRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Validate checks the parameter schemas and that the template URL, headers and body
+// reference only declared parameters.
+func Validate(t *models.RequestTemplate) error {
+	declared := make(map[string]string, len(t.Parameters))
+	for name, p := range t.Parameters {
+		if !nameRe.MatchString(name) {
+			return fmt.Errorf("invalid parameter name %q", name)
+		}
+		if err := validateParameter(name, p); err != nil {
+			return err
+		}
+		declared[name] = ""
+	}
+	return variables.Validate(&models.Task{URL: t.URL, Headers: t.Headers, Body: t.Body}, declared)
+}
+
+// validateParameter checks the type, the pattern and the default value of the parameter.
+func validateParameter(name string, p models.TemplateParameter) error {
+	switch p.Type {
+	case models.ParameterTypeString, models.ParameterTypeInteger, models.ParameterTypeNumber, models.ParameterTypeBoolean:
+	default:
+		return fmt.Errorf("parameter %s has unknown type %q", name, p.Type)
+	}
+	if p.Pattern != "" {
+		if p.Type != models.ParameterTypeString {
+			return fmt.Errorf("parameter %s has a pattern but isn't a string", name)
+		}
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return fmt.Errorf("parameter %s has invalid pattern: %w", name, err)
+		}
+	}
+	if len(p.Default) > 0 {
+		if _, err := bind(name, p, p.Default); err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+	}
+	return nil
+}
+
+// Bind checks the parameters against the template parameter schemas and returns them as task variables.
+// Parameters which aren't set take their defaults, optional ones without defaults are empty.
+func Bind(t *models.RequestTemplate, params map[string]jx.Raw) (map[string]string, error) {
+	for name := range params {
+		if _, ok := t.Parameters[name]; !ok {
+			return nil, fmt.Errorf("parameter %s is unknown", name)
+		}
+	}
+
+	values := make(map[string]string, len(t.Parameters))
+	for name, p := range t.Parameters {
+		raw, ok := params[name]
+		if !ok {
+			if p.Required {
+				return nil, fmt.Errorf("parameter %s is required", name)
+			}
+			raw = p.Default
+		}
+		if len(raw) == 0 {
+			values[name] = ""
+			continue
+		}
+		value, err := bind(name, p, raw)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// bind checks the JSON value against the parameter schema and returns it as a variable value.
+// Strings are unquoted, other values are kept as JSON.
+func bind(name string, p models.TemplateParameter, raw jx.Raw) (string, error) {
+	if !json.Valid(raw) {
+		return "", fmt.Errorf("parameter %s is not valid JSON", name)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return "", fmt.Errorf("parameter %s is not valid JSON", name)
+	}
+
+	switch p.Type {
+	case models.ParameterTypeString:
+		s, ok := v.(string)
+		if !ok {
+			return "", fmt.Errorf("parameter %s is not a string", name)
+		}
+		if p.Pattern != "" {
+			re, err := regexp.Compile(p.Pattern)
+			if err != nil {
+				return "", err
+			}
+			if !re.MatchString(s) {
+				return "", fmt.Errorf("parameter %s doesn't match %q", name, p.Pattern)
+			}
+		}
+		return s, nil
+	case models.ParameterTypeInteger:
+		n, ok := v.(json.Number)
+		if !ok {
+			return "", fmt.Errorf("parameter %s is not an integer", name)
+		}
+		if _, err := n.Int64(); err != nil {
+			return "", fmt.Errorf("parameter %s is not an integer", name)
+		}
+		return n.String(), nil
+	case models.ParameterTypeNumber:
+		n, ok := v.(json.Number)
+		if !ok {
+			return "", fmt.Errorf("parameter %s is not a number", name)
+		}
+		return n.String(), nil
+	case models.ParameterTypeBoolean:
+		b, ok := v.(bool)
+		if !ok {
+			return "", fmt.Errorf("parameter %s is not a boolean", name)
+		}
+		return strconv.FormatBool(b), nil
+	default:
+		return "", fmt.Errorf("parameter %s has unknown type %q", name, p.Type)
+	}
+}
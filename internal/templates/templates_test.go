@@ -0,0 +1,70 @@
+package templates
+
+import (
+	"github.com/go-faster/jx"
+	"github.com/stretchr/testify/require"
+	"requester/internal/models"
+	"testing"
+)
+
+func Test_Validate(t *testing.T) {
+	template := func(url string, parameters map[string]models.TemplateParameter) *models.RequestTemplate {
+		return &models.RequestTemplate{URL: url, Parameters: parameters}
+	}
+	require.NoError(t, Validate(template("https://example.com/{{var:id}}?at={{now_unix}}", map[string]models.TemplateParameter{
+		"id": {Type: models.ParameterTypeString, Pattern: `^\d+$`, Default: jx.Raw(`"1"`)},
+	})))
+
+	tests := []struct {
+		name     string
+		template *models.RequestTemplate
+	}{
+		{"undeclared", template("https://example.com/{{var:id}}", nil)},
+		{"invalid_name", template("https://example.com", map[string]models.TemplateParameter{"a b": {Type: models.ParameterTypeString}})},
+		{"unknown_type", template("https://example.com", map[string]models.TemplateParameter{"id": {Type: "object"}})},
+		{"invalid_pattern", template("https://example.com", map[string]models.TemplateParameter{"id": {Type: models.ParameterTypeString, Pattern: "("}})},
+		{"pattern_not_string", template("https://example.com", map[string]models.TemplateParameter{"id": {Type: models.ParameterTypeInteger, Pattern: `\d`}})},
+		{"invalid_default", template("https://example.com", map[string]models.TemplateParameter{"id": {Type: models.ParameterTypeInteger, Default: jx.Raw(`"1"`)}})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, Validate(tt.template))
+		})
+	}
+}
+
+func Test_Bind(t *testing.T) {
+	template := &models.RequestTemplate{Parameters: map[string]models.TemplateParameter{
+		"id":     {Type: models.ParameterTypeInteger, Required: true},
+		"name":   {Type: models.ParameterTypeString, Pattern: `^[a-z]+$`},
+		"amount": {Type: models.ParameterTypeNumber, Default: jx.Raw(`1.5`)},
+		"notify": {Type: models.ParameterTypeBoolean},
+	}}
+
+	values, err := Bind(template, map[string]jx.Raw{"id": jx.Raw(`7`), "name": jx.Raw(`"jo"`), "notify": jx.Raw(`true`)})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"id": "7", "name": "jo", "amount": "1.5", "notify": "true"}, values)
+
+	values, err = Bind(template, map[string]jx.Raw{"id": jx.Raw(`7`)})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"id": "7", "name": "", "amount": "1.5", "notify": ""}, values)
+
+	tests := []struct {
+		name   string
+		params map[string]jx.Raw
+		want   string
+	}{
+		{"missing", map[string]jx.Raw{}, "parameter id is required"},
+		{"unknown", map[string]jx.Raw{"id": jx.Raw(`7`), "x": jx.Raw(`1`)}, "parameter x is unknown"},
+		{"not_integer", map[string]jx.Raw{"id": jx.Raw(`7.5`)}, "parameter id is not an integer"},
+		{"not_string", map[string]jx.Raw{"id": jx.Raw(`7`), "name": jx.Raw(`1`)}, "parameter name is not a string"},
+		{"pattern", map[string]jx.Raw{"id": jx.Raw(`7`), "name": jx.Raw(`"Jo"`)}, `parameter name doesn't match "^[a-z]+$"`},
+		{"not_boolean", map[string]jx.Raw{"id": jx.Raw(`7`), "notify": jx.Raw(`"yes"`)}, "parameter notify is not a boolean"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Bind(template, tt.params)
+			require.EqualError(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package tlsprofile
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"requester/internal/models"
+)
+
+// DefaultMinVersion is the minimal TLS version of profiles without one.
+const DefaultMinVersion = "1.2"
+
+// versions maps profile TLS versions to crypto/tls versions.
+var versions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ClientConfig builds the TLS client config of the profile.
+// Returns an error if the certificates, the key or the version are invalid.
+func ClientConfig(profile *models.TLSProfile) (*tls.Config, error) {
+	minVersion := profile.MinVersion
+	if minVersion == "" {
+		minVersion = DefaultMinVersion
+	}
+	version, ok := versions[minVersion]
+	if !ok {
+		return nil, fmt.Errorf("unknown TLS version %q", minVersion)
+	}
+
+	cfg := &tls.Config{
+		MinVersion: version,
+		ServerName: profile.ServerName,
+	}
+
+	if profile.ClientCert != "" || profile.ClientKey != "" {
+		if profile.ClientCert == "" || profile.ClientKey == "" {
+			return nil, errors.New("client certificate and key must be set together")
+		}
+		cert, err := tls.X509KeyPair([]byte(profile.ClientCert), []byte(profile.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if profile.CABundle != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(profile.CABundle)) {
+			return nil, errors.New("CA bundle has no valid certificates")
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
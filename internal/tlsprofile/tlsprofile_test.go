@@ -0,0 +1,137 @@
+package tlsprofile
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/stretchr/testify/require"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"requester/internal/models"
+	"testing"
+	"time"
+)
+
+// testCert is a PEM-encoded certificate and key.
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM string
+	keyPEM  string
+}
+
+// newTestCert issues a certificate signed by the parent, self-signed if the parent is nil.
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+func Test_ClientConfig_mutualTLS(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+	server := newTestCert(t, "partner.internal", ca)
+	client := newTestCert(t, "requester", ca)
+
+	serverCert, err := tls.X509KeyPair([]byte(server.certPEM), []byte(server.keyPEM))
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	cfg, err := ClientConfig(&models.TLSProfile{
+		ClientCert: client.certPEM,
+		ClientKey:  client.keyPEM,
+		CABundle:   ca.certPEM,
+		MinVersion: "1.3",
+		ServerName: "partner.internal",
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint16(tls.VersionTLS13), cfg.MinVersion)
+
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	resp, err := httpClient.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "requester", string(body), "server must see the client certificate")
+
+	cfg, err = ClientConfig(&models.TLSProfile{CABundle: ca.certPEM, ServerName: "partner.internal"})
+	require.NoError(t, err)
+	httpClient = &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	_, err = httpClient.Get(srv.URL)
+	require.Error(t, err, "server requires a client certificate")
+}
+
+func Test_ClientConfig_invalid(t *testing.T) {
+	cert := newTestCert(t, "requester", nil)
+	other := newTestCert(t, "other", nil)
+
+	tests := []struct {
+		name    string
+		profile models.TLSProfile
+	}{
+		{"cert_without_key", models.TLSProfile{ClientCert: cert.certPEM}},
+		{"key_without_cert", models.TLSProfile{ClientKey: cert.keyPEM}},
+		{"mismatched_key", models.TLSProfile{ClientCert: cert.certPEM, ClientKey: other.keyPEM}},
+		{"invalid_ca_bundle", models.TLSProfile{CABundle: "not a certificate"}},
+		{"unknown_version", models.TLSProfile{MinVersion: "2.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ClientConfig(&tt.profile)
+			require.Error(t, err)
+		})
+	}
+}
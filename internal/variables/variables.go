@@ -0,0 +1,146 @@
+// Package variables renders task request templates: references to variables like {{var:name}}
+// and built-ins {{now}}, {{now_unix}}, {{task_id}} and {{uuid}} in URL, headers and body.
+package variables
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	"net/url"
+	"regexp"
+	"requester/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// referenceRe matches variable references like {{var:customer_id}} and built-in references like {{now}}.
+var referenceRe = regexp.MustCompile(`\{\{\s*(?:var:([A-Za-z0-9_.-]+)|(now|now_unix|task_id|uuid))\s*\}\}`)
+
+// Builtins are the values of built-in references of a single render.
+type Builtins struct {
+	// Render time, {{now}} is RFC 3339 and {{now_unix}} is Unix seconds
+	Now time.Time
+	// {{task_id}}
+	TaskID uuid.UUID
+	// {{uuid}}, the same for all references of a single render
+	UUID uuid.UUID
+}
+
+// NewBuiltins returns the built-ins of the task rendered now.
+func NewBuiltins(taskID uuid.UUID) Builtins {
+	return Builtins{Now: time.Now(), TaskID: taskID, UUID: uuid.New()}
+}
+
+// value returns the value of the built-in reference.
+func (b Builtins) value(name string) string {
+	switch name {
+	case "now":
+		return b.Now.UTC().Format(time.RFC3339)
+	case "now_unix":
+		return strconv.FormatInt(b.Now.Unix(), 10)
+	case "task_id":
+		return b.TaskID.String()
+	default:
+		return b.UUID.String()
+	}
+}
+
+// Validate checks that all variables referenced in the task URL, headers and body are defined.
+func Validate(task *models.Task, values map[string]string) error {
+	_, err := Render(task, values, Builtins{})
+	return err
+}
+
+// Render returns a copy of the task with variable and built-in references in URL, headers and body
+// replaced with their values. Values are escaped by their position in the URL, inserted as is
+// in headers and JSON-escaped in the body.
+// Returns an error if a referenced variable is undefined.
+func Render(task *models.Task, values map[string]string, builtins Builtins) (*models.Task, error) {
+	rendered := *task
+	lookup := func(groups []string) (string, error) {
+		if groups[2] != "" {
+			return builtins.value(groups[2]), nil
+		}
+		value, ok := values[groups[1]]
+		if !ok {
+			return "", fmt.Errorf("variable %s is undefined", groups[1])
+		}
+		return value, nil
+	}
+
+	var err error
+	if rendered.URL, err = render(task.URL, lookup, EscapeURL); err != nil {
+		return nil, err
+	}
+
+	if task.Headers != nil {
+		rendered.Headers = make(map[string]string, len(task.Headers))
+		for k, v := range task.Headers {
+			if rendered.Headers[k], err = render(v, lookup, noEscape); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if task.Body != nil {
+		rendered.Body = make(map[string]jx.Raw, len(task.Body))
+		for k, v := range task.Body {
+			value, err := render(string(v), lookup, jsonEscape)
+			if err != nil {
+				return nil, err
+			}
+			rendered.Body[k] = jx.Raw(value)
+		}
+	}
+
+	return &rendered, nil
+}
+
+// render replaces references in the string with values escaped by the escape function.
+func render(s string, lookup func(groups []string) (string, error), escape escaper) (string, error) {
+	var b strings.Builder
+	last := 0
+	for _, loc := range referenceRe.FindAllStringSubmatchIndex(s, -1) {
+		groups := make([]string, len(loc)/2)
+		for i := range groups {
+			if loc[2*i] >= 0 {
+				groups[i] = s[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		value, err := lookup(groups)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(s[last:loc[0]])
+		b.WriteString(escape(s[:loc[0]], value))
+		last = loc[1]
+	}
+	b.WriteString(s[last:])
+	return b.String(), nil
+}
+
+// escaper escapes the value of a reference preceded by the prefix of the template.
+type escaper func(prefix, value string) string
+
+// noEscape returns the value as is.
+func noEscape(_, value string) string {
+	return value
+}
+
+// jsonEscape escapes the value to be placed inside a JSON string.
+func jsonEscape(_, value string) string {
+	escaped, _ := json.Marshal(value)
+	return string(escaped[1 : len(escaped)-1])
+}
+
+// EscapeURL escapes the value of a reference preceded by the prefix of the URL template, so that
+// it can't change the request target: query values are query-escaped, the rest is path-escaped.
+// References in the URL host are rejected at creation, so they aren't handled.
+func EscapeURL(prefix, value string) string {
+	if strings.Contains(prefix, "?") && !strings.Contains(prefix, "#") {
+		return url.QueryEscape(value)
+	}
+	return url.PathEscape(value)
+}
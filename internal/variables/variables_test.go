@@ -0,0 +1,68 @@
+package variables
+
+import (
+	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"requester/internal/models"
+	"testing"
+	"time"
+)
+
+func Test_Render(t *testing.T) {
+	task := &models.Task{
+		URL:     "https://example.com/subscribers/{{ var:id }}?at={{now_unix}}",
+		Headers: map[string]string{"Idempotency-Key": "{{uuid}}", "X-Task": "{{task_id}}"},
+		Body: map[string]jx.Raw{
+			"name":  jx.Raw(`"{{var:name}}"`),
+			"sent":  jx.Raw(`"{{now}}"`),
+			"key":   jx.Raw(`"{{uuid}}"`),
+			"fixed": jx.Raw(`1`),
+		},
+	}
+	values := map[string]string{"id": "7", "name": `"Jo"`}
+	builtins := Builtins{
+		Now:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		TaskID: uuid.New(),
+		UUID:   uuid.New(),
+	}
+
+	rendered, err := Render(task, values, builtins)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/subscribers/7?at=1714564800", rendered.URL)
+	require.Equal(t, map[string]string{
+		"Idempotency-Key": builtins.UUID.String(),
+		"X-Task":          builtins.TaskID.String(),
+	}, rendered.Headers)
+	require.Equal(t, map[string]jx.Raw{
+		"name":  jx.Raw(`"\"Jo\""`),
+		"sent":  jx.Raw(`"2024-05-01T12:00:00Z"`),
+		"key":   jx.Raw(`"` + builtins.UUID.String() + `"`),
+		"fixed": jx.Raw(`1`),
+	}, rendered.Body)
+	require.Equal(t, "https://example.com/subscribers/{{ var:id }}?at={{now_unix}}", task.URL, "task is unchanged")
+
+	require.NoError(t, Validate(task, values))
+	require.EqualError(t, Validate(task, map[string]string{"id": "7"}), "variable name is undefined")
+}
+
+func Test_Render_url(t *testing.T) {
+	tests := []struct {
+		name  string
+		url   string
+		value string
+		want  string
+	}{
+		{"path", "https://example.com/items/{{var:v}}", "a/../b?c#d", "https://example.com/items/a%2F..%2Fb%3Fc%23d"},
+		{"query", "https://example.com/items?q={{var:v}}", "a&b=c#d", "https://example.com/items?q=a%26b%3Dc%23d"},
+		{"fragment", "https://example.com/items#{{var:v}}", "a b", "https://example.com/items#a%20b"},
+		{"fragment after query", "https://example.com/items?q=1#{{var:v}}", "a b", "https://example.com/items?q=1#a%20b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := Render(&models.Task{URL: tt.url}, map[string]string{"v": tt.value}, Builtins{})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, rendered.URL)
+		})
+	}
+}
@@ -0,0 +1,236 @@
+// Package workflow plans workflows: orders their steps, assigns step tasks IDs and parents
+// and rewrites step references.
+package workflow
+
+import (
+	"fmt"
+	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	"regexp"
+	"requester/internal/dependency"
+	"requester/internal/models"
+)
+
+var (
+	// nameRe matches valid step names.
+	nameRe = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+	// stepRefRe matches step references like {{step:login.token}}.
+	stepRefRe = regexp.MustCompile(`\{\{\s*step:([A-Za-z0-9_-]+)\.([A-Za-z0-9_.-]+)\s*\}\}`)
+)
+
+// Step is a step of a workflow definition.
+type Step struct {
+	// Step name, unique within the workflow
+	Name string
+	// Names of the steps which must be done before the step is sent
+	DependsOn []string
+	// Condition on a value extracted by a parent step, nil if not used
+	Condition *Condition
+	// Request of the step, Plan sets its ID, parents and condition
+	Task *models.Task
+}
+
+// Condition is a condition of a step on a value extracted by a parent step.
+type Condition struct {
+	// Name of the parent step
+	Step string
+	// Name of the value extracted by the parent step
+	Value string
+	// JSON value the extracted value must equal, if set
+	Equals jx.Raw
+	// JSON value the extracted value must not equal, if set
+	NotEquals jx.Raw
+}
+
+// Plan validates the steps and returns them ordered so that parents precede their dependents,
+// steps without dependencies between them keep their order.
+// Step tasks get new IDs and parents and step references like {{step:<name>.<value>}}
+// are rewritten to parent references.
+func Plan(steps []Step) ([]Step, error) {
+	byName := make(map[string]*Step, len(steps))
+	for i := range steps {
+		step := &steps[i]
+		if !nameRe.MatchString(step.Name) {
+			return nil, fmt.Errorf("invalid step name %q", step.Name)
+		}
+		if _, ok := byName[step.Name]; ok {
+			return nil, fmt.Errorf("duplicate step %s", step.Name)
+		}
+		if len(dependency.References(step.Task)) > 0 {
+			return nil, fmt.Errorf("step %s references a parent task, use step references", step.Name)
+		}
+		byName[step.Name] = step
+	}
+
+	ordered, err := order(steps, byName)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]uuid.UUID, len(ordered))
+	for i := range ordered {
+		step := &ordered[i]
+		task := *step.Task
+		task.ID = uuid.New()
+		ids[step.Name] = task.ID
+
+		task.DependsOn = nil
+		for _, name := range step.DependsOn {
+			task.DependsOn = append(task.DependsOn, ids[name])
+		}
+
+		if step.Condition != nil {
+			if task.Condition, err = condition(step, byName, ids); err != nil {
+				return nil, err
+			}
+		}
+
+		rewriteStep := func(groups []string) (string, error) {
+			name, value := groups[1], groups[2]
+			if err := checkParentValue(step, byName, name, value); err != nil {
+				return "", err
+			}
+			return "{{parent:" + ids[name].String() + "." + value + "}}", nil
+		}
+		if err = rewriteTask(&task, rewriteStep); err != nil {
+			return nil, err
+		}
+
+		step.Task = &task
+	}
+	return ordered, nil
+}
+
+// order returns the steps ordered so that parents precede their dependents.
+// Returns an error if a parent is unknown or the dependencies have a cycle.
+func order(steps []Step, byName map[string]*Step) ([]Step, error) {
+	for _, step := range steps {
+		seen := make(map[string]bool, len(step.DependsOn))
+		for _, name := range step.DependsOn {
+			if _, ok := byName[name]; !ok {
+				return nil, fmt.Errorf("step %s depends on unknown step %s", step.Name, name)
+			}
+			if seen[name] || name == step.Name {
+				return nil, fmt.Errorf("step %s has invalid dependency %s", step.Name, name)
+			}
+			seen[name] = true
+		}
+	}
+
+	ordered := make([]Step, 0, len(steps))
+	placed := make(map[string]bool, len(steps))
+	for len(ordered) < len(steps) {
+		progressed := false
+		for _, step := range steps {
+			if placed[step.Name] || !allPlaced(step.DependsOn, placed) {
+				continue
+			}
+			ordered = append(ordered, step)
+			placed[step.Name] = true
+			progressed = true
+		}
+		if !progressed {
+			return nil, fmt.Errorf("steps have a dependency cycle")
+		}
+	}
+	return ordered, nil
+}
+
+// allPlaced reports whether all the named steps are placed.
+func allPlaced(names []string, placed map[string]bool) bool {
+	for _, name := range names {
+		if !placed[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// condition converts the step condition to the condition of its task.
+func condition(step *Step, byName map[string]*Step, ids map[string]uuid.UUID) (*models.Condition, error) {
+	c := step.Condition
+	if err := checkParentValue(step, byName, c.Step, c.Value); err != nil {
+		return nil, err
+	}
+	taskCondition := &models.Condition{
+		ParentID:  ids[c.Step],
+		Value:     c.Value,
+		Equals:    c.Equals,
+		NotEquals: c.NotEquals,
+	}
+	if err := dependency.ValidateCondition(taskCondition); err != nil {
+		return nil, fmt.Errorf("step %s: %w", step.Name, err)
+	}
+	return taskCondition, nil
+}
+
+// checkParentValue checks that the named step is a parent of the step which extracts the value.
+func checkParentValue(step *Step, byName map[string]*Step, name, value string) error {
+	if !contains(step.DependsOn, name) {
+		return fmt.Errorf("step %s references step %s which is not its parent", step.Name, name)
+	}
+	if _, ok := byName[name].Task.Extract[value]; !ok {
+		return fmt.Errorf("step %s references value %s which isn't extracted by step %s", step.Name, value, name)
+	}
+	return nil
+}
+
+// rewriteTask rewrites the step references in the task URL, headers and body.
+func rewriteTask(task *models.Task, rewrite func(groups []string) (string, error)) error {
+	var err error
+	if task.URL, err = replace(task.URL, stepRefRe, rewrite); err != nil {
+		return err
+	}
+
+	if task.Headers != nil {
+		headers := make(map[string]string, len(task.Headers))
+		for k, v := range task.Headers {
+			if headers[k], err = replace(v, stepRefRe, rewrite); err != nil {
+				return err
+			}
+		}
+		task.Headers = headers
+	}
+
+	if task.Body != nil {
+		body := make(map[string]jx.Raw, len(task.Body))
+		for k, v := range task.Body {
+			value, err := replace(string(v), stepRefRe, rewrite)
+			if err != nil {
+				return err
+			}
+			body[k] = jx.Raw(value)
+		}
+		task.Body = body
+	}
+	return nil
+}
+
+// replace replaces the matches of the expression in the string.
+func replace(s string, re *regexp.Regexp, replacement func(groups []string) (string, error)) (string, error) {
+	var err error
+	replaced := re.ReplaceAllStringFunc(s, func(match string) string {
+		if err != nil {
+			return match
+		}
+		var value string
+		if value, err = replacement(re.FindStringSubmatch(match)); err != nil {
+			return match
+		}
+		return value
+	})
+	if err != nil {
+		return "", err
+	}
+	return replaced, nil
+}
+
+// contains reports whether the values contain the value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package workflow
+
+import (
+	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"requester/internal/models"
+	"testing"
+)
+
+func Test_Plan(t *testing.T) {
+	steps := []Step{
+		{
+			Name:      "charge",
+			DependsOn: []string{"login", "order"},
+			Condition: &Condition{Step: "order", Value: "state", Equals: jx.Raw(`"new"`)},
+			Task: &models.Task{
+				URL:     "https://example.com/orders/{{step:order.id}}/charge",
+				Headers: map[string]string{"Authorization": "Bearer {{step:login.token}}"},
+				Body:    map[string]jx.Raw{"note": jx.Raw(`"{{var:note}}"`)},
+			},
+		},
+		{
+			Name:      "order",
+			DependsOn: []string{"login"},
+			Task: &models.Task{
+				URL:     "https://example.com/customers/{{var:customer}}/orders",
+				Extract: map[string]string{"id": "$.id", "state": "$.state"},
+			},
+		},
+		{
+			Name: "login",
+			Task: &models.Task{URL: "https://example.com/sessions", Extract: map[string]string{"token": "$.token"}},
+		},
+	}
+
+	planned, err := Plan(steps)
+	require.NoError(t, err)
+	require.Len(t, planned, 3)
+	login, order, charge := planned[0], planned[1], planned[2]
+	require.Equal(t, []string{"login", "order", "charge"}, []string{login.Name, order.Name, charge.Name})
+
+	require.Empty(t, login.Task.DependsOn)
+	require.Equal(t, "https://example.com/customers/{{var:customer}}/orders", order.Task.URL, "variables are rendered on send")
+	require.Equal(t, []uuid.UUID{login.Task.ID}, order.Task.DependsOn)
+	require.Equal(t, []uuid.UUID{login.Task.ID, order.Task.ID}, charge.Task.DependsOn)
+	require.Equal(t, "https://example.com/orders/{{parent:"+order.Task.ID.String()+".id}}/charge", charge.Task.URL)
+	require.Equal(t, "Bearer {{parent:"+login.Task.ID.String()+".token}}", charge.Task.Headers["Authorization"])
+	require.Equal(t, jx.Raw(`"{{var:note}}"`), charge.Task.Body["note"])
+	require.Equal(t, &models.Condition{ParentID: order.Task.ID, Value: "state", Equals: jx.Raw(`"new"`)}, charge.Task.Condition)
+	require.Equal(t, "https://example.com/orders/{{step:order.id}}/charge", steps[0].Task.URL, "input steps are unchanged")
+}
+
+func Test_Plan_invalid(t *testing.T) {
+	task := func(url string) *models.Task {
+		return &models.Task{URL: url, Extract: map[string]string{"id": "$.id"}}
+	}
+	tests := []struct {
+		name  string
+		steps []Step
+	}{
+		{"invalid_name", []Step{{Name: "a b", Task: task("https://example.com")}}},
+		{"duplicate", []Step{{Name: "a", Task: task("https://example.com")}, {Name: "a", Task: task("https://example.com")}}},
+		{"unknown_parent", []Step{{Name: "a", DependsOn: []string{"b"}, Task: task("https://example.com")}}},
+		{"self", []Step{{Name: "a", DependsOn: []string{"a"}, Task: task("https://example.com")}}},
+		{"cycle", []Step{
+			{Name: "a", DependsOn: []string{"b"}, Task: task("https://example.com")},
+			{Name: "b", DependsOn: []string{"a"}, Task: task("https://example.com")},
+		}},
+		{"not_parent", []Step{
+			{Name: "a", Task: task("https://example.com")},
+			{Name: "b", Task: task("https://example.com/{{step:a.id}}")},
+		}},
+		{"not_extracted", []Step{
+			{Name: "a", Task: task("https://example.com")},
+			{Name: "b", DependsOn: []string{"a"}, Task: task("https://example.com/{{step:a.token}}")},
+		}},
+		{"parent_reference", []Step{{Name: "a", Task: task("https://example.com/{{parent:6f1c4f6e-3a0b-4b8e-9c1d-2b7f0e5ae2a4.id}}")}}},
+		{"invalid_condition", []Step{
+			{Name: "a", Task: task("https://example.com")},
+			{Name: "b", DependsOn: []string{"a"}, Condition: &Condition{Step: "a", Value: "id"}, Task: task("https://example.com")},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Plan(tt.steps)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks ADD COLUMN client_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+CREATE INDEX tasks_client_id_status_idx ON tasks (client_id, status);
+
+CREATE TABLE client_limits (
+    client_id TEXT PRIMARY KEY,
+    requests_per_second INTEGER,
+    max_outstanding_tasks INTEGER,
+    daily_task_quota INTEGER
+);
+
+CREATE TABLE client_rate_counters (
+    client_id TEXT NOT NULL,
+    bucket TEXT NOT NULL,
+    window_start TIMESTAMPTZ NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (client_id, bucket, window_start)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE client_rate_counters;
+DROP TABLE client_limits;
+DROP INDEX tasks_client_id_status_idx;
+ALTER TABLE tasks DROP COLUMN created_at;
+ALTER TABLE tasks DROP COLUMN client_id;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE host_limits (
+    host TEXT PRIMARY KEY,
+    max_concurrent INTEGER,
+    requests_per_second DOUBLE PRECISION,
+    burst INTEGER
+);
+
+CREATE TABLE host_slots (
+    host TEXT NOT NULL,
+    task_id UUID NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (host, task_id)
+);
+
+CREATE TABLE host_rate_buckets (
+    host TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE host_rate_buckets;
+DROP TABLE host_slots;
+DROP TABLE host_limits;
+-- +goose StatementEnd
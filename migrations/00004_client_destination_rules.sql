@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE client_destination_rules (
+    client_id TEXT PRIMARY KEY,
+    allow_hosts TEXT[] NOT NULL DEFAULT '{}',
+    deny_hosts TEXT[] NOT NULL DEFAULT '{}',
+    allow_cidrs TEXT[] NOT NULL DEFAULT '{}',
+    deny_cidrs TEXT[] NOT NULL DEFAULT '{}'
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE client_destination_rules;
+-- +goose StatementEnd
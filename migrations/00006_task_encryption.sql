@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks ADD COLUMN key_id TEXT;
+ALTER TABLE tasks ADD COLUMN data_key BYTEA;
+ALTER TABLE tasks ADD COLUMN headers_encrypted BYTEA;
+ALTER TABLE tasks ADD COLUMN body_encrypted BYTEA;
+ALTER TABLE tasks ADD COLUMN response_headers_encrypted BYTEA;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tasks DROP COLUMN response_headers_encrypted;
+ALTER TABLE tasks DROP COLUMN body_encrypted;
+ALTER TABLE tasks DROP COLUMN headers_encrypted;
+ALTER TABLE tasks DROP COLUMN data_key;
+ALTER TABLE tasks DROP COLUMN key_id;
+-- +goose StatementEnd
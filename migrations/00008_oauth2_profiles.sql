@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE oauth2_profiles (
+    client_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    token_url TEXT NOT NULL,
+    oauth2_client_id TEXT NOT NULL,
+    client_secret_name TEXT NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (client_id, name)
+);
+ALTER TABLE tasks ADD COLUMN oauth2_profile TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tasks DROP COLUMN oauth2_profile;
+DROP TABLE oauth2_profiles;
+-- +goose StatementEnd
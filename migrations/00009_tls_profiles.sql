@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tls_profiles (
+    client_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    client_cert TEXT NOT NULL DEFAULT '',
+    client_key BYTEA,
+    ca_bundle TEXT NOT NULL DEFAULT '',
+    min_version TEXT NOT NULL DEFAULT '',
+    server_name TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (client_id, name)
+);
+ALTER TABLE tasks ADD COLUMN tls_profile TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tasks DROP COLUMN tls_profile;
+DROP TABLE tls_profiles;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks ADD COLUMN proxy_encrypted BYTEA;
+ALTER TABLE tasks ADD COLUMN used_proxy TEXT;
+ALTER TABLE tls_profiles ADD COLUMN proxy_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE tls_profiles ADD COLUMN proxy_username TEXT NOT NULL DEFAULT '';
+ALTER TABLE tls_profiles ADD COLUMN proxy_password BYTEA;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tls_profiles DROP COLUMN proxy_password;
+ALTER TABLE tls_profiles DROP COLUMN proxy_username;
+ALTER TABLE tls_profiles DROP COLUMN proxy_url;
+ALTER TABLE tasks DROP COLUMN used_proxy;
+ALTER TABLE tasks DROP COLUMN proxy_encrypted;
+-- +goose StatementEnd
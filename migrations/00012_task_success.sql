@@ -0,0 +1,10 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE task_status ADD VALUE IF NOT EXISTS 'failed';
+ALTER TABLE tasks ADD COLUMN success JSONB;
+ALTER TABLE tasks ADD COLUMN failed_assertion TEXT;
+
+-- +goose Down
+UPDATE tasks SET status = 'error' WHERE status = 'failed';
+ALTER TABLE tasks DROP COLUMN failed_assertion;
+ALTER TABLE tasks DROP COLUMN success;
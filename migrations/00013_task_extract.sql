@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks ADD COLUMN extract JSONB;
+ALTER TABLE tasks ADD COLUMN extracted_encrypted BYTEA;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tasks DROP COLUMN extracted_encrypted;
+ALTER TABLE tasks DROP COLUMN extract;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE task_status ADD VALUE IF NOT EXISTS 'waiting';
+ALTER TYPE task_status ADD VALUE IF NOT EXISTS 'skipped';
+CREATE TABLE task_dependencies (
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    parent_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, parent_id)
+);
+CREATE INDEX task_dependencies_parent_id_idx ON task_dependencies (parent_id);
+
+-- +goose Down
+DROP TABLE task_dependencies;
+UPDATE tasks SET status = 'error' WHERE status IN ('waiting', 'skipped');
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE workflows (
+    id UUID PRIMARY KEY,
+    client_id TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE TABLE workflow_steps (
+    workflow_id UUID NOT NULL REFERENCES workflows (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    PRIMARY KEY (workflow_id, name)
+);
+ALTER TABLE tasks ADD COLUMN condition JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tasks DROP COLUMN condition;
+DROP TABLE workflow_steps;
+DROP TABLE workflows;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE task_groups (
+    id UUID PRIMARY KEY,
+    client_id TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+ALTER TABLE tasks ADD COLUMN group_id UUID REFERENCES task_groups (id) ON DELETE SET NULL;
+CREATE INDEX tasks_group_id_idx ON tasks (group_id) WHERE group_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tasks DROP COLUMN group_id;
+DROP TABLE task_groups;
+-- +goose StatementEnd
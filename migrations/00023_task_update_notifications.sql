@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE FUNCTION notify_task_update() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify(
+        'task_updates',
+        json_build_object('id', NEW.id, 'client_id', NEW.client_id, 'status', NEW.status)::text
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER tasks_notify_update
+    AFTER UPDATE OF status ON tasks
+    FOR EACH ROW EXECUTE FUNCTION notify_task_update();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER tasks_notify_update ON tasks;
+DROP FUNCTION notify_task_update();
+-- +goose StatementEnd